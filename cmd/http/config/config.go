@@ -4,15 +4,28 @@ import (
 	"io"
 	"log"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Port     string   `yaml:"port"`
-	Domain   string   `yaml:"domain"`
-	Postgres Postgres `yaml:"postgres"`
-	Redis    Redis    `yaml:"redis"`
+	Port       string     `yaml:"port"`
+	Domain     string     `yaml:"domain"`
+	Postgres   Postgres   `yaml:"postgres"`
+	Redis      Redis      `yaml:"redis"`
+	WebSocket  WebSocket  `yaml:"websocket"`
+	Timeline   Timeline   `yaml:"timeline"`
+	Tweet      Tweet      `yaml:"tweet"`
+	Media      Media      `yaml:"media"`
+	Unfurl     Unfurl     `yaml:"unfurl"`
+	Moderation Moderation `yaml:"moderation"`
+	Admin      Admin      `yaml:"admin"`
+	Tracing    Tracing    `yaml:"tracing"`
+	Logging    Logging    `yaml:"logging"`
+	Server     Server     `yaml:"server"`
+	Resilience Resilience `yaml:"resilience"`
+	RateLimit  RateLimit  `yaml:"rate_limit"`
 }
 
 type Postgres struct {
@@ -32,6 +45,178 @@ type Redis struct {
 	DB       int    `yaml:"db"`
 }
 
+type WebSocket struct {
+	MaxConnectionsPerUser int `yaml:"max_connections_per_user"`
+}
+
+type Timeline struct {
+	MaxNewTweetsCount int           `yaml:"max_new_tweets_count"`
+	MaxTweetsCached   int           `yaml:"max_tweets_cached"`
+	TTL               time.Duration `yaml:"ttl"`
+	FanOutBatchSize   int           `yaml:"fan_out_batch_size"`
+	FanOutWorkers     int           `yaml:"fan_out_workers"`
+	FanOutMaxRetries  int           `yaml:"fan_out_max_retries"`
+
+	FanOutRecoveryInterval time.Duration `yaml:"fan_out_recovery_interval"`
+	FanOutMaxAttempts      int           `yaml:"fan_out_max_attempts"`
+}
+
+type Tweet struct {
+	// EditWindow is how long after its creation the author can edit a tweet.
+	EditWindow time.Duration `yaml:"edit_window"`
+}
+
+// Media blob stores.
+const (
+	MediaStoreLocal = "local"
+	MediaStoreS3    = "s3"
+)
+
+type Media struct {
+	// MaxSize is the largest upload accepted, in bytes.
+	MaxSize int64 `yaml:"max_size"`
+	// AllowedTypes are the content types accepted, e.g. image/png.
+	AllowedTypes []string `yaml:"allowed_types"`
+	// Store is local (default), files under Local.Dir, or s3, any S3-compatible object storage.
+	Store string     `yaml:"store"`
+	Local LocalStore `yaml:"local"`
+	S3    S3Store    `yaml:"s3"`
+}
+
+type LocalStore struct {
+	Dir string `yaml:"dir"`
+}
+
+type S3Store struct {
+	// Endpoint is the host[:port] of the object storage, e.g. s3.amazonaws.com or localhost:9000.
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+	UseSSL    bool   `yaml:"use_ssl"`
+}
+
+type Unfurl struct {
+	// FetchTimeout bounds the fetch of a linked page, redirects included.
+	FetchTimeout time.Duration `yaml:"fetch_timeout"`
+	// MaxBodySize is how many bytes of a page are read looking for its metadata.
+	MaxBodySize int64 `yaml:"max_body_size"`
+	// RefreshAfter is how long a stored preview is used before the page is fetched again.
+	RefreshAfter         time.Duration `yaml:"refresh_after"`
+	MaxConcurrentFetches int           `yaml:"max_concurrent_fetches"`
+	// AllowPrivateNetworks lets the unfurler fetch loopback and private addresses. Only for
+	// local development: the linked URLs come from the users.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+type Moderation struct {
+	// ReviewQueueSize is how many held tweets the review queue returns at once.
+	ReviewQueueSize int                `yaml:"review_queue_size"`
+	BannedWords     BannedWordsRule    `yaml:"banned_words"`
+	BlockedDomains  BlockedDomainsRule `yaml:"blocked_domains"`
+	DuplicateBurst  DuplicateBurstRule `yaml:"duplicate_burst"`
+}
+
+// Every moderation rule has an Action, one of reject, hold or shadow_limit, taken on the
+// tweets breaking it. A rule without action is off.
+
+type BannedWordsRule struct {
+	Action string `yaml:"action"`
+	// Words are words or phrases, matched as whole words whatever their case, accents or
+	// look-alike characters.
+	Words []string `yaml:"words"`
+}
+
+type BlockedDomainsRule struct {
+	Action string `yaml:"action"`
+	// Domains are blocked with their subdomains.
+	Domains []string `yaml:"domains"`
+}
+
+type DuplicateBurstRule struct {
+	Action string `yaml:"action"`
+	// MaxDuplicates is how many tweets with the same text an author can publish within
+	// Window before the next one breaks the rule.
+	MaxDuplicates int           `yaml:"max_duplicates"`
+	Window        time.Duration `yaml:"window"`
+}
+
+type Admin struct {
+	// UserIDs are the users allowed to call the /api/v1/admin routes. Nobody is when empty.
+	UserIDs []string `yaml:"user_ids"`
+}
+
+type Tracing struct {
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector. Tracing is disabled when empty.
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	Insecure     bool    `yaml:"insecure"`
+	ServiceName  string  `yaml:"service_name"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+}
+
+type Server struct {
+	// HealthCheckTimeout bounds each dependency ping of /healthz and /readyz.
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout"`
+	// DrainDelay is how long /readyz fails before the server stops accepting connections,
+	// so load balancers notice and stop routing to the instance.
+	DrainDelay time.Duration `yaml:"drain_delay"`
+	// ShutdownTimeout bounds the wait for in-flight requests on shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type Resilience struct {
+	Postgres ResiliencePolicy `yaml:"postgres"`
+	Redis    ResiliencePolicy `yaml:"redis"`
+}
+
+// ResiliencePolicy holds the timeouts, retries and circuit breaker of the calls to a dependency.
+type ResiliencePolicy struct {
+	Timeout time.Duration `yaml:"timeout"`
+	// OperationTimeouts overrides Timeout by repository method name, e.g. RebuildList.
+	OperationTimeouts map[string]time.Duration `yaml:"operation_timeouts"`
+	MaxRetries        int                      `yaml:"max_retries"`
+	RetryBackoff      time.Duration            `yaml:"retry_backoff"`
+	MaxRetryBackoff   time.Duration            `yaml:"max_retry_backoff"`
+	// BreakerFailures consecutive transient errors open the circuit breaker for BreakerCooldown.
+	BreakerFailures int           `yaml:"breaker_failures"`
+	BreakerCooldown time.Duration `yaml:"breaker_cooldown"`
+}
+
+// Rate limit stores.
+const (
+	RateLimitStoreRedis  = "redis"
+	RateLimitStoreMemory = "memory"
+)
+
+type RateLimit struct {
+	// Store is redis, shared by every instance (default), or memory, per instance.
+	Store string `yaml:"store"`
+	// TrustForwardedFor takes the client IP from X-Forwarded-For. Only enable it behind a load
+	// balancer that sets the header, otherwise clients can pick their own IP.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for"`
+	// Routes holds the limits by route pattern, e.g. /api/v1/tweet. Routes not listed aren't limited.
+	Routes map[string]RouteRateLimit `yaml:"routes"`
+}
+
+type RouteRateLimit struct {
+	PerUser Limit `yaml:"per_user"`
+	PerIP   Limit `yaml:"per_ip"`
+}
+
+// Limit allows Requests per Window.
+type Limit struct {
+	Requests int           `yaml:"requests"`
+	Window   time.Duration `yaml:"window"`
+}
+
+type Logging struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format"`
+}
+
 func LoadConfig() Config {
 	file, err := os.Open("cmd/http/config/local.yml")
 	if err != nil {
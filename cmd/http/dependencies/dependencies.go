@@ -1,44 +1,224 @@
 package dependencies
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"net/http"
 
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/admin"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/health"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/media"
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/reader"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/stream"
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/httpfetch"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/localfs"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/postgres"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/redis"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/s3"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+	"github.com/renzonaitor/tweet-api/internal/ratelimit"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	healthservice "github.com/renzonaitor/tweet-api/internal/service/health"
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl"
 	"github.com/renzonaitor/tweet-api/internal/service/user"
 )
 
 type Dependencies struct {
 	WriterHandler writer.WriterHandler
 	ReaderHandler reader.ReaderHandler
+	StreamHandler stream.StreamHandler
+	AdminHandler  admin.AdminHandler
+	HealthHandler health.HealthHandler
+	MediaHandler  media.MediaHandler
+
+	// RateLimit returns the middleware enforcing the rate limits configured for route.
+	RateLimit func(route string) func(http.Handler) http.Handler
+	// AdminOnly rejects the requests of the users that aren't admins.
+	AdminOnly func(http.Handler) http.Handler
+
+	// Health is drained on shutdown, so readiness fails while the in-flight requests finish.
+	Health *healthservice.Service
+	// Close releases the PostgreSQL and Redis connection pools.
+	Close func()
 }
 
-func InitDependencies(cfg config.Config) Dependencies {
+// InitDependencies wires every layer. The background workers (event subscription, fan-out
+// recovery) run until ctx is cancelled.
+func InitDependencies(ctx context.Context, cfg config.Config, logger *slog.Logger) Dependencies {
 
 	// repository layer
-	postgresRepo, err := postgres.NewRepository(cfg)
+	postgresRepo, err := postgres.NewRepository(cfg, logger)
 	if err != nil {
 		panic(fmt.Sprintf("failed to connect a postgres: %s", err.Error()))
 	}
-	redisRepo, err := redis.NewRepository(cfg)
+	redisRepo, err := redis.NewRepository(cfg, logger)
 	if err != nil {
 		panic(fmt.Sprintf("failed to connect a redis: %s", err.Error()))
 	}
+	metrics.RegisterPoolStats(postgresRepo.Stats, redisRepo.PoolStats)
+
+	// The media bytes go to the local filesystem unless an S3-compatible store is picked.
+	var blobStore mediaservice.BlobStore
+	switch cfg.Media.Store {
+	case "", config.MediaStoreLocal:
+		blobStore, err = localfs.NewBlobStore(cfg, logger)
+	case config.MediaStoreS3:
+		blobStore, err = s3.NewBlobStore(cfg, logger)
+	default:
+		panic(fmt.Sprintf("unknown media store %q", cfg.Media.Store))
+	}
+	if err != nil {
+		panic(fmt.Sprintf("failed to set up the media store: %s", err.Error()))
+	}
+
+	// resilience layer: one policy per dependency, so its circuit breaker sees every call.
+	postgresPolicy := resilience.NewPolicy(resilience.Postgres, resilienceConfig(cfg.Resilience.Postgres))
+	redisPolicy := resilience.NewPolicy(resilience.Redis, resilienceConfig(cfg.Resilience.Redis))
+	timelineStorage := resilience.NewTimelineStorage(postgresRepo, postgresPolicy)
+	timelineCache := resilience.NewTimelineCache(redisRepo, redisPolicy)
+	userStorage := resilience.NewUserStorage(postgresRepo, postgresPolicy)
+	mediaStorage := resilience.NewMediaStorage(postgresRepo, postgresPolicy)
+	unfurlStorage := resilience.NewUnfurlStorage(postgresRepo, postgresPolicy)
+	moderationStorage := resilience.NewModerationStorage(postgresRepo, postgresPolicy)
+	reviewStorage := resilience.NewReviewStorage(postgresRepo, postgresPolicy)
 
 	// service layer
-	timelineService := timeline.NewService(postgresRepo, redisRepo)
-	userService := user.NewService(postgresRepo, timelineService)
+	timelineService := timeline.NewService(timelineStorage, timelineCache, timeline.Config{
+		MaxNewTweetsCount: cfg.Timeline.MaxNewTweetsCount,
+		MaxTweetsCached:   cfg.Timeline.MaxTweetsCached,
+		TimelineTTL:       cfg.Timeline.TTL,
+		FanOutBatchSize:   cfg.Timeline.FanOutBatchSize,
+		FanOutWorkers:     cfg.Timeline.FanOutWorkers,
+		FanOutMaxRetries:  cfg.Timeline.FanOutMaxRetries,
+
+		FanOutRecoveryInterval: cfg.Timeline.FanOutRecoveryInterval,
+		FanOutMaxAttempts:      cfg.Timeline.FanOutMaxAttempts,
+	}, logger)
+	unfurlService := unfurl.NewService(unfurlStorage, httpfetch.NewClient(cfg, logger), unfurl.Config{
+		FetchTimeout:         cfg.Unfurl.FetchTimeout,
+		MaxBodySize:          cfg.Unfurl.MaxBodySize,
+		RefreshAfter:         cfg.Unfurl.RefreshAfter,
+		MaxConcurrentFetches: cfg.Unfurl.MaxConcurrentFetches,
+	}, logger)
+	moderator := moderation.NewModerator(logger, moderationPolicies(cfg.Moderation, moderationStorage)...)
+	userService := user.NewService(userStorage, timelineService, unfurlService, moderator, timelineCache, user.Config{
+		EditWindow: cfg.Tweet.EditWindow,
+	}, logger)
+	reviewService := review.NewService(reviewStorage, timelineService, review.Config{
+		QueueSize: cfg.Moderation.ReviewQueueSize,
+	})
+	mediaService := mediaservice.NewService(mediaStorage, blobStore, mediaservice.Config{
+		MaxSize:      cfg.Media.MaxSize,
+		AllowedTypes: cfg.Media.AllowedTypes,
+	})
+	healthService := healthservice.NewService(map[string]healthservice.Pinger{
+		"postgres": postgresRepo,
+		"redis":    redisRepo,
+	}, cfg.Server.HealthCheckTimeout)
+	realtimeService := realtime.NewService(redisRepo, cfg.WebSocket.MaxConnectionsPerUser, logger)
+
+	// The shared Pub/Sub subscription lives for the whole process and is renewed when it drops.
+	go realtimeService.Listen(ctx)
+
+	// Fan-out jobs interrupted by a crash or by Redis errors are resumed in the background.
+	go timelineService.RunFanOutRecovery(ctx)
+
+	// Rate limits are counted in Redis, so they hold across instances, unless the memory store is picked.
+	var limiter ratelimit.Limiter
+	switch cfg.RateLimit.Store {
+	case "", config.RateLimitStoreRedis:
+		limiter = resilience.NewRateLimiter(redisRepo, redisPolicy)
+	case config.RateLimitStoreMemory:
+		limiter = ratelimit.NewMemory()
+	default:
+		panic(fmt.Sprintf("unknown rate limit store %q", cfg.RateLimit.Store))
+	}
+	rateLimiter := middleware.NewRateLimiter(limiter, cfg.RateLimit.TrustForwardedFor, logger)
 
 	// handler layer
 	writerHandler := writer.NewHandler(userService)
 	readerHandler := reader.NewHandler(timelineService)
+	streamHandler := stream.NewHandler(realtimeService)
+	adminHandler := admin.NewHandler(timelineService, reviewService)
+	healthHandler := health.NewHandler(healthService)
+	mediaHandler := media.NewHandler(mediaService)
 
 	return Dependencies{
 		WriterHandler: *writerHandler,
 		ReaderHandler: *readerHandler,
+		StreamHandler: *streamHandler,
+		AdminHandler:  *adminHandler,
+		HealthHandler: *healthHandler,
+		MediaHandler:  *mediaHandler,
+
+		RateLimit: func(route string) func(http.Handler) http.Handler {
+			limits := cfg.RateLimit.Routes[route]
+			return rateLimiter.Limit(route, middleware.RouteRateLimit{
+				PerUser: rateLimit(limits.PerUser),
+				PerIP:   rateLimit(limits.PerIP),
+			})
+		},
+		AdminOnly: middleware.AdminOnly(cfg.Admin.UserIDs),
+
+		Health: healthService,
+		Close: func() {
+			postgresRepo.Close()
+			redisRepo.Close()
+		},
+	}
+}
+
+// moderationPolicies builds the moderation rules turned on in cfg, in the order they are
+// checked: the cheap ones first, the one querying PostgreSQL last.
+func moderationPolicies(cfg config.Moderation, duplicates moderation.DuplicateCounter) []moderation.Policy {
+	var policies []moderation.Policy
+	add := func(action string, rule moderation.Rule) {
+		if action == "" {
+			return
+		}
+		switch domain.ModerationAction(action) {
+		case domain.ModerationReject, domain.ModerationHold, domain.ModerationShadowLimit:
+		default:
+			panic(fmt.Sprintf("unknown action %q for moderation rule %s", action, rule.Name()))
+		}
+		policies = append(policies, moderation.Policy{Rule: rule, Action: domain.ModerationAction(action)})
+	}
+
+	if len(cfg.BannedWords.Words) > 0 {
+		add(cfg.BannedWords.Action, moderation.NewBannedWords(cfg.BannedWords.Words))
 	}
+	if len(cfg.BlockedDomains.Domains) > 0 {
+		add(cfg.BlockedDomains.Action, moderation.NewLinkBlocklist(cfg.BlockedDomains.Domains))
+	}
+	add(cfg.DuplicateBurst.Action, moderation.NewDuplicateBurst(duplicates, cfg.DuplicateBurst.MaxDuplicates, cfg.DuplicateBurst.Window))
+	return policies
+}
+
+func resilienceConfig(policy config.ResiliencePolicy) resilience.Config {
+	return resilience.Config{
+		Timeout:           policy.Timeout,
+		OperationTimeouts: policy.OperationTimeouts,
+		MaxRetries:        policy.MaxRetries,
+		RetryBackoff:      policy.RetryBackoff,
+		MaxRetryBackoff:   policy.MaxRetryBackoff,
+		Breaker: circuitbreaker.Config{
+			FailureThreshold: policy.BreakerFailures,
+			Cooldown:         policy.BreakerCooldown,
+		},
+	}
+}
+
+func rateLimit(limit config.Limit) domain.RateLimit {
+	return domain.RateLimit{Requests: limit.Requests, Window: limit.Window}
 }
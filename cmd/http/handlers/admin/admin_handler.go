@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+//go:generate mockgen -source=admin_handler.go -destination=./../mocks/fan_out_job_service_mock.go -package=mocks
+type FanOutJobService interface {
+	GetFanOutJob(ctx context.Context, tweetID string) (domain.FanOutJob, error)
+}
+
+type ModerationService interface {
+	GetReviewQueue(ctx context.Context) ([]domain.ModerationReview, error)
+	ResolveReview(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision) (domain.Tweet, error)
+}
+
+// AdminHandler depends on the interfaces, not concrete types.
+type AdminHandler struct {
+	Timeline   FanOutJobService
+	Moderation ModerationService
+}
+
+func NewHandler(timeline FanOutJobService, moderation ModerationService) *AdminHandler {
+	return &AdminHandler{
+		Timeline:   timeline,
+		Moderation: moderation,
+	}
+}
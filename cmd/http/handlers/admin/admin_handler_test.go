@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHandler(t *testing.T) {
+	type args struct {
+		timelineService   FanOutJobService
+		moderationService ModerationService
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should return a new AdminHandler",
+			args: args{
+				timelineService:   timeline.NewService(nil, nil, timeline.Config{}, logging.Discard()),
+				moderationService: review.NewService(nil, nil, review.Config{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.args.timelineService, tt.args.moderationService)
+			assert.NotNil(t, handler)
+			assert.Equal(t, tt.args.timelineService, handler.Timeline)
+			assert.Equal(t, tt.args.moderationService, handler.Moderation)
+		})
+	}
+}
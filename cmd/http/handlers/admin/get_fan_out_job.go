@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+)
+
+// HandleGetFanOutJob reports the delivery progress of a tweet to its author's followers.
+func (h *AdminHandler) HandleGetFanOutJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tweetID := r.URL.Query().Get("tweet_id")
+	if err := uuid.Validate(tweetID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("query param tweet_id must be a valid tweet id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	job, err := h.Timeline.GetFanOutJob(r.Context(), tweetID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, timeline.ErrFanOutJobNotFound) {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error getting fan-out job: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	jobResponse, err := json.Marshal(job)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(jobResponse)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,131 @@
+package admin_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/admin"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// TestHandleGetFanOutJob uses a table-driven approach with gomock.
+func TestHandleGetFanOutJob(t *testing.T) {
+	const (
+		tweetID  = "a00ffe35-fc64-45f3-be60-8c824ec0a353"
+		authorID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	)
+
+	testCases := []struct {
+		name                 string
+		setupMock            func(mock *mocks.MockFanOutJobService)
+		request              *http.Request
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name: "Success - 200 OK",
+			setupMock: func(mock *mocks.MockFanOutJobService) {
+				mock.EXPECT().
+					GetFanOutJob(gomock.Any(), tweetID).
+					Return(domain.FanOutJob{
+						TweetID:        tweetID,
+						AuthorID:       authorID,
+						Status:         domain.FanOutJobFailed,
+						LastFollowerID: authorID,
+						ProcessedCount: 500,
+						Attempts:       1,
+						LastError:      "some follower timelines could not be updated",
+						CreatedAt:      time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+						// Timestamps are rendered in UTC.
+						UpdatedAt: time.Date(2025, 1, 1, 7, 0, 5, 123456789, time.FixedZone("UTC-3", -3*60*60)),
+					}, nil).
+					Times(1)
+			},
+			request:        httptest.NewRequest(http.MethodGet, "/api/v1/admin/fan-out-jobs?tweet_id="+tweetID, nil),
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: fmt.Sprintf(`{
+				"tweet_id": %q,
+				"author_id": %q,
+				"status": "failed",
+				"last_follower_id": %q,
+				"processed_count": 500,
+				"attempts": 1,
+				"last_error": "some follower timelines could not be updated",
+				"created_at": "2025-01-01T10:00:00.000Z",
+				"updated_at": "2025-01-01T10:00:05.123Z"
+			}`, tweetID, authorID, authorID),
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			setupMock:            func(mock *mocks.MockFanOutJobService) {},
+			request:              httptest.NewRequest(http.MethodPost, "/api/v1/admin/fan-out-jobs", nil),
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed\n",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid tweet id",
+			setupMock:            func(mock *mocks.MockFanOutJobService) {},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/fan-out-jobs?tweet_id=abc", nil),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "query param tweet_id must be a valid tweet id",
+		},
+		{
+			name: "Failure - 404 Not Found",
+			setupMock: func(mock *mocks.MockFanOutJobService) {
+				mock.EXPECT().
+					GetFanOutJob(gomock.Any(), tweetID).
+					Return(domain.FanOutJob{}, timeline.ErrFanOutJobNotFound)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/fan-out-jobs?tweet_id="+tweetID, nil),
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "error getting fan-out job: fan-out job not found",
+		},
+		{
+			name: "Failure - 500 Internal Server Error from service",
+			setupMock: func(mock *mocks.MockFanOutJobService) {
+				mock.EXPECT().
+					GetFanOutJob(gomock.Any(), tweetID).
+					Return(domain.FanOutJob{}, errors.New("database is down"))
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/fan-out-jobs?tweet_id="+tweetID, nil),
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error getting fan-out job: database is down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockFanOutJobService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := admin.NewHandler(mockService, nil)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.HandleGetFanOutJob(recorder, tc.request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+
+			if tc.expectedBodyContains != "" {
+				assert.Equal(t, tc.expectedBodyContains, recorder.Body.String())
+			}
+
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HandleGetReviewQueue lists the held tweets waiting for a moderator, the longest waiting first.
+func (h *AdminHandler) HandleGetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reviews, err := h.Moderation.GetReviewQueue(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error getting review queue: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	reviewsResponse, err := json.Marshal(reviews)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(reviewsResponse)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,112 @@
+package admin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/admin"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleGetReviewQueue(t *testing.T) {
+	const (
+		tweetID  = "a00ffe35-fc64-45f3-be60-8c824ec0a353"
+		authorID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	)
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                 string
+		setupMock            func(mock *mocks.MockModerationService)
+		request              *http.Request
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name: "Success - 200 OK",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().
+					GetReviewQueue(gomock.Any()).
+					Return([]domain.ModerationReview{{
+						Tweet:     domain.Tweet{ID: tweetID, UserID: authorID, Text: "this is a scam", CreatedAt: createdAt, Moderation: domain.TweetHeld},
+						Rule:      "banned_words",
+						Reason:    `contains the banned word "scam"`,
+						FlaggedAt: createdAt.Add(1500 * time.Microsecond),
+					}}, nil)
+			},
+			request:        httptest.NewRequest(http.MethodGet, "/api/v1/admin/moderation/reviews", nil),
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `[{
+				"tweet": {
+					"id": "a00ffe35-fc64-45f3-be60-8c824ec0a353",
+					"user_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+					"text": "this is a scam",
+					"created_at": "2025-01-01T10:00:00.000Z",
+					"held": true
+				},
+				"rule": "banned_words",
+				"reason": "contains the banned word \"scam\"",
+				"flagged_at": "2025-01-01T10:00:00.001Z"
+			}]`,
+		},
+		{
+			name: "Success - 200 OK with an empty queue",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().GetReviewQueue(gomock.Any()).Return([]domain.ModerationReview{}, nil)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/moderation/reviews", nil),
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: `[]`,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			setupMock:            func(mock *mocks.MockModerationService) {},
+			request:              httptest.NewRequest(http.MethodPost, "/api/v1/admin/moderation/reviews", nil),
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed\n",
+		},
+		{
+			name: "Failure - 500 Internal Server Error from service",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().GetReviewQueue(gomock.Any()).Return(nil, errors.New("database is down"))
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/moderation/reviews", nil),
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error getting review queue: database is down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockModerationService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := admin.NewHandler(nil, mockService)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.HandleGetReviewQueue(recorder, tc.request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+
+			if tc.expectedBodyContains != "" {
+				assert.Equal(t, tc.expectedBodyContains, recorder.Body.String())
+			}
+
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+)
+
+type ResolveReviewRequest struct {
+	Decision domain.ReviewDecision `json:"decision"`
+}
+
+// HandleResolveReview approves or removes the held tweet in the {id} path segment. The
+// moderator making the decision is identified by the X-User-ID header.
+func (h *AdminHandler) HandleResolveReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reviewerID := r.Header.Get("X-User-ID")
+	if err := uuid.Validate(reviewerID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("Header X-User-ID must be a valid user id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	tweetID := r.PathValue("id")
+	if err := uuid.Validate(tweetID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("tweet id must be a valid tweet id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error reading body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	var request ResolveReviewRequest
+	if err = json.Unmarshal(bytes, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmarshalling body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	tweet, err := h.Moderation.ResolveReview(r.Context(), tweetID, reviewerID, request.Decision)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, review.ErrInvalidDecision):
+			status = http.StatusBadRequest
+		case errors.Is(err, review.ErrReviewNotFound):
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error resolving review: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	tweetResponse, err := json.Marshal(tweet)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(tweetResponse)
+	if err != nil {
+		return
+	}
+}
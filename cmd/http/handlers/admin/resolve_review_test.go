@@ -0,0 +1,151 @@
+package admin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/admin"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleResolveReview(t *testing.T) {
+	const (
+		tweetID    = "a00ffe35-fc64-45f3-be60-8c824ec0a353"
+		authorID   = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		reviewerID = "b1ffcd88-8d1a-4ef8-bb6d-6bb9bd380a22"
+	)
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// newRequest builds a decision request on tweetID routed as by the mux, so the {id}
+	// path value is set.
+	newRequest := func(method, id, reviewer, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/admin/moderation/reviews/"+id, strings.NewReader(body))
+		req.SetPathValue("id", id)
+		if reviewer != "" {
+			req.Header.Set("X-User-ID", reviewer)
+		}
+		return req
+	}
+
+	testCases := []struct {
+		name                 string
+		setupMock            func(mock *mocks.MockModerationService)
+		request              *http.Request
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name: "Success - 200 OK approving",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().
+					ResolveReview(gomock.Any(), tweetID, reviewerID, domain.ReviewApprove).
+					Return(domain.Tweet{ID: tweetID, UserID: authorID, Text: "hello", CreatedAt: createdAt, Moderation: domain.TweetVisible}, nil)
+			},
+			request:        newRequest(http.MethodPost, tweetID, reviewerID, `{"decision":"approve"}`),
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"id": "a00ffe35-fc64-45f3-be60-8c824ec0a353",
+				"user_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+				"text": "hello",
+				"created_at": "2025-01-01T10:00:00.000Z"
+			}`,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			setupMock:            func(mock *mocks.MockModerationService) {},
+			request:              newRequest(http.MethodGet, tweetID, reviewerID, ""),
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed\n",
+		},
+		{
+			name:                 "Failure - 400 Bad Request without reviewer",
+			setupMock:            func(mock *mocks.MockModerationService) {},
+			request:              newRequest(http.MethodPost, tweetID, "", `{"decision":"approve"}`),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID must be a valid user id",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid tweet id",
+			setupMock:            func(mock *mocks.MockModerationService) {},
+			request:              newRequest(http.MethodPost, "abc", reviewerID, `{"decision":"approve"}`),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "tweet id must be a valid tweet id",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid body",
+			setupMock:            func(mock *mocks.MockModerationService) {},
+			request:              newRequest(http.MethodPost, tweetID, reviewerID, `{`),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error unmarshalling body: unexpected end of JSON input",
+		},
+		{
+			name: "Failure - 400 Bad Request for invalid decision",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().
+					ResolveReview(gomock.Any(), tweetID, reviewerID, domain.ReviewDecision("maybe")).
+					Return(domain.Tweet{}, review.ErrInvalidDecision)
+			},
+			request:              newRequest(http.MethodPost, tweetID, reviewerID, `{"decision":"maybe"}`),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error resolving review: decision must be approve or remove",
+		},
+		{
+			name: "Failure - 404 Not Found",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().
+					ResolveReview(gomock.Any(), tweetID, reviewerID, domain.ReviewRemove).
+					Return(domain.Tweet{}, review.ErrReviewNotFound)
+			},
+			request:              newRequest(http.MethodPost, tweetID, reviewerID, `{"decision":"remove"}`),
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "error resolving review: tweet is not waiting for a review",
+		},
+		{
+			name: "Failure - 500 Internal Server Error from service",
+			setupMock: func(mock *mocks.MockModerationService) {
+				mock.EXPECT().
+					ResolveReview(gomock.Any(), tweetID, reviewerID, domain.ReviewRemove).
+					Return(domain.Tweet{}, errors.New("database is down"))
+			},
+			request:              newRequest(http.MethodPost, tweetID, reviewerID, `{"decision":"remove"}`),
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error resolving review: database is down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockModerationService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := admin.NewHandler(nil, mockService)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.HandleResolveReview(recorder, tc.request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+
+			if tc.expectedBodyContains != "" {
+				assert.Equal(t, tc.expectedBodyContains, recorder.Body.String())
+			}
+
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
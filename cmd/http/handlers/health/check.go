@@ -0,0 +1,56 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// HandleLiveness answers whether the process is up. The dependencies are reported for
+// troubleshooting, but their failures don't fail the check: restarting the instance
+// wouldn't bring PostgreSQL or Redis back.
+func (h HealthHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeHealthReport(w, http.StatusOK, h.Health.Check(r.Context()))
+}
+
+// HandleReadiness answers whether the instance can take traffic: 200 when every dependency
+// answered its ping, 503 when one didn't or the instance is draining for shutdown.
+func (h HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	report := h.Health.Check(r.Context())
+	status := http.StatusOK
+	if report.Status != domain.HealthOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeHealthReport(w, status, report)
+}
+
+func writeHealthReport(w http.ResponseWriter, status int, report domain.HealthReport) {
+	body, err := json.Marshal(report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Probes must always see the current state.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	if err != nil {
+		return
+	}
+}
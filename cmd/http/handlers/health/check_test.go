@@ -0,0 +1,132 @@
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/health"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthHandlers(t *testing.T) {
+	healthy := domain.HealthReport{
+		Status: domain.HealthOK,
+		Checks: map[string]domain.DependencyHealth{
+			"postgres": {Status: domain.HealthOK, LatencyMS: 1.5},
+			"redis":    {Status: domain.HealthOK, LatencyMS: 0.25},
+		},
+	}
+	redisDown := domain.HealthReport{
+		Status: domain.HealthUnavailable,
+		Checks: map[string]domain.DependencyHealth{
+			"postgres": {Status: domain.HealthOK, LatencyMS: 1.5},
+			"redis":    {Status: domain.HealthUnavailable, LatencyMS: 1000, Error: "failed to PING redis: i/o timeout"},
+		},
+	}
+	draining := domain.HealthReport{Status: domain.HealthDraining, Checks: healthy.Checks}
+
+	redisDownJSON := `{
+		"status": "unavailable",
+		"checks": {
+			"postgres": {"status": "ok", "latency_ms": 1.5},
+			"redis": {"status": "unavailable", "latency_ms": 1000, "error": "failed to PING redis: i/o timeout"}
+		}
+	}`
+
+	testCases := []struct {
+		name                 string
+		handler              func(h *health.HealthHandler) http.HandlerFunc
+		method               string
+		report               *domain.HealthReport
+		expectedStatus       int
+		expectedJSONResponse string
+	}{
+		{
+			name:           "Liveness - 200 OK when healthy",
+			handler:        func(h *health.HealthHandler) http.HandlerFunc { return h.HandleLiveness },
+			method:         http.MethodGet,
+			report:         &healthy,
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"status": "ok",
+				"checks": {
+					"postgres": {"status": "ok", "latency_ms": 1.5},
+					"redis": {"status": "ok", "latency_ms": 0.25}
+				}
+			}`,
+		},
+		{
+			name:                 "Liveness - 200 OK even when a dependency is down",
+			handler:              func(h *health.HealthHandler) http.HandlerFunc { return h.HandleLiveness },
+			method:               http.MethodGet,
+			report:               &redisDown,
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: redisDownJSON,
+		},
+		{
+			name:           "Liveness - 405 Method Not Allowed",
+			handler:        func(h *health.HealthHandler) http.HandlerFunc { return h.HandleLiveness },
+			method:         http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "Readiness - 200 OK when healthy",
+			handler:        func(h *health.HealthHandler) http.HandlerFunc { return h.HandleReadiness },
+			method:         http.MethodGet,
+			report:         &healthy,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                 "Readiness - 503 when a dependency is down",
+			handler:              func(h *health.HealthHandler) http.HandlerFunc { return h.HandleReadiness },
+			method:               http.MethodGet,
+			report:               &redisDown,
+			expectedStatus:       http.StatusServiceUnavailable,
+			expectedJSONResponse: redisDownJSON,
+		},
+		{
+			name:           "Readiness - 503 while draining",
+			handler:        func(h *health.HealthHandler) http.HandlerFunc { return h.HandleReadiness },
+			method:         http.MethodGet,
+			report:         &draining,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Readiness - 405 Method Not Allowed",
+			handler:        func(h *health.HealthHandler) http.HandlerFunc { return h.HandleReadiness },
+			method:         http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockHealthService(ctrl)
+			if tc.report != nil {
+				mockService.EXPECT().Check(gomock.Any()).Return(*tc.report)
+			}
+
+			handler := health.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			tc.handler(handler)(recorder, httptest.NewRequest(tc.method, "/readyz", nil))
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.report != nil {
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+			}
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+			}
+		})
+	}
+}
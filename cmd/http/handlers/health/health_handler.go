@@ -0,0 +1,23 @@
+package health
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+//go:generate mockgen -source=health_handler.go -destination=./../mocks/health_service_mock.go -package=mocks
+type HealthService interface {
+	Check(ctx context.Context) domain.HealthReport
+}
+
+// HealthHandler depends on the interfaces, not concrete types.
+type HealthHandler struct {
+	Health HealthService
+}
+
+func NewHandler(health HealthService) *HealthHandler {
+	return &HealthHandler{
+		Health: health,
+	}
+}
@@ -0,0 +1,35 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/service/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHandler(t *testing.T) {
+	type args struct {
+		healthService HealthService
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should return a new HealthHandler",
+			args: args{
+				healthService: health.NewService(nil, time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.args.healthService)
+			assert.NotNil(t, handler)
+			assert.Equal(t, tt.args.healthService, handler.Health)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+)
+
+// HandleGetMedia serves the content of the media in the {id} path segment.
+func (h MediaHandler) HandleGetMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mediaID := r.PathValue("id")
+	if err := uuid.Validate(mediaID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("media id must be a valid media id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	media, content, err := h.MediaService.GetMedia(r.Context(), mediaID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, mediaservice.ErrMediaNotFound) {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error getting media: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", media.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(media.Size, 10))
+	// The content of a media never changes, and the type was checked on upload.
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, content)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package media_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/media"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleGetMedia(t *testing.T) {
+	content := "\x89PNG\r\n\x1a\n-png"
+	stored := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		ContentType: "image/png",
+		Size:        int64(len(content)),
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name                 string
+		method               string
+		mediaID              string
+		setupMock            func(mock *mocks.MockMediaService)
+		expectedStatus       int
+		expectedBody         string
+		expectedBodyContains string
+		expectedHeaders      map[string]string
+	}{
+		{
+			name:    "Success - 200 OK serves the content",
+			method:  http.MethodGet,
+			mediaID: stored.ID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().GetMedia(gomock.Any(), stored.ID).Return(stored, io.NopCloser(strings.NewReader(content)), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   content,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "image/png",
+				"Content-Length":         "12",
+				"Cache-Control":          "public, max-age=31536000, immutable",
+				"X-Content-Type-Options": "nosniff",
+			},
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodPost,
+			mediaID:              stored.ID,
+			setupMock:            func(mock *mocks.MockMediaService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid media id",
+			method:               http.MethodGet,
+			mediaID:              "abc",
+			setupMock:            func(mock *mocks.MockMediaService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "media id must be a valid media id",
+		},
+		{
+			name:    "Failure - 404 Not Found",
+			method:  http.MethodGet,
+			mediaID: stored.ID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().GetMedia(gomock.Any(), stored.ID).Return(domain.Media{}, nil, mediaservice.ErrMediaNotFound)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: mediaservice.ErrMediaNotFound.Error(),
+		},
+		{
+			name:    "Failure - 500 Internal Server Error from service",
+			method:  http.MethodGet,
+			mediaID: stored.ID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().GetMedia(gomock.Any(), stored.ID).Return(domain.Media{}, nil, errors.New("database connection lost"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error getting media",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockMediaService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := media.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/media/"+tc.mediaID, nil)
+			request.SetPathValue("id", tc.mediaID)
+
+			// Act
+			handler.HandleGetMedia(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, recorder.Body.String())
+			}
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+			for header, value := range tc.expectedHeaders {
+				assert.Equal(t, value, recorder.Header().Get(header), header)
+			}
+		})
+	}
+}
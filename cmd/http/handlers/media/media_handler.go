@@ -0,0 +1,25 @@
+package media
+
+import (
+	"context"
+	"io"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+//go:generate mockgen -source=media_handler.go -destination=./../mocks/media_service_mock.go -package=mocks
+type MediaService interface {
+	UploadMedia(ctx context.Context, userID, contentType string, data io.Reader) (domain.Media, error)
+	GetMedia(ctx context.Context, mediaID string) (domain.Media, io.ReadCloser, error)
+}
+
+// MediaHandler depends on the interfaces, not concrete types.
+type MediaHandler struct {
+	MediaService MediaService
+}
+
+func NewHandler(mediaService MediaService) *MediaHandler {
+	return &MediaHandler{
+		MediaService: mediaService,
+	}
+}
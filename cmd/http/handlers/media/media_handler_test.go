@@ -0,0 +1,34 @@
+package media
+
+import (
+	"testing"
+
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHandler(t *testing.T) {
+	type args struct {
+		mediaService MediaService
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should return a new MediaHandler",
+			args: args{
+				mediaService: mediaservice.NewService(nil, nil, mediaservice.Config{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.args.mediaService)
+			assert.NotNil(t, handler)
+			assert.Equal(t, tt.args.mediaService, handler.MediaService)
+		})
+	}
+}
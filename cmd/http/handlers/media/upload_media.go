@@ -0,0 +1,65 @@
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+)
+
+// HandleUploadMedia stores the request body as a new media of the user. The body is the raw
+// file, described by the Content-Type header. The returned ID can be attached to a tweet.
+func (h MediaHandler) HandleUploadMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	media, err := h.MediaService.UploadMedia(r.Context(), userID, r.Header.Get("Content-Type"), r.Body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, mediaservice.ErrMediaTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, mediaservice.ErrUnsupportedMediaType):
+			status = http.StatusUnsupportedMediaType
+		case errors.Is(err, mediaservice.ErrEmptyMedia):
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error uploading media: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	mediaResponse, err := json.Marshal(media)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(mediaResponse)
+	if err != nil {
+		return
+	}
+}
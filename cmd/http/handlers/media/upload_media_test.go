@@ -0,0 +1,147 @@
+package media_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/media"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	mediaservice "github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleUploadMedia(t *testing.T) {
+	const userID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+	uploaded := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		ContentType: "image/png",
+		Size:        12,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name                 string
+		method               string
+		userID               string
+		setupMock            func(mock *mocks.MockMediaService)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name:   "Success - 201 Created",
+			method: http.MethodPost,
+			userID: userID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().UploadMedia(gomock.Any(), userID, "image/png", gomock.Any()).Return(uploaded, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedJSONResponse: `{
+				"id": "` + uploaded.ID + `",
+				"content_type": "image/png",
+				"size": 12,
+				"created_at": "2025-01-01T10:00:00.000Z"
+			}`,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodGet,
+			userID:               userID,
+			setupMock:            func(mock *mocks.MockMediaService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodPost,
+			setupMock:            func(mock *mocks.MockMediaService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:   "Failure - 400 Bad Request for an empty body",
+			method: http.MethodPost,
+			userID: userID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().UploadMedia(gomock.Any(), userID, "image/png", gomock.Any()).Return(domain.Media{}, mediaservice.ErrEmptyMedia)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: mediaservice.ErrEmptyMedia.Error(),
+		},
+		{
+			name:   "Failure - 413 Request Entity Too Large",
+			method: http.MethodPost,
+			userID: userID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().
+					UploadMedia(gomock.Any(), userID, "image/png", gomock.Any()).
+					Return(domain.Media{}, fmt.Errorf("%w: the limit is 5 bytes", mediaservice.ErrMediaTooLarge))
+			},
+			expectedStatus:       http.StatusRequestEntityTooLarge,
+			expectedBodyContains: "the limit is 5 bytes",
+		},
+		{
+			name:   "Failure - 415 Unsupported Media Type",
+			method: http.MethodPost,
+			userID: userID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().
+					UploadMedia(gomock.Any(), userID, "image/png", gomock.Any()).
+					Return(domain.Media{}, mediaservice.ErrUnsupportedMediaType)
+			},
+			expectedStatus:       http.StatusUnsupportedMediaType,
+			expectedBodyContains: mediaservice.ErrUnsupportedMediaType.Error(),
+		},
+		{
+			name:   "Failure - 500 Internal Server Error from service",
+			method: http.MethodPost,
+			userID: userID,
+			setupMock: func(mock *mocks.MockMediaService) {
+				mock.EXPECT().
+					UploadMedia(gomock.Any(), userID, "image/png", gomock.Any()).
+					Return(domain.Media{}, errors.New("disk full"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error uploading media: disk full",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockMediaService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := media.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/media", strings.NewReader("\x89PNG\r\n\x1a\n-png"))
+			request.Header.Set("Content-Type", "image/png")
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+
+			// Act
+			handler.HandleUploadMedia(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
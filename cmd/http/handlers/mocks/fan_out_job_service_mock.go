@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin_handler.go -destination=./../mocks/fan_out_job_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFanOutJobService is a mock of FanOutJobService interface.
+type MockFanOutJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFanOutJobServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFanOutJobServiceMockRecorder is the mock recorder for MockFanOutJobService.
+type MockFanOutJobServiceMockRecorder struct {
+	mock *MockFanOutJobService
+}
+
+// NewMockFanOutJobService creates a new mock instance.
+func NewMockFanOutJobService(ctrl *gomock.Controller) *MockFanOutJobService {
+	mock := &MockFanOutJobService{ctrl: ctrl}
+	mock.recorder = &MockFanOutJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFanOutJobService) EXPECT() *MockFanOutJobServiceMockRecorder {
+	return m.recorder
+}
+
+// GetFanOutJob mocks base method.
+func (m *MockFanOutJobService) GetFanOutJob(ctx context.Context, tweetID string) (domain.FanOutJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFanOutJob", ctx, tweetID)
+	ret0, _ := ret[0].(domain.FanOutJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFanOutJob indicates an expected call of GetFanOutJob.
+func (mr *MockFanOutJobServiceMockRecorder) GetFanOutJob(ctx, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFanOutJob", reflect.TypeOf((*MockFanOutJobService)(nil).GetFanOutJob), ctx, tweetID)
+}
+
+// MockModerationService is a mock of ModerationService interface.
+type MockModerationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockModerationServiceMockRecorder is the mock recorder for MockModerationService.
+type MockModerationServiceMockRecorder struct {
+	mock *MockModerationService
+}
+
+// NewMockModerationService creates a new mock instance.
+func NewMockModerationService(ctrl *gomock.Controller) *MockModerationService {
+	mock := &MockModerationService{ctrl: ctrl}
+	mock.recorder = &MockModerationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationService) EXPECT() *MockModerationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReviewQueue mocks base method.
+func (m *MockModerationService) GetReviewQueue(ctx context.Context) ([]domain.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewQueue", ctx)
+	ret0, _ := ret[0].([]domain.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewQueue indicates an expected call of GetReviewQueue.
+func (mr *MockModerationServiceMockRecorder) GetReviewQueue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewQueue", reflect.TypeOf((*MockModerationService)(nil).GetReviewQueue), ctx)
+}
+
+// ResolveReview mocks base method.
+func (m *MockModerationService) ResolveReview(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision) (domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReview", ctx, tweetID, reviewerID, decision)
+	ret0, _ := ret[0].(domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveReview indicates an expected call of ResolveReview.
+func (mr *MockModerationServiceMockRecorder) ResolveReview(ctx, tweetID, reviewerID, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReview", reflect.TypeOf((*MockModerationService)(nil).ResolveReview), ctx, tweetID, reviewerID, decision)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health_handler.go -destination=./../mocks/health_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthService is a mock of HealthService interface.
+type MockHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthServiceMockRecorder is the mock recorder for MockHealthService.
+type MockHealthServiceMockRecorder struct {
+	mock *MockHealthService
+}
+
+// NewMockHealthService creates a new mock instance.
+func NewMockHealthService(ctrl *gomock.Controller) *MockHealthService {
+	mock := &MockHealthService{ctrl: ctrl}
+	mock.recorder = &MockHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthService) EXPECT() *MockHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockHealthService) Check(ctx context.Context) domain.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(domain.HealthReport)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockHealthServiceMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockHealthService)(nil).Check), ctx)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: media_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=media_handler.go -destination=./../mocks/media_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMediaService is a mock of MediaService interface.
+type MockMediaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaServiceMockRecorder is the mock recorder for MockMediaService.
+type MockMediaServiceMockRecorder struct {
+	mock *MockMediaService
+}
+
+// NewMockMediaService creates a new mock instance.
+func NewMockMediaService(ctrl *gomock.Controller) *MockMediaService {
+	mock := &MockMediaService{ctrl: ctrl}
+	mock.recorder = &MockMediaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaService) EXPECT() *MockMediaServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMedia mocks base method.
+func (m *MockMediaService) GetMedia(ctx context.Context, mediaID string) (domain.Media, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", ctx, mediaID)
+	ret0, _ := ret[0].(domain.Media)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockMediaServiceMockRecorder) GetMedia(ctx, mediaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockMediaService)(nil).GetMedia), ctx, mediaID)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaService) UploadMedia(ctx context.Context, userID, contentType string, data io.Reader) (domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", ctx, userID, contentType, data)
+	ret0, _ := ret[0].(domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaServiceMockRecorder) UploadMedia(ctx, userID, contentType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaService)(nil).UploadMedia), ctx, userID, contentType, data)
+}
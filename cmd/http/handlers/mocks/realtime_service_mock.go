@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stream_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stream_handler.go -destination=./../mocks/realtime_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	realtime "github.com/renzonaitor/tweet-api/internal/service/realtime"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRealtimeService is a mock of RealtimeService interface.
+type MockRealtimeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRealtimeServiceMockRecorder is the mock recorder for MockRealtimeService.
+type MockRealtimeServiceMockRecorder struct {
+	mock *MockRealtimeService
+}
+
+// NewMockRealtimeService creates a new mock instance.
+func NewMockRealtimeService(ctrl *gomock.Controller) *MockRealtimeService {
+	mock := &MockRealtimeService{ctrl: ctrl}
+	mock.recorder = &MockRealtimeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeService) EXPECT() *MockRealtimeServiceMockRecorder {
+	return m.recorder
+}
+
+// Connect mocks base method.
+func (m *MockRealtimeService) Connect(userID string) (*realtime.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connect", userID)
+	ret0, _ := ret[0].(*realtime.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockRealtimeServiceMockRecorder) Connect(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockRealtimeService)(nil).Connect), userID)
+}
+
+// Disconnect mocks base method.
+func (m *MockRealtimeService) Disconnect(client *realtime.Client) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Disconnect", client)
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockRealtimeServiceMockRecorder) Disconnect(client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockRealtimeService)(nil).Disconnect), client)
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtimeService) Subscribe(client *realtime.Client, topic string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", client, topic)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeServiceMockRecorder) Subscribe(client, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtimeService)(nil).Subscribe), client, topic)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockRealtimeService) Unsubscribe(client *realtime.Client, topic string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", client, topic)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockRealtimeServiceMockRecorder) Unsubscribe(client, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockRealtimeService)(nil).Unsubscribe), client, topic)
+}
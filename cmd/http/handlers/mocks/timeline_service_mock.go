@@ -41,17 +41,32 @@ func (m *MockTimelineService) EXPECT() *MockTimelineServiceMockRecorder {
 	return m.recorder
 }
 
+// CountNewTweets mocks base method.
+func (m *MockTimelineService) CountNewTweets(ctx context.Context, userID, sinceCursor string) (domain.NewTweetsCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNewTweets", ctx, userID, sinceCursor)
+	ret0, _ := ret[0].(domain.NewTweetsCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNewTweets indicates an expected call of CountNewTweets.
+func (mr *MockTimelineServiceMockRecorder) CountNewTweets(ctx, userID, sinceCursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNewTweets", reflect.TypeOf((*MockTimelineService)(nil).CountNewTweets), ctx, userID, sinceCursor)
+}
+
 // GetTimeline mocks base method.
-func (m *MockTimelineService) GetTimeline(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+func (m *MockTimelineService) GetTimeline(ctx context.Context, userID string, limit int, cursor string) (domain.TimelinePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTimeline", ctx, userID, limit)
-	ret0, _ := ret[0].([]domain.Tweet)
+	ret := m.ctrl.Call(m, "GetTimeline", ctx, userID, limit, cursor)
+	ret0, _ := ret[0].(domain.TimelinePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTimeline indicates an expected call of GetTimeline.
-func (mr *MockTimelineServiceMockRecorder) GetTimeline(ctx, userID, limit any) *gomock.Call {
+func (mr *MockTimelineServiceMockRecorder) GetTimeline(ctx, userID, limit, cursor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockTimelineService)(nil).GetTimeline), ctx, userID, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockTimelineService)(nil).GetTimeline), ctx, userID, limit, cursor)
 }
@@ -41,6 +41,35 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// BlockUser mocks base method.
+func (m *MockUserService) BlockUser(ctx context.Context, block domain.BlockUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUserServiceMockRecorder) BlockUser(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUserService)(nil).BlockUser), ctx, block)
+}
+
+// EditTweet mocks base method.
+func (m *MockUserService) EditTweet(ctx context.Context, tweetID, userID, text string) (domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditTweet", ctx, tweetID, userID, text)
+	ret0, _ := ret[0].(domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditTweet indicates an expected call of EditTweet.
+func (mr *MockUserServiceMockRecorder) EditTweet(ctx, tweetID, userID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTweet", reflect.TypeOf((*MockUserService)(nil).EditTweet), ctx, tweetID, userID, text)
+}
+
 // FollowUser mocks base method.
 func (m *MockUserService) FollowUser(ctx context.Context, followUser domain.FollowUser) error {
 	m.ctrl.T.Helper()
@@ -55,13 +84,43 @@ func (mr *MockUserServiceMockRecorder) FollowUser(ctx, followUser any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUserService)(nil).FollowUser), ctx, followUser)
 }
 
+// GetTweetHistory mocks base method.
+func (m *MockUserService) GetTweetHistory(ctx context.Context, tweetID string) (domain.TweetHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTweetHistory", ctx, tweetID)
+	ret0, _ := ret[0].(domain.TweetHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTweetHistory indicates an expected call of GetTweetHistory.
+func (mr *MockUserServiceMockRecorder) GetTweetHistory(ctx, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweetHistory", reflect.TypeOf((*MockUserService)(nil).GetTweetHistory), ctx, tweetID)
+}
+
+// MuteUser mocks base method.
+func (m *MockUserService) MuteUser(ctx context.Context, mute domain.MuteUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", ctx, mute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockUserServiceMockRecorder) MuteUser(ctx, mute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockUserService)(nil).MuteUser), ctx, mute)
+}
+
 // PublishTweet mocks base method.
-func (m *MockUserService) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+func (m *MockUserService) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishTweet", ctx, tweet)
 	ret0, _ := ret[0].(domain.Tweet)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // PublishTweet indicates an expected call of PublishTweet.
@@ -69,3 +128,31 @@ func (mr *MockUserServiceMockRecorder) PublishTweet(ctx, tweet any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishTweet", reflect.TypeOf((*MockUserService)(nil).PublishTweet), ctx, tweet)
 }
+
+// UnblockUser mocks base method.
+func (m *MockUserService) UnblockUser(ctx context.Context, block domain.BlockUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockUserServiceMockRecorder) UnblockUser(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockUserService)(nil).UnblockUser), ctx, block)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockUserService) UnmuteUser(ctx context.Context, mute domain.MuteUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", ctx, mute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockUserServiceMockRecorder) UnmuteUser(ctx, mute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockUserService)(nil).UnmuteUser), ctx, mute)
+}
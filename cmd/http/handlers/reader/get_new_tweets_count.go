@@ -0,0 +1,66 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (h *ReaderHandler) HandleGetNewTweetsCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	sinceCursor := r.URL.Query().Get("since_cursor")
+	if err := uuid.Validate(sinceCursor); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("query param since_cursor must be a valid tweet id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	count, err := h.Timeline.CountNewTweets(r.Context(), userID, sinceCursor)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error counting new tweets: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	if count.Degraded {
+		w.Header().Set(DegradedHeader, "true")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	countResponse, err := json.Marshal(count)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(countResponse)
+	if err != nil {
+		return
+	}
+}
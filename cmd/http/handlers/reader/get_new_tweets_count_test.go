@@ -0,0 +1,125 @@
+package reader_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/reader"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// TestHandleGetNewTweetsCount uses a table-driven approach with gomock.
+func TestHandleGetNewTweetsCount(t *testing.T) {
+	const (
+		testUserID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		cursor     = "a00ffe35-fc64-45f3-be60-8c824ec0a353"
+	)
+
+	testCases := []struct {
+		name                 string
+		setupMock            func(mock *mocks.MockTimelineService)
+		request              *http.Request
+		setupRequest         func(req *http.Request)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+		expectedDegraded     string
+	}{
+		{
+			name: "Success - 200 OK",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().
+					CountNewTweets(gomock.Any(), testUserID, cursor).
+					Return(domain.NewTweetsCount{Count: 12}, nil).
+					Times(1)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/timeline/new-count?since_cursor="+cursor, nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: `{"count": 12, "capped": false}`,
+		},
+		{
+			name: "Success - 200 OK with degraded header when the cache is unavailable",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().
+					CountNewTweets(gomock.Any(), testUserID, cursor).
+					Return(domain.NewTweetsCount{Count: 3, Degraded: true}, nil)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/timeline/new-count?since_cursor="+cursor, nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: `{"count": 3, "capped": false, "degraded": true}`,
+			expectedDegraded:     "true",
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			setupMock:            func(mock *mocks.MockTimelineService) {},
+			request:              httptest.NewRequest(http.MethodPost, "/api/v1/timeline/new-count", nil),
+			setupRequest:         func(req *http.Request) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed\n",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID",
+			setupMock:            func(mock *mocks.MockTimelineService) {},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/timeline/new-count?since_cursor="+cursor, nil),
+			setupRequest:         func(req *http.Request) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing cursor",
+			setupMock:            func(mock *mocks.MockTimelineService) {},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/timeline/new-count", nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "query param since_cursor must be a valid tweet id",
+		},
+		{
+			name: "Failure - 500 Internal Server Error from service",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().
+					CountNewTweets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.NewTweetsCount{}, errors.New("database is down"))
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/timeline/new-count?since_cursor="+cursor, nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error counting new tweets: database is down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockTimelineService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := reader.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			tc.setupRequest(tc.request)
+
+			// Act
+			handler.HandleGetNewTweetsCount(recorder, tc.request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Equal(t, tc.expectedDegraded, recorder.Header().Get(reader.DegradedHeader))
+
+			if tc.expectedBodyContains != "" {
+				assert.Equal(t, tc.expectedBodyContains, recorder.Body.String())
+			}
+
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
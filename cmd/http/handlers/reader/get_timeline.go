@@ -6,9 +6,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+
+	"github.com/google/uuid"
 )
 
-const defaultPaginationLimit = 10
+// Page sizes of the timeline: defaultPaginationLimit when limit isn't set, and at most
+// maxPaginationLimit, so a page can't ask PostgreSQL or Redis for an unbounded number of tweets.
+const (
+	defaultPaginationLimit = 10
+	maxPaginationLimit     = 100
+)
 
 func (h *ReaderHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -37,9 +44,17 @@ func (h *ReaderHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// nextCursor := r.URL.Query().Get("next_cursor") // TODO implement pagination
+	nextCursor, err := parseCursor(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error parsing next_cursor: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
 
-	timeline, err := h.Timeline.GetTimeline(r.Context(), userID, limit)
+	timeline, err := h.Timeline.GetTimeline(r.Context(), userID, limit, nextCursor)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, err = w.Write([]byte(fmt.Sprintf("error getting timeline: %s", err)))
@@ -49,10 +64,13 @@ func (h *ReaderHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if timeline.Degraded {
+		w.Header().Set(DegradedHeader, "true")
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	timelineResponse, err := json.Marshal(timeline)
+	timelineResponse, err := json.Marshal(timeline.Tweets)
 	if err != nil {
 		_, err = w.Write([]byte(err.Error()))
 		if err != nil {
@@ -77,8 +95,23 @@ func parseLimit(request *http.Request) (int, error) {
 		if limit <= 0 {
 			return 0, errors.New("limit must be a positive number")
 		}
+		if limit > maxPaginationLimit {
+			return 0, fmt.Errorf("limit must be at most %d", maxPaginationLimit)
+		}
 		limitResponse = limit
 	}
 
 	return limitResponse, nil
 }
+
+// parseCursor returns the id of the last tweet of the previous page, or "" for the first page.
+func parseCursor(request *http.Request) (string, error) {
+	cursor := request.URL.Query().Get("next_cursor")
+	if cursor == "" {
+		return "", nil
+	}
+	if err := uuid.Validate(cursor); err != nil {
+		return "", errors.New("next_cursor must be a valid tweet id")
+	}
+	return cursor, nil
+}
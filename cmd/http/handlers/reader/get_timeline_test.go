@@ -18,7 +18,7 @@ import (
 
 // TestHandleGetTimeline uses a table-driven approach with gomock.
 func TestHandleGetTimeline(t *testing.T) {
-	now := time.Now().Format(time.RFC3339)
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
 	mockTweets := []domain.Tweet{
 		{ID: "a00ffe35-fc64-45f3-be60-8c824ec0a353", UserID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", Text: "Hello World", CreatedAt: now},
 		{ID: "a00ffe35-fc64-45f3-be60-8c824ec0a352", UserID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", Text: "Testing handlers!", CreatedAt: now},
@@ -32,13 +32,14 @@ func TestHandleGetTimeline(t *testing.T) {
 		expectedStatus       int
 		expectedBodyContains string
 		expectedJSONResponse []domain.Tweet
+		expectedDegraded     string
 	}{
 		{
 			name: "Success - 200 OK with default limit",
 			setupMock: func(mock *mocks.MockTimelineService) {
 				mock.EXPECT().
-					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 10).
-					Return(mockTweets, nil).
+					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 10, "").
+					Return(domain.TimelinePage{Tweets: mockTweets}, nil).
 					Times(1)
 			},
 			request:              httptest.NewRequest(http.MethodGet, "/timeline", nil),
@@ -50,14 +51,47 @@ func TestHandleGetTimeline(t *testing.T) {
 			name: "Success - 200 OK with custom limit",
 			setupMock: func(mock *mocks.MockTimelineService) {
 				mock.EXPECT().
-					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 5).
-					Return(mockTweets, nil)
+					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 5, "").
+					Return(domain.TimelinePage{Tweets: mockTweets}, nil)
 			},
 			request:              httptest.NewRequest(http.MethodGet, "/timeline?limit=5", nil),
 			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
 			expectedStatus:       http.StatusOK,
 			expectedJSONResponse: mockTweets,
 		},
+		{
+			name: "Success - 200 OK with next cursor",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().
+					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 10, "a00ffe35-fc64-45f3-be60-8c824ec0a352").
+					Return(domain.TimelinePage{Tweets: mockTweets}, nil)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/timeline?next_cursor=a00ffe35-fc64-45f3-be60-8c824ec0a352", nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: mockTweets,
+		},
+		{
+			name: "Success - 200 OK with degraded header when the cache is unavailable",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().
+					GetTimeline(gomock.Any(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", 10, "").
+					Return(domain.TimelinePage{Tweets: mockTweets, Degraded: true}, nil)
+			},
+			request:              httptest.NewRequest(http.MethodGet, "/timeline", nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
+			expectedStatus:       http.StatusOK,
+			expectedJSONResponse: mockTweets,
+			expectedDegraded:     "true",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid next cursor",
+			setupMock:            func(mock *mocks.MockTimelineService) {}, // No calls to the mock are expected
+			request:              httptest.NewRequest(http.MethodGet, "/timeline?next_cursor=abc", nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error parsing next_cursor: next_cursor must be a valid tweet id",
+		},
 		{
 			name:                 "Failure - 400 Bad Request for missing user ID",
 			setupMock:            func(mock *mocks.MockTimelineService) {}, // No calls to the mock are expected
@@ -74,6 +108,23 @@ func TestHandleGetTimeline(t *testing.T) {
 			expectedStatus:       http.StatusBadRequest,
 			expectedBodyContains: "error parsing limit: limit must be a positive number",
 		},
+		{
+			name:                 "Failure - 400 Bad Request for a limit over the maximum",
+			setupMock:            func(mock *mocks.MockTimelineService) {}, // No calls to the mock are expected
+			request:              httptest.NewRequest(http.MethodGet, "/timeline?limit=1125899906842624", nil),
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error parsing limit: limit must be at most 100",
+		},
+		{
+			name: "Success - 200 OK with the maximum limit",
+			setupMock: func(mock *mocks.MockTimelineService) {
+				mock.EXPECT().GetTimeline(gomock.Any(), gomock.Any(), 100, "").Return(domain.TimelinePage{}, nil)
+			},
+			request:        httptest.NewRequest(http.MethodGet, "/timeline?limit=100", nil),
+			setupRequest:   func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:                 "Failure - 400 Bad Request for method not allowed",
 			setupMock:            func(mock *mocks.MockTimelineService) {}, // No calls to the mock are expected
@@ -86,8 +137,8 @@ func TestHandleGetTimeline(t *testing.T) {
 			name: "Failure - 500 Internal Server Error from service",
 			setupMock: func(mock *mocks.MockTimelineService) {
 				mock.EXPECT().
-					GetTimeline(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("database is down"))
+					GetTimeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.TimelinePage{}, errors.New("database is down"))
 			},
 			request:              httptest.NewRequest(http.MethodGet, "/timeline", nil),
 			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11") },
@@ -115,6 +166,7 @@ func TestHandleGetTimeline(t *testing.T) {
 
 			// Assert
 			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Equal(t, tc.expectedDegraded, recorder.Header().Get(reader.DegradedHeader))
 
 			if tc.expectedBodyContains != "" {
 				assert.Equal(t, recorder.Body.String(), tc.expectedBodyContains)
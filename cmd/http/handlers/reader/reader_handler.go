@@ -6,9 +6,14 @@ import (
 	"github.com/renzonaitor/tweet-api/internal/domain"
 )
 
+// DegradedHeader is set to "true" on the responses computed from PostgreSQL because the
+// timeline cache was unavailable: they may be slower and miss the latest tweets.
+const DegradedHeader = "X-Timeline-Degraded"
+
 //go:generate mockgen -source=reader_handler.go -destination=./../mocks/timeline_service_mock.go -package=mocks
 type TimelineService interface {
-	GetTimeline(ctx context.Context, userID string, limit int) ([]domain.Tweet, error)
+	GetTimeline(ctx context.Context, userID string, limit int, cursor string) (domain.TimelinePage, error)
+	CountNewTweets(ctx context.Context, userID, sinceCursor string) (domain.NewTweetsCount, error)
 }
 
 // ReaderHandler depends on the interfaces, not concrete types.
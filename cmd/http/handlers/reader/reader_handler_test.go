@@ -9,11 +9,16 @@ import (
 )
 
 type TimelineServiceMock struct {
-	GetTimelineFunc func(ctx context.Context, userID string, limit int) ([]domain.Tweet, error)
+	GetTimelineFunc    func(ctx context.Context, userID string, limit int, cursor string) (domain.TimelinePage, error)
+	CountNewTweetsFunc func(ctx context.Context, userID, sinceCursor string) (domain.NewTweetsCount, error)
 }
 
-func (m *TimelineServiceMock) GetTimeline(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
-	return m.GetTimelineFunc(ctx, userID, limit)
+func (m *TimelineServiceMock) GetTimeline(ctx context.Context, userID string, limit int, cursor string) (domain.TimelinePage, error) {
+	return m.GetTimelineFunc(ctx, userID, limit, cursor)
+}
+
+func (m *TimelineServiceMock) CountNewTweets(ctx context.Context, userID, sinceCursor string) (domain.NewTweetsCount, error) {
+	return m.CountNewTweetsFunc(ctx, userID, sinceCursor)
 }
 
 func Test_NewHandler(t *testing.T) {
@@ -0,0 +1,24 @@
+package stream
+
+import (
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+)
+
+//go:generate mockgen -source=stream_handler.go -destination=./../mocks/realtime_service_mock.go -package=mocks
+type RealtimeService interface {
+	Connect(userID string) (*realtime.Client, error)
+	Disconnect(client *realtime.Client)
+	Subscribe(client *realtime.Client, topic string) error
+	Unsubscribe(client *realtime.Client, topic string) error
+}
+
+// StreamHandler depends on the interfaces, not concrete types.
+type StreamHandler struct {
+	Realtime RealtimeService
+}
+
+func NewHandler(realtime RealtimeService) *StreamHandler {
+	return &StreamHandler{
+		Realtime: realtime,
+	}
+}
@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHandler(t *testing.T) {
+	type args struct {
+		realtimeService RealtimeService
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should return a new StreamHandler",
+			args: args{
+				realtimeService: realtime.NewService(nil, 0, logging.Discard()),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.args.realtimeService)
+			assert.NotNil(t, handler)
+			assert.Equal(t, tt.args.realtimeService, handler.Realtime)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+)
+
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+
+	replySubscribed   = "subscribed"
+	replyUnsubscribed = "unsubscribed"
+	replyError        = "error"
+)
+
+// Command is a message sent by the client over the socket.
+type Command struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// Reply acknowledges a Command or reports why it was rejected.
+type Reply struct {
+	Type  string `json:"type"`
+	Topic string `json:"topic,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func (h *StreamHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	client, err := h.Realtime.Connect(userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, realtime.ErrTooManyConnections) {
+			status = http.StatusTooManyRequests
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error opening connection: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+	defer h.Realtime.Disconnect(client)
+
+	// Accept writes the error response itself if the handshake fails.
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go h.readCommands(ctx, cancel, conn, client)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-client.Events():
+			if !ok {
+				return
+			}
+			if err := wsjson.Write(ctx, conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readCommands processes subscribe/unsubscribe commands until the client goes away,
+// then cancels the connection context so the write loop stops as well.
+func (h *StreamHandler) readCommands(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, client *realtime.Client) {
+	defer cancel()
+
+	for {
+		var cmd Command
+		if err := wsjson.Read(ctx, conn, &cmd); err != nil {
+			return
+		}
+
+		reply := h.applyCommand(client, cmd)
+		if err := wsjson.Write(ctx, conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (h *StreamHandler) applyCommand(client *realtime.Client, cmd Command) Reply {
+	var err error
+	var replyType string
+
+	switch cmd.Action {
+	case actionSubscribe:
+		err = h.Realtime.Subscribe(client, cmd.Topic)
+		replyType = replySubscribed
+	case actionUnsubscribe:
+		err = h.Realtime.Unsubscribe(client, cmd.Topic)
+		replyType = replyUnsubscribed
+	default:
+		err = fmt.Errorf("unknown action %q", cmd.Action)
+	}
+
+	if err != nil {
+		return Reply{Type: replyError, Topic: cmd.Topic, Error: err.Error()}
+	}
+	return Reply{Type: replyType, Topic: cmd.Topic}
+}
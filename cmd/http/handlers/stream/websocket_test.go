@@ -0,0 +1,188 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/stream"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	realtimemocks "github.com/renzonaitor/tweet-api/internal/service/realtime/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestHandleWebSocket_Rejections covers the requests refused before the upgrade.
+func TestHandleWebSocket_Rejections(t *testing.T) {
+	const testUserID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+
+	testCases := []struct {
+		name                 string
+		method               string
+		setupRequest         func(req *http.Request)
+		setupMock            func(mock *mocks.MockRealtimeService)
+		expectedStatus       int
+		expectedBodyContains string
+	}{
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodPost,
+			setupRequest:         func(req *http.Request) {},
+			setupMock:            func(mock *mocks.MockRealtimeService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodGet,
+			setupRequest:         func(req *http.Request) {},
+			setupMock:            func(mock *mocks.MockRealtimeService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:         "Failure - 429 Too Many Requests when the connection limit is reached",
+			method:       http.MethodGet,
+			setupRequest: func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock: func(mock *mocks.MockRealtimeService) {
+				mock.EXPECT().Connect(testUserID).Return(nil, realtime.ErrTooManyConnections)
+			},
+			expectedStatus:       http.StatusTooManyRequests,
+			expectedBodyContains: "too many open connections",
+		},
+		{
+			name:         "Failure - 500 Internal Server Error from service",
+			method:       http.MethodGet,
+			setupRequest: func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock: func(mock *mocks.MockRealtimeService) {
+				mock.EXPECT().Connect(testUserID).Return(nil, errors.New("unexpected"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error opening connection",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockRealtimeService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := stream.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/ws", nil)
+			tc.setupRequest(request)
+
+			// Act
+			handler.HandleWebSocket(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+// TestHandleWebSocket_Stream runs the handler behind a real HTTP server and talks to it
+// as a client would, with a mocked broker feeding the realtime service.
+func TestHandleWebSocket_Stream(t *testing.T) {
+	userID := uuid.NewString()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockBroker := realtimemocks.NewMockBroker(ctrl)
+	service := realtime.NewService(mockBroker, 1, logging.Discard())
+
+	deliver := make(chan func(channel, payload string), 1)
+	mockBroker.EXPECT().
+		PSubscribe(gomock.Any(), "events:*", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+			deliver <- handler
+			<-ctx.Done()
+			return nil
+		})
+
+	runCtx, stopRun := context.WithCancel(context.Background())
+	t.Cleanup(stopRun)
+	go func() { _ = service.Run(runCtx) }()
+	dispatch := <-deliver
+
+	server := httptest.NewServer(http.HandlerFunc(stream.NewHandler(service).HandleWebSocket))
+	t.Cleanup(server.Close)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	headers := http.Header{}
+	headers.Set("X-User-ID", userID)
+	conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{HTTPHeader: headers})
+	require.NoError(t, err)
+	defer conn.CloseNow()
+
+	t.Run("Success - subscribe is acknowledged", func(t *testing.T) {
+		require.NoError(t, wsjson.Write(ctx, conn, stream.Command{Action: "subscribe", Topic: "timeline"}))
+
+		var reply stream.Reply
+		require.NoError(t, wsjson.Read(ctx, conn, &reply))
+		assert.Equal(t, stream.Reply{Type: "subscribed", Topic: "timeline"}, reply)
+	})
+
+	t.Run("Success - published events reach the socket", func(t *testing.T) {
+		dispatch(domain.TimelineEventsChannel(userID),
+			fmt.Sprintf(`{"type":"tweet_created","tweet_id":"%s","author_id":"%s"}`, tweetID, authorID))
+
+		var event domain.Event
+		require.NoError(t, wsjson.Read(ctx, conn, &event))
+		assert.Equal(t, domain.Event{
+			Topic:    "timeline",
+			Type:     domain.EventTweetCreated,
+			TweetID:  tweetID,
+			AuthorID: authorID,
+		}, event)
+	})
+
+	t.Run("Failure - invalid topic returns an error reply", func(t *testing.T) {
+		require.NoError(t, wsjson.Write(ctx, conn, stream.Command{Action: "subscribe", Topic: "tweet:nope"}))
+
+		var reply stream.Reply
+		require.NoError(t, wsjson.Read(ctx, conn, &reply))
+		assert.Equal(t, "error", reply.Type)
+		assert.Contains(t, reply.Error, "invalid topic")
+	})
+
+	t.Run("Failure - second connection exceeds the per-user limit", func(t *testing.T) {
+		_, resp, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{HTTPHeader: headers})
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("Success - slot is released after the client closes", func(t *testing.T) {
+		require.NoError(t, conn.Close(websocket.StatusNormalClosure, ""))
+
+		assert.Eventually(t, func() bool {
+			next, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{HTTPHeader: headers})
+			if err != nil {
+				return false
+			}
+			next.CloseNow()
+			return true
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+}
@@ -0,0 +1,132 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+type BlockUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// HandleBlockUser makes the X-User-ID user block the user in the body. The follows between
+// both users are removed and neither can follow the other until the block is lifted.
+func (h *WriterHandler) HandleBlockUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error reading body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	var block BlockUserRequest
+	if err := json.Unmarshal(bytes, &block); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmarshalling body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	if err := uuid.Validate(block.UserID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user id must be a valid user id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	if sameUser(userID, block.UserID) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user cannot block themselves"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	err = h.UserService.BlockUser(r.Context(), domain.BlockUser{
+		BlockerID: userID,
+		BlockedID: block.UserID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error blocking user: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnblockUser lifts the block of the X-User-ID user on the user in the {id} path
+// segment. The follows removed by the block aren't restored.
+func (h *WriterHandler) HandleUnblockUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	blockedID := r.PathValue("id")
+	if err := uuid.Validate(blockedID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user id must be a valid user id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	err := h.UserService.UnblockUser(r.Context(), domain.BlockUser{
+		BlockerID: userID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error unblocking user: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,113 @@
+package writer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+)
+
+type EditTweetRequest struct {
+	Text string `json:"text"`
+}
+
+// HandleEditTweet replaces the text of the tweet in the {id} path segment. Only its author can
+// edit it, within the configured edit window.
+func (h WriterHandler) HandleEditTweet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		w.Header().Set("Allow", http.MethodPatch)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	tweetID := r.PathValue("id")
+	if err := uuid.Validate(tweetID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("tweet id must be a valid tweet id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error reading body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	var edit EditTweetRequest
+	if err = json.Unmarshal(bytes, &edit); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmarshalling body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	text, err := h.validateMaxLengthText(edit.Text)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error validating tweet content: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	tweet, err := h.UserService.EditTweet(r.Context(), tweetID, userID, text)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, user.ErrTweetNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, user.ErrNotTweetAuthor):
+			status = http.StatusForbidden
+		case errors.Is(err, user.ErrEditWindowExpired):
+			status = http.StatusConflict
+		case errors.Is(err, user.ErrTweetRejected):
+			status = http.StatusUnprocessableEntity
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error editing tweet: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	tweetResponse, err := json.Marshal(tweet)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(tweetResponse)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,190 @@
+package writer_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleEditTweet(t *testing.T) {
+	const testUserID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+	tweetID := uuid.NewString()
+	editedAt := time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC)
+	editedTweet := domain.Tweet{
+		ID:        tweetID,
+		Text:      "hello world",
+		UserID:    testUserID,
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		EditedAt:  &editedAt,
+	}
+
+	testCases := []struct {
+		name                 string
+		method               string
+		tweetID              string
+		userID               string
+		body                 string
+		setupMock            func(mock *mocks.MockUserService)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name:    "Success - 200 OK",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "  hello world  "}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(editedTweet, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"id": "` + tweetID + `",
+				"text": "hello world",
+				"user_id": "` + testUserID + `",
+				"created_at": "2025-01-01T10:00:00.000Z",
+				"edited_at": "2025-01-01T10:05:00.000Z"
+			}`,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodPut,
+			tweetID:              tweetID,
+			userID:               testUserID,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodPatch,
+			tweetID:              tweetID,
+			body:                 `{"text": "hello world"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid tweet id",
+			method:               http.MethodPatch,
+			tweetID:              "abc",
+			userID:               testUserID,
+			body:                 `{"text": "hello world"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "tweet id must be a valid tweet id",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for empty text",
+			method:               http.MethodPatch,
+			tweetID:              tweetID,
+			userID:               testUserID,
+			body:                 `{"text": "   "}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "tweet text cannot be empty",
+		},
+		{
+			name:    "Failure - 404 Not Found",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "hello world"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(domain.Tweet{}, user.ErrTweetNotFound)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: user.ErrTweetNotFound.Error(),
+		},
+		{
+			name:    "Failure - 403 Forbidden for another user's tweet",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "hello world"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(domain.Tweet{}, user.ErrNotTweetAuthor)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBodyContains: user.ErrNotTweetAuthor.Error(),
+		},
+		{
+			name:    "Failure - 409 Conflict after the edit window",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "hello world"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(domain.Tweet{}, user.ErrEditWindowExpired)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBodyContains: user.ErrEditWindowExpired.Error(),
+		},
+		{
+			name:    "Failure - 422 Unprocessable Entity for an edit rejected by moderation",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "hello world"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(domain.Tweet{}, user.ErrTweetRejected)
+			},
+			expectedStatus:       http.StatusUnprocessableEntity,
+			expectedBodyContains: user.ErrTweetRejected.Error(),
+		},
+		{
+			name:    "Failure - 500 Internal Server Error from service",
+			method:  http.MethodPatch,
+			tweetID: tweetID,
+			userID:  testUserID,
+			body:    `{"text": "hello world"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().EditTweet(gomock.Any(), tweetID, testUserID, "hello world").Return(domain.Tweet{}, errors.New("database connection lost"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error editing tweet",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockUserService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := writer.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/tweets/"+tc.tweetID, strings.NewReader(tc.body))
+			request.SetPathValue("id", tc.tweetID)
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+
+			// Act
+			handler.HandleEditTweet(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
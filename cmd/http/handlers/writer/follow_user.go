@@ -2,11 +2,13 @@ package writer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
 )
 
 type FollowUserRequest struct {
@@ -49,7 +51,7 @@ func (h *WriterHandler) HandleFollowUser(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if userID == follow.FollowUserID {
+	if sameUser(userID, follow.FollowUserID) {
 		w.WriteHeader(http.StatusBadRequest)
 		_, err = w.Write([]byte("user cannot follow themselves"))
 		if err != nil {
@@ -63,7 +65,11 @@ func (h *WriterHandler) HandleFollowUser(w http.ResponseWriter, r *http.Request)
 		FollowedID: follow.FollowUserID,
 	})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if errors.Is(err, user.ErrUserBlocked) {
+			status = http.StatusForbidden
+		}
+		w.WriteHeader(status)
 		_, err = w.Write([]byte(fmt.Sprintf("error following user: %s", err)))
 		if err != nil {
 			return
@@ -10,6 +10,7 @@ import (
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -88,6 +89,19 @@ func TestHandleFollowUser(t *testing.T) {
 			expectedStatus:       http.StatusBadRequest,
 			expectedBodyContains: "user cannot follow themselves",
 		},
+		{
+			name: "Failure - 400 Bad Request for following self with the ID in upper case",
+			request: httptest.NewRequest(
+				http.MethodPost,
+				"/api/v1/follow",
+				strings.NewReader(`{"follow_user_id": "`+strings.ToUpper(xUserID)+`"}`)),
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", xUserID)
+			},
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user cannot follow themselves",
+		},
 		{
 			name: "Failure - 500 Internal Server Error from service",
 			request: httptest.NewRequest(
@@ -105,6 +119,23 @@ func TestHandleFollowUser(t *testing.T) {
 			expectedStatus:       http.StatusInternalServerError,
 			expectedBodyContains: "error following user",
 		},
+		{
+			name: "Failure - 403 Forbidden when a block exists between the users",
+			request: httptest.NewRequest(
+				http.MethodPost,
+				"/api/v1/follow",
+				strings.NewReader(`{"follow_user_id": "`+userToFollow+`"}`)),
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", xUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					FollowUser(gomock.Any(), gomock.Any()).
+					Return(user.ErrUserBlocked)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBodyContains: "a block exists between the users",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,60 @@
+package writer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+)
+
+// HandleGetTweetHistory returns the tweet in the {id} path segment with its previous texts.
+func (h WriterHandler) HandleGetTweetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tweetID := r.PathValue("id")
+	if err := uuid.Validate(tweetID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("tweet id must be a valid tweet id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	history, err := h.UserService.GetTweetHistory(r.Context(), tweetID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, user.ErrTweetNotFound) {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(fmt.Sprintf("error getting tweet history: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	historyResponse, err := json.Marshal(history)
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(historyResponse)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,122 @@
+package writer_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleGetTweetHistory(t *testing.T) {
+	const authorID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+	tweetID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(5 * time.Minute)
+	history := domain.TweetHistory{
+		Tweet:     domain.Tweet{ID: tweetID, Text: "hello world", UserID: authorID, CreatedAt: createdAt, EditedAt: &editedAt},
+		Revisions: []domain.TweetRevision{{Text: "helo world", CreatedAt: createdAt, ReplacedAt: editedAt}},
+	}
+
+	testCases := []struct {
+		name                 string
+		method               string
+		tweetID              string
+		setupMock            func(mock *mocks.MockUserService)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name:    "Success - 200 OK",
+			method:  http.MethodGet,
+			tweetID: tweetID,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().GetTweetHistory(gomock.Any(), tweetID).Return(history, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"tweet": {
+					"id": "` + tweetID + `",
+					"text": "hello world",
+					"user_id": "` + authorID + `",
+					"created_at": "2025-01-01T10:00:00.000Z",
+					"edited_at": "2025-01-01T10:05:00.000Z"
+				},
+				"revisions": [
+					{"text": "helo world", "created_at": "2025-01-01T10:00:00.000Z", "replaced_at": "2025-01-01T10:05:00.000Z"}
+				]
+			}`,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodPost,
+			tweetID:              tweetID,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid tweet id",
+			method:               http.MethodGet,
+			tweetID:              "abc",
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "tweet id must be a valid tweet id",
+		},
+		{
+			name:    "Failure - 404 Not Found",
+			method:  http.MethodGet,
+			tweetID: tweetID,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().GetTweetHistory(gomock.Any(), tweetID).Return(domain.TweetHistory{}, user.ErrTweetNotFound)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: user.ErrTweetNotFound.Error(),
+		},
+		{
+			name:    "Failure - 500 Internal Server Error from service",
+			method:  http.MethodGet,
+			tweetID: tweetID,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().GetTweetHistory(gomock.Any(), tweetID).Return(domain.TweetHistory{}, errors.New("database connection lost"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error getting tweet history",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockUserService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := writer.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/tweets/"+tc.tweetID+"/history", nil)
+			request.SetPathValue("id", tc.tweetID)
+
+			// Act
+			handler.HandleGetTweetHistory(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+			}
+		})
+	}
+}
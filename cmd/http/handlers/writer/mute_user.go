@@ -0,0 +1,131 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+type MuteUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// HandleMuteUser makes the X-User-ID user mute the user in the body. The follow is kept, but the
+// tweets of the muted user are hidden from the muter's timeline.
+func (h *WriterHandler) HandleMuteUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error reading body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	var mute MuteUserRequest
+	if err := json.Unmarshal(bytes, &mute); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmarshalling body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	if err := uuid.Validate(mute.UserID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user id must be a valid user id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	if sameUser(userID, mute.UserID) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user cannot mute themselves"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	err = h.UserService.MuteUser(r.Context(), domain.MuteUser{
+		MuterID: userID,
+		MutedID: mute.UserID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error muting user: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnmuteUser lifts the mute of the X-User-ID user on the user in the {id} path segment.
+func (h *WriterHandler) HandleUnmuteUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	mutedID := r.PathValue("id")
+	if err := uuid.Validate(mutedID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte("user id must be a valid user id"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	err := h.UserService.UnmuteUser(r.Context(), domain.MuteUser{
+		MuterID: userID,
+		MutedID: mutedID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmuting user: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
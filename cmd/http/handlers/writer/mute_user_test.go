@@ -0,0 +1,226 @@
+package writer_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleMuteUser(t *testing.T) {
+	mutedID := uuid.NewString()
+
+	testCases := []struct {
+		name                 string
+		method               string
+		userID               string
+		body                 string
+		setupMock            func(mock *mocks.MockUserService)
+		expectedStatus       int
+		expectedBodyContains string
+	}{
+		{
+			name:   "Success - 204 No Content",
+			method: http.MethodPost,
+			userID: xUserID,
+			body:   `{"user_id": "` + mutedID + `"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().MuteUser(gomock.Any(), domain.MuteUser{MuterID: xUserID, MutedID: mutedID}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodGet,
+			userID:               xUserID,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodPost,
+			body:                 `{"user_id": "` + mutedID + `"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid body",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			body:                 `{`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error unmarshalling body",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid user id",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			body:                 `{"user_id": "not-a-uuid"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user id must be a valid user id",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for muting self",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			body:                 `{"user_id": "` + xUserID + `"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user cannot mute themselves",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for muting self with the ID in upper case",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			body:                 `{"user_id": "` + strings.ToUpper(xUserID) + `"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user cannot mute themselves",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for muting self with the ID in braces",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			body:                 `{"user_id": "{` + xUserID + `}"}`,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user cannot mute themselves",
+		},
+		{
+			name:   "Failure - 500 Internal Server Error from service",
+			method: http.MethodPost,
+			userID: xUserID,
+			body:   `{"user_id": "` + mutedID + `"}`,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().MuteUser(gomock.Any(), gomock.Any()).Return(errors.New("database connection lost"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error muting user",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockUserService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := writer.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/mutes", strings.NewReader(tc.body))
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+
+			// Act
+			handler.HandleMuteUser(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+		})
+	}
+}
+
+func TestHandleUnmuteUser(t *testing.T) {
+	mutedID := uuid.NewString()
+
+	testCases := []struct {
+		name                 string
+		method               string
+		userID               string
+		mutedID              string
+		setupMock            func(mock *mocks.MockUserService)
+		expectedStatus       int
+		expectedBodyContains string
+	}{
+		{
+			name:    "Success - 204 No Content",
+			method:  http.MethodDelete,
+			userID:  xUserID,
+			mutedID: mutedID,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().UnmuteUser(gomock.Any(), domain.MuteUser{MuterID: xUserID, MutedID: mutedID}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:                 "Failure - 405 Method Not Allowed",
+			method:               http.MethodPost,
+			userID:               xUserID,
+			mutedID:              mutedID,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedBodyContains: "Method Not Allowed",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodDelete,
+			mutedID:              mutedID,
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for invalid user id",
+			method:               http.MethodDelete,
+			userID:               xUserID,
+			mutedID:              "not-a-uuid",
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "user id must be a valid user id",
+		},
+		{
+			name:    "Failure - 500 Internal Server Error from service",
+			method:  http.MethodDelete,
+			userID:  xUserID,
+			mutedID: mutedID,
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().UnmuteUser(gomock.Any(), gomock.Any()).Return(errors.New("database connection lost"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "error unmuting user",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockUserService(ctrl)
+			tc.setupMock(mockService)
+
+			handler := writer.NewHandler(mockService)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/mutes/"+tc.mutedID, nil)
+			request.SetPathValue("id", tc.mutedID)
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+
+			// Act
+			handler.HandleUnmuteUser(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+		})
+	}
+}
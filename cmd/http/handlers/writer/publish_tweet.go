@@ -2,21 +2,28 @@ package writer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
-	"time"
-	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
 )
 
-const maxTweetLength = 280
+// maxMediaPerTweet is how many media a tweet can have attached.
+const maxMediaPerTweet = 4
+
+// IdempotencyKeyHeader may carry the idempotency key instead of the idempotency_key field.
+const IdempotencyKeyHeader = "Idempotency-Key"
 
 type TweetRequest struct {
 	Text           string `json:"text"`
 	IdempotencyKey string `json:"idempotency_key"`
+	// MediaIDs are uploaded media to attach, in display order.
+	MediaIDs []string `json:"media_ids"`
 }
 
 func (h WriterHandler) HandlePublishTweet(w http.ResponseWriter, r *http.Request) {
@@ -66,17 +73,42 @@ func (h WriterHandler) HandlePublishTweet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// TODO validate idempotency_key with UUID pattern
+	tweetID, err := idempotencyKey(r.Header.Get(IdempotencyKeyHeader), tweet.IdempotencyKey)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error validating idempotency key: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
 
-	newTweet, err := h.UserService.PublishTweet(r.Context(), domain.Tweet{
-		ID:        tweet.IdempotencyKey,
-		Text:      text,
-		UserID:    userID,
-		CreatedAt: time.Now().Format(time.RFC3339),
+	media, err := mediaToAttach(tweet.MediaIDs)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error validating media: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	newTweet, created, err := h.UserService.PublishTweet(r.Context(), domain.Tweet{
+		ID:     tweetID,
+		Text:   text,
+		UserID: userID,
+		Media:  media,
 	})
 
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, user.ErrIdempotencyKeyMismatch), errors.Is(err, user.ErrTweetRejected):
+			status = http.StatusUnprocessableEntity
+		case errors.Is(err, user.ErrMediaNotAttachable):
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
 		_, err = w.Write([]byte(fmt.Sprintf("error publishing tweet: %s", err)))
 		if err != nil {
 			return
@@ -84,8 +116,13 @@ func (h WriterHandler) HandlePublishTweet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// A replay gets the stored tweet back with 200, so clients can tell it apart from a creation.
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 
 	tweetResponse, err := json.Marshal(newTweet)
 	if err != nil {
@@ -101,22 +138,72 @@ func (h WriterHandler) HandlePublishTweet(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// idempotencyKey returns the key sent in the header or in the body, which becomes the tweet ID.
+// The key must be a UUID; when none is sent a new one is generated, so the request can't be
+// deduplicated. Sending different keys in both places is an error.
+func idempotencyKey(header, body string) (string, error) {
+	key := header
+	if key == "" {
+		key = body
+	} else if body != "" && body != header {
+		return "", fmt.Errorf("header %s and idempotency_key don't match", IdempotencyKeyHeader)
+	}
+	if key == "" {
+		return uuid.NewString(), nil
+	}
+
+	parsed, err := uuid.Parse(key)
+	if err != nil {
+		return "", errors.New("idempotency key must be a UUID")
+	}
+	// The canonical form, so the same key written in another case maps to the same tweet.
+	return parsed.String(), nil
+}
+
+// mediaToAttach validates the media IDs of a publish request. Like idempotency keys, they are
+// canonicalized, so the same media written in another case is caught as a duplicate.
+func mediaToAttach(mediaIDs []string) ([]domain.Media, error) {
+	if len(mediaIDs) > maxMediaPerTweet {
+		return nil, fmt.Errorf("a tweet can have at most %d media", maxMediaPerTweet)
+	}
+	if len(mediaIDs) == 0 {
+		return nil, nil
+	}
+
+	media := make([]domain.Media, len(mediaIDs))
+	seen := make(map[string]bool, len(mediaIDs))
+	for i, mediaID := range mediaIDs {
+		parsed, err := uuid.Parse(mediaID)
+		if err != nil {
+			return nil, fmt.Errorf("media id %q must be a UUID", mediaID)
+		}
+		id := parsed.String()
+		if seen[id] {
+			return nil, fmt.Errorf("media %s is attached twice", id)
+		}
+		seen[id] = true
+		media[i] = domain.Media{ID: id}
+	}
+	return media, nil
+}
+
 func (h WriterHandler) validateMaxLengthText(text string) (string, error) {
 	// 2. Validate the tweet content.
-	// First, trim leading/trailing whitespace to handle empty or space-only tweets.
-	trimmedText := strings.TrimSpace(text)
-	// Count runes, not bytes, to correctly handle multi-byte characters like emojis.
-	runeCount := utf8.RuneCountInString(trimmedText)
+	// The text is trimmed of leading/trailing whitespace, to handle empty or space-only
+	// tweets, and NFC normalized, so what's stored is exactly what was counted.
+	result := tweettext.Parse(text)
 
-	if runeCount == 0 {
+	if result.Text == "" {
 		// It's a good practice to return specific validation errors.
 		return "", fmt.Errorf("tweet text cannot be empty")
 	}
 
-	if runeCount > maxTweetLength {
-		return "", fmt.Errorf("tweet exceeds maximum length of %d characters", maxTweetLength)
+	// Count as users see the text: by characters, not bytes or code points, with CJK and
+	// emoji weighing two and every URL as a shortened link, whatever its length.
+	if result.WeightedLength > tweettext.MaxLength {
+		return "", fmt.Errorf("tweet exceeds maximum length of %d characters", tweettext.MaxLength)
 	}
 
-	// Use the trimmed text for the actual tweet content.
-	return trimmedText, nil
+	// Use the normalized text for the actual tweet content.
+	return result.Text, nil
 }
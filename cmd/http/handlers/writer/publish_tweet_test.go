@@ -12,6 +12,7 @@ import (
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
 	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -30,9 +31,16 @@ func TestHandlePublishTweet(t *testing.T) {
 		ID:        idempotencyKey,
 		Text:      "This is a valid tweet!",
 		UserID:    testUserID,
-		CreatedAt: time.Now().Format(time.RFC3339),
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
 	}
 
+	mediaID := uuid.NewString()
+	tweetWithMedia := mockTweetResponse
+	tweetWithMedia.Media = []domain.Media{
+		{ID: mediaID, UserID: testUserID, ContentType: "image/png", Size: 2048, CreatedAt: mockTweetResponse.CreatedAt},
+	}
+	tooManyMedia := `"` + strings.Join([]string{uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()}, `", "`) + `"`
+
 	testCases := []struct {
 		name                 string
 		body                 string
@@ -43,7 +51,7 @@ func TestHandlePublishTweet(t *testing.T) {
 		expectedJSONResponse *domain.Tweet
 	}{
 		{
-			name: "Success - 200 OK",
+			name: "Success - 201 Created",
 			body: `{"text": "This is a valid tweet!", "idempotency_key": "` + idempotencyKey + `"}`,
 			setupRequest: func(req *http.Request) {
 				req.Header.Set("X-User-ID", testUserID)
@@ -51,13 +59,175 @@ func TestHandlePublishTweet(t *testing.T) {
 			},
 			setupMock: func(mock *mocks.MockUserService) {
 				mock.EXPECT().
-					PublishTweet(gomock.Any(), gomock.Any()).
-					Return(mockTweetResponse, nil).
+					PublishTweet(gomock.Any(), withTweetID(idempotencyKey)).
+					Return(mockTweetResponse, true, nil).
 					Times(1)
 			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
+		{
+			name: "Success - 200 OK for a replayed idempotency key",
+			body: `{"text": "This is a valid tweet!", "idempotency_key": "` + idempotencyKey + `"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), withTweetID(idempotencyKey)).
+					Return(mockTweetResponse, false, nil)
+			},
 			expectedStatus:       http.StatusOK,
 			expectedJSONResponse: &mockTweetResponse,
 		},
+		{
+			name: "Success - Idempotency-Key header",
+			body: `{"text": "This is a valid tweet!"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+				req.Header.Set(writer.IdempotencyKeyHeader, strings.ToUpper(idempotencyKey))
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), withTweetID(idempotencyKey)).
+					Return(mockTweetResponse, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
+		{
+			name: "Success - missing idempotency key gets a generated tweet ID",
+			body: `{"text": "This is a valid tweet!"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), gomock.Cond(func(tweet domain.Tweet) bool {
+						return uuid.Validate(tweet.ID) == nil && tweet.ID != idempotencyKey
+					})).
+					Return(mockTweetResponse, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
+		{
+			name: "Success - text is stored trimmed and NFC normalized",
+			body: `{"text": "  Cafe\u0301 ", "idempotency_key": "` + idempotencyKey + `"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), gomock.Cond(func(tweet domain.Tweet) bool {
+						return tweet.Text == "Caf\u00e9"
+					})).
+					Return(mockTweetResponse, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
+		{
+			name: "Success - 201 Created with media",
+			body: `{"text": "This is a valid tweet!", "idempotency_key": "` + idempotencyKey + `", "media_ids": ["` + strings.ToUpper(mediaID) + `"]}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), gomock.Cond(func(tweet domain.Tweet) bool {
+						return tweet.ID == idempotencyKey && len(tweet.Media) == 1 && tweet.Media[0].ID == mediaID
+					})).
+					Return(tweetWithMedia, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &tweetWithMedia,
+		},
+		{
+			name:                 "Failure - 400 Bad Request for a media id that isn't a UUID",
+			body:                 `{"text": "This is a valid tweet!", "media_ids": ["not-a-uuid"]}`,
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: `media id "not-a-uuid" must be a UUID`,
+		},
+		{
+			name:                 "Failure - 400 Bad Request for a media attached twice",
+			body:                 `{"text": "This is a valid tweet!", "media_ids": ["` + mediaID + `", "` + strings.ToUpper(mediaID) + `"]}`,
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "is attached twice",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for too many media",
+			body:                 `{"text": "This is a valid tweet!", "media_ids": [` + tooManyMedia + `]}`,
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "at most 4 media",
+		},
+		{
+			name: "Failure - 400 Bad Request for a media that can't be attached",
+			body: `{"text": "This is a valid tweet!", "media_ids": ["` + mediaID + `"]}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), gomock.Any()).
+					Return(domain.Tweet{}, false, user.ErrMediaNotAttachable)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: user.ErrMediaNotAttachable.Error(),
+		},
+		{
+			name: "Failure - 422 Unprocessable Entity for a tweet rejected by moderation",
+			body: `{"text": "This is a valid tweet!"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), gomock.Any()).
+					Return(domain.Tweet{}, false, user.ErrTweetRejected)
+			},
+			expectedStatus:       http.StatusUnprocessableEntity,
+			expectedBodyContains: user.ErrTweetRejected.Error(),
+		},
+		{
+			name:                 "Failure - 400 Bad Request for an idempotency key that isn't a UUID",
+			body:                 `{"text": "This is a valid tweet!", "idempotency_key": "not-a-uuid"}`,
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "idempotency key must be a UUID",
+		},
+		{
+			name: "Failure - 400 Bad Request for different header and body idempotency keys",
+			body: `{"text": "This is a valid tweet!", "idempotency_key": "` + idempotencyKey + `"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+				req.Header.Set(writer.IdempotencyKeyHeader, uuid.NewString())
+			},
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "don't match",
+		},
+		{
+			name: "Failure - 422 Unprocessable Entity for a key reused with a different request",
+			body: `{"text": "This is another tweet!", "idempotency_key": "` + idempotencyKey + `"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().
+					PublishTweet(gomock.Any(), withTweetID(idempotencyKey)).
+					Return(domain.Tweet{}, false, user.ErrIdempotencyKeyMismatch)
+			},
+			expectedStatus:       http.StatusUnprocessableEntity,
+			expectedBodyContains: user.ErrIdempotencyKeyMismatch.Error(),
+		},
 		{
 			name:                 "Failure - 405 Method Not Allowed",
 			body:                 "",
@@ -98,6 +268,38 @@ func TestHandlePublishTweet(t *testing.T) {
 			expectedStatus:       http.StatusBadRequest,
 			expectedBodyContains: "tweet exceeds maximum length of 280 characters",
 		},
+		{
+			name:                 "Failure - 400 Bad Request for CJK text exceeding the weighted max length",
+			body:                 `{"text": "` + strings.Repeat("字", 141) + `"}`,
+			setupRequest:         func(req *http.Request) { req.Header.Set("X-User-ID", testUserID) },
+			setupMock:            func(mock *mocks.MockUserService) {},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "tweet exceeds maximum length of 280 characters",
+		},
+		{
+			name: "Success - an emoji sequence counts as a single character",
+			body: `{"text": "` + strings.Repeat("a", 278) + `👨‍👩‍👧‍👦"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().PublishTweet(gomock.Any(), gomock.Any()).Return(mockTweetResponse, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
+		{
+			name: "Success - a URL counts as shortened towards the max length",
+			body: `{"text": "` + strings.Repeat("a", 250) + ` https://example.com/` + strings.Repeat("b", 100) + `"}`,
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("X-User-ID", testUserID)
+			},
+			setupMock: func(mock *mocks.MockUserService) {
+				mock.EXPECT().PublishTweet(gomock.Any(), gomock.Any()).Return(mockTweetResponse, true, nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedJSONResponse: &mockTweetResponse,
+		},
 		{
 			name: "Failure - 500 Internal Server Error from service",
 			body: `{"text": "a valid tweet", "idempotency_key": "` + idempotencyKey + `"}`,
@@ -108,7 +310,7 @@ func TestHandlePublishTweet(t *testing.T) {
 			setupMock: func(mock *mocks.MockUserService) {
 				mock.EXPECT().
 					PublishTweet(gomock.Any(), gomock.Any()).
-					Return(domain.Tweet{}, errors.New("idempotency key already exists"))
+					Return(domain.Tweet{}, false, errors.New("database connection lost"))
 			},
 			expectedStatus:       http.StatusInternalServerError,
 			expectedBodyContains: "error publishing tweet",
@@ -156,3 +358,8 @@ func TestHandlePublishTweet(t *testing.T) {
 		})
 	}
 }
+
+// withTweetID matches the tweets published with the given ID.
+func withTweetID(id string) gomock.Matcher {
+	return gomock.Cond(func(tweet domain.Tweet) bool { return tweet.ID == id })
+}
@@ -0,0 +1,93 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+)
+
+type ValidateTweetRequest struct {
+	Text string `json:"text"`
+}
+
+// ValidateTweetResponse tells how the text of a tweet counts, so clients can show the
+// remaining characters with the same rules as the server.
+type ValidateTweetResponse struct {
+	WeightedLength int  `json:"weighted_length"`
+	MaxLength      int  `json:"max_length"`
+	Remaining      int  `json:"remaining"`
+	Valid          bool `json:"valid"`
+	// URLs are the links found in the text, which count as shortened.
+	URLs []domain.URLEntity `json:"urls"`
+}
+
+// HandleValidateTweet counts the text of a tweet without publishing it. An invalid text, blank
+// or too long, is still answered with 200 and valid false: the request itself is valid.
+func (h WriterHandler) HandleValidateTweet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.Header.Get("X-User-ID") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("Header X-User-ID is required"))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error reading body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	var request ValidateTweetRequest
+	if err = json.Unmarshal(bytes, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("error unmarshalling body: %s", err)))
+		if err != nil {
+			return
+		}
+		return
+	}
+
+	result := tweettext.Parse(request.Text)
+	urls := result.URLs
+	if urls == nil {
+		urls = []domain.URLEntity{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	validateResponse, err := json.Marshal(ValidateTweetResponse{
+		WeightedLength: result.WeightedLength,
+		MaxLength:      tweettext.MaxLength,
+		Remaining:      tweettext.MaxLength - result.WeightedLength,
+		Valid:          result.Valid,
+		URLs:           urls,
+	})
+	if err != nil {
+		_, err = w.Write([]byte(err.Error()))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = w.Write(validateResponse)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,120 @@
+package writer_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/mocks"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/writer"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleValidateTweet(t *testing.T) {
+	const userID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+
+	testCases := []struct {
+		name                 string
+		method               string
+		userID               string
+		body                 string
+		expectedStatus       int
+		expectedBodyContains string
+		expectedJSONResponse string
+	}{
+		{
+			name:           "Success - weighted length of a valid text with a URL",
+			method:         http.MethodPost,
+			userID:         userID,
+			body:           `{"text": "ver 東京 https://example.com/a-very-long-path 👋🏽"}`,
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"weighted_length": 35,
+				"max_length": 280,
+				"remaining": 245,
+				"valid": true,
+				"urls": [{"url": "https://example.com/a-very-long-path", "start": 7, "end": 43}]
+			}`,
+		},
+		{
+			name:           "Success - too long text is answered as invalid",
+			method:         http.MethodPost,
+			userID:         userID,
+			body:           `{"text": "` + strings.Repeat("字", 141) + `"}`,
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"weighted_length": 282,
+				"max_length": 280,
+				"remaining": -2,
+				"valid": false,
+				"urls": []
+			}`,
+		},
+		{
+			name:           "Success - blank text is answered as invalid",
+			method:         http.MethodPost,
+			userID:         userID,
+			body:           `{"text": "  "}`,
+			expectedStatus: http.StatusOK,
+			expectedJSONResponse: `{
+				"weighted_length": 0,
+				"max_length": 280,
+				"remaining": 280,
+				"valid": false,
+				"urls": []
+			}`,
+		},
+		{
+			name:                 "Failure - 400 Bad Request for malformed JSON",
+			method:               http.MethodPost,
+			userID:               userID,
+			body:                 `{"text": `,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "error unmarshalling body",
+		},
+		{
+			name:                 "Failure - 400 Bad Request for missing user ID header",
+			method:               http.MethodPost,
+			body:                 `{"text": "hello"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Header X-User-ID is required",
+		},
+		{
+			name:           "Failure - 405 Method Not Allowed",
+			method:         http.MethodGet,
+			userID:         userID,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			handler := writer.NewHandler(mocks.NewMockUserService(ctrl))
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/api/v1/tweets/validate", strings.NewReader(tc.body))
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+
+			// Act
+			handler.HandleValidateTweet(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedBodyContains != "" {
+				assert.Contains(t, recorder.Body.String(), tc.expectedBodyContains)
+			}
+			if tc.expectedJSONResponse != "" {
+				assert.JSONEq(t, tc.expectedJSONResponse, recorder.Body.String())
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+			if tc.expectedStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+			}
+		})
+	}
+}
@@ -2,14 +2,23 @@ package writer
 
 import (
 	"context"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/renzonaitor/tweet-api/internal/domain"
 )
 
 //go:generate mockgen -source=write_handler.go -destination=./../mocks/user_service_mock.go -package=mocks
 type UserService interface {
 	FollowUser(ctx context.Context, followUser domain.FollowUser) error
-	PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error)
+	BlockUser(ctx context.Context, block domain.BlockUser) error
+	UnblockUser(ctx context.Context, block domain.BlockUser) error
+	MuteUser(ctx context.Context, mute domain.MuteUser) error
+	UnmuteUser(ctx context.Context, mute domain.MuteUser) error
+	// PublishTweet reports whether the tweet was created, or an idempotent replay returned it.
+	PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error)
+	EditTweet(ctx context.Context, tweetID, userID, text string) (domain.Tweet, error)
+	GetTweetHistory(ctx context.Context, tweetID string) (domain.TweetHistory, error)
 }
 
 // WriterHandler depends on the interfaces, not concrete types.
@@ -22,3 +31,14 @@ func NewHandler(userService UserService) *WriterHandler {
 		UserService: userService,
 	}
 }
+
+// sameUser reports whether both IDs belong to the same user. UUIDs are compared by value, so
+// neither their case nor the form they are written in lets a user act on themselves.
+func sameUser(userID, otherUserID string) bool {
+	id, err := uuid.Parse(userID)
+	otherID, otherErr := uuid.Parse(otherUserID)
+	if err != nil || otherErr != nil {
+		return strings.EqualFold(userID, otherUserID)
+	}
+	return id == otherID
+}
@@ -9,19 +9,49 @@ import (
 )
 
 type UserServiceMock struct {
-	FollowUserFunc   func(ctx context.Context, followUser domain.FollowUser) error
-	PublishTweetFunc func(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error)
+	FollowUserFunc      func(ctx context.Context, followUser domain.FollowUser) error
+	BlockUserFunc       func(ctx context.Context, block domain.BlockUser) error
+	UnblockUserFunc     func(ctx context.Context, block domain.BlockUser) error
+	MuteUserFunc        func(ctx context.Context, mute domain.MuteUser) error
+	UnmuteUserFunc      func(ctx context.Context, mute domain.MuteUser) error
+	PublishTweetFunc    func(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error)
+	EditTweetFunc       func(ctx context.Context, tweetID, userID, text string) (domain.Tweet, error)
+	GetTweetHistoryFunc func(ctx context.Context, tweetID string) (domain.TweetHistory, error)
 }
 
 func (m *UserServiceMock) FollowUser(ctx context.Context, followUser domain.FollowUser) error {
 	return m.FollowUserFunc(ctx, followUser)
 }
 
-func (m *UserServiceMock) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+func (m *UserServiceMock) BlockUser(ctx context.Context, block domain.BlockUser) error {
+	return m.BlockUserFunc(ctx, block)
+}
+
+func (m *UserServiceMock) UnblockUser(ctx context.Context, block domain.BlockUser) error {
+	return m.UnblockUserFunc(ctx, block)
+}
+
+func (m *UserServiceMock) MuteUser(ctx context.Context, mute domain.MuteUser) error {
+	return m.MuteUserFunc(ctx, mute)
+}
+
+func (m *UserServiceMock) UnmuteUser(ctx context.Context, mute domain.MuteUser) error {
+	return m.UnmuteUserFunc(ctx, mute)
+}
+
+func (m *UserServiceMock) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
 	return m.PublishTweetFunc(ctx, tweet)
 
 }
 
+func (m *UserServiceMock) EditTweet(ctx context.Context, tweetID, userID, text string) (domain.Tweet, error) {
+	return m.EditTweetFunc(ctx, tweetID, userID, text)
+}
+
+func (m *UserServiceMock) GetTweetHistory(ctx context.Context, tweetID string) (domain.TweetHistory, error) {
+	return m.GetTweetHistoryFunc(ctx, tweetID)
+}
+
 func Test_WriteHandler(t *testing.T) {
 	type args struct {
 		userService UserService
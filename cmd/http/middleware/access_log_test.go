@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/timeline", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/api/v1/tweet", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "error publishing tweet", http.StatusInternalServerError)
+	})
+
+	testCases := []struct {
+		name           string
+		request        *http.Request
+		expectedLevel  string
+		expectedRoute  string
+		expectedStatus float64
+	}{
+		{
+			name:           "Logs a served request at info level",
+			request:        httptest.NewRequest(http.MethodGet, "/api/v1/timeline", nil),
+			expectedLevel:  "INFO",
+			expectedRoute:  "/api/v1/timeline",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Logs a server error at error level",
+			request:        httptest.NewRequest(http.MethodPost, "/api/v1/tweet", nil),
+			expectedLevel:  "ERROR",
+			expectedRoute:  "/api/v1/tweet",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var output bytes.Buffer
+			logger, err := logging.New(&output, logging.Config{})
+			require.NoError(t, err)
+			handler := middleware.RequestID(middleware.AccessLog(logger)(mux))
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, tc.request)
+
+			// Assert
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+			assert.Equal(t, tc.expectedLevel, record["level"])
+			assert.Equal(t, tc.expectedRoute, record["route"])
+			assert.Equal(t, tc.expectedStatus, record["status"])
+			assert.Equal(t, tc.request.Method, record["method"])
+			assert.Equal(t, recorder.Header().Get(middleware.RequestIDHeader), record["request_id"])
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminOnly(t *testing.T) {
+	const adminID = "a00ffe35-fc64-45f3-be60-8c824ec0a352"
+
+	testCases := []struct {
+		name           string
+		adminIDs       []string
+		userID         string
+		expectedStatus int
+		expectCalled   bool
+	}{
+		{
+			name:           "Lets an admin through",
+			adminIDs:       []string{adminID},
+			userID:         adminID,
+			expectedStatus: http.StatusOK,
+			expectCalled:   true,
+		},
+		{
+			name:           "Lets an admin through whatever the case of the ID",
+			adminIDs:       []string{strings.ToUpper(adminID)},
+			userID:         adminID,
+			expectedStatus: http.StatusOK,
+			expectCalled:   true,
+		},
+		{
+			name:           "Rejects a user who isn't an admin",
+			adminIDs:       []string{adminID},
+			userID:         "a00ffe35-fc64-45f3-be60-8c824ec0a353",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Rejects a request without user",
+			adminIDs:       []string{adminID},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Rejects everyone when there are no admins",
+			userID:         adminID,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			called := false
+			handler := middleware.AdminOnly(tc.adminIDs)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			}))
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/admin/fan-out-jobs", nil)
+			if tc.userID != "" {
+				request.Header.Set("X-User-ID", tc.userID)
+			}
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Equal(t, tc.expectCalled, called)
+			if !tc.expectCalled {
+				assert.Contains(t, recorder.Body.String(), "admin access required")
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+)
+
+// unmatchedRoute labels requests that didn't match any registered pattern, so unknown
+// paths don't create a new series each.
+const unmatchedRoute = "unmatched"
+
+// statusRecorder captures the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// statusCode returns the status sent to the client: 200 when the handler didn't set one.
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Unwrap lets http.ResponseController and the websocket upgrade reach the original writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Metrics observes the latency of every request handled by next, labelled by the ServeMux
+// pattern that matched it, the method and the response status.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		// The ServeMux sets the matched pattern on the request while routing it.
+		route := r.Pattern
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(route, r.Method, strconv.Itoa(recorder.statusCode())).
+			Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestCount returns how many requests were observed with the given labels.
+func requestCount(t *testing.T, route, method, status string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	observer := metrics.HTTPRequestDuration.WithLabelValues(route, method, status)
+	require.NoError(t, observer.(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/timeline", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/api/v1/tweet", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	})
+
+	testCases := []struct {
+		name           string
+		request        *http.Request
+		expectedRoute  string
+		expectedStatus string
+	}{
+		{
+			name:           "Labels the matched route with the implicit 200",
+			request:        httptest.NewRequest(http.MethodGet, "/api/v1/timeline?limit=10", nil),
+			expectedRoute:  "/api/v1/timeline",
+			expectedStatus: "200",
+		},
+		{
+			name:           "Labels the status written by the handler",
+			request:        httptest.NewRequest(http.MethodPost, "/api/v1/tweet", nil),
+			expectedRoute:  "/api/v1/tweet",
+			expectedStatus: "400",
+		},
+		{
+			name:           "Groups unknown paths under a single route",
+			request:        httptest.NewRequest(http.MethodGet, "/not/a/route", nil),
+			expectedRoute:  "unmatched",
+			expectedStatus: "404",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			handler := middleware.Metrics(mux)
+			recorder := httptest.NewRecorder()
+			before := requestCount(t, tc.expectedRoute, tc.request.Method, tc.expectedStatus)
+
+			// Act
+			handler.ServeHTTP(recorder, tc.request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, strconv.Itoa(recorder.Code))
+			assert.Equal(t, before+1, requestCount(t, tc.expectedRoute, tc.request.Method, tc.expectedStatus))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"github.com/renzonaitor/tweet-api/internal/ratelimit"
+)
+
+// Scopes of a rate limit: the requests are counted per X-User-ID or per client IP.
+const (
+	rateLimitScopeUser = "user"
+	rateLimitScopeIP   = "ip"
+)
+
+// rateLimitLogSampling bounds the lines logged while the rate limit store is failing.
+var rateLimitLogSampling = logging.SamplerConfig{First: 10, Thereafter: 100, Tick: time.Second}
+
+// RouteRateLimit holds the limits of a route. A limit without Requests or Window isn't enforced.
+type RouteRateLimit struct {
+	PerUser domain.RateLimit
+	PerIP   domain.RateLimit
+}
+
+// RateLimiter builds the rate limiting middlewares of the routes.
+type RateLimiter struct {
+	limiter ratelimit.Limiter
+	// trustForwardedFor takes the client IP from X-Forwarded-For, which is only safe behind a
+	// load balancer that sets it.
+	trustForwardedFor bool
+	logger            *slog.Logger
+}
+
+func NewRateLimiter(limiter ratelimit.Limiter, trustForwardedFor bool, logger *slog.Logger) *RateLimiter {
+	return &RateLimiter{
+		limiter:           limiter,
+		trustForwardedFor: trustForwardedFor,
+		logger:            logging.Sampled(logger, rateLimitLogSampling),
+	}
+}
+
+// rateLimitCheck is a limit to count a request against.
+type rateLimitCheck struct {
+	scope string
+	key   string
+	limit domain.RateLimit
+}
+
+// Limit returns a middleware counting the requests of route against its per-IP limit and then
+// its per-user limit, when X-User-ID is set. Requests over a limit are answered with 429 and a
+// Retry-After header. Every answer carries the RateLimit-* headers of the limit closest to
+// being reached. If the limiter fails the request goes through: an outage of the store
+// doesn't take the API down.
+func (l *RateLimiter) Limit(route string, limits RouteRateLimit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enforced(limits.PerUser) && !enforced(limits.PerIP) {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The per-IP limit goes first: a request it rejects doesn't spend the user's quota,
+			// so requests from elsewhere sharing the IP can't use up a user's limit.
+			var checks []rateLimitCheck
+			if enforced(limits.PerIP) {
+				checks = append(checks, rateLimitCheck{rateLimitScopeIP, "ratelimit:" + route + ":ip:" + l.clientIP(r), limits.PerIP})
+			}
+			if userID := r.Header.Get("X-User-ID"); userID != "" && enforced(limits.PerUser) {
+				checks = append(checks, rateLimitCheck{rateLimitScopeUser, "ratelimit:" + route + ":user:" + userID, limits.PerUser})
+			}
+
+			var closest *rateLimitCheck
+			var closestDecision domain.RateLimitDecision
+			for _, check := range checks {
+				decision, err := l.limiter.AllowRate(r.Context(), check.key, check.limit)
+				if err != nil {
+					l.logger.WarnContext(r.Context(), "rate limit unavailable, letting the request through",
+						"route", route, "scope", check.scope, "error", err)
+					continue
+				}
+
+				if !decision.Allowed {
+					metrics.RateLimitRejections.WithLabelValues(route, check.scope).Inc()
+					setRateLimitHeaders(w, check.limit, decision)
+					writeTooManyRequests(w, decision.RetryAfter)
+					return
+				}
+				if closest == nil || decision.Remaining < closestDecision.Remaining {
+					closest, closestDecision = &check, decision
+				}
+			}
+
+			if closest != nil {
+				setRateLimitHeaders(w, closest.limit, closestDecision)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP of the client: the first address of X-Forwarded-For when trusted,
+// the address of the connection otherwise.
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.trustForwardedFor {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			first, _, _ := strings.Cut(forwardedFor, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func enforced(limit domain.RateLimit) bool {
+	return limit.Requests > 0 && limit.Window > 0
+}
+
+// setRateLimitHeaders sets the RateLimit-* headers of the IETF draft "RateLimit header fields
+// for HTTP": the quota and window of the limit, what's left of it and when it's fully available again.
+func setRateLimitHeaders(w http.ResponseWriter, limit domain.RateLimit, decision domain.RateLimitDecision) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+	w.Header().Set("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(ceilSeconds(limit.Window)))
+}
+
+// tooManyRequestsResponse is the body of a 429 answer.
+type tooManyRequestsResponse struct {
+	Error      string `json:"error"`
+	RetryAfter int    `json:"retry_after"`
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := max(ceilSeconds(retryAfter), 1)
+
+	body, err := json.Marshal(tooManyRequestsResponse{Error: "rate limit exceeded", RetryAfter: seconds})
+	if err != nil {
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write(body)
+}
+
+// ceilSeconds rounds d up to whole seconds, as the headers don't take fractions.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
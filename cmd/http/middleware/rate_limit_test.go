@@ -0,0 +1,189 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingLimiter is a rate limit store that can't be reached.
+type failingLimiter struct{}
+
+func (failingLimiter) AllowRate(context.Context, string, domain.RateLimit) (domain.RateLimitDecision, error) {
+	return domain.RateLimitDecision{}, errors.New("redis: connection pool timeout")
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	const route = "/api/v1/tweet"
+	perMinute := func(requests int) domain.RateLimit {
+		return domain.RateLimit{Requests: requests, Window: time.Minute}
+	}
+
+	testCases := []struct {
+		name              string
+		limiter           ratelimit.Limiter
+		trustForwardedFor bool
+		limits            middleware.RouteRateLimit
+		requests          []func(r *http.Request)
+		expectedStatus    int
+		expectedHeaders   map[string]string
+		expectedBody      string
+	}{
+		{
+			name:   "Allowed request gets the headers of its limit",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(10)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "6",
+				"RateLimit-Policy":    "10;w=60",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:   "Headers come from the limit closest to being reached",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(10), PerIP: perMinute(2)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": "1",
+			},
+		},
+		{
+			name:   "Requests over the per-user limit are rejected",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(2)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": "0",
+				"Retry-After":         "30",
+				"Content-Type":        "application/json",
+			},
+			expectedBody: `{"error": "rate limit exceeded", "retry_after": 30}`,
+		},
+		{
+			name:   "Users are limited separately",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-2") },
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Changing X-User-ID doesn't get around the per-IP limit",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(10), PerIP: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-2") },
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:   "Requests rejected per IP don't spend the per-user limit",
+			limits: middleware.RouteRateLimit{PerUser: perMinute(2), PerIP: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) { r.Header.Set("X-User-ID", "user-1") },
+				func(r *http.Request) {
+					r.Header.Set("X-User-ID", "user-1")
+					r.RemoteAddr = "203.0.113.2:1234"
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "1",
+				"RateLimit-Remaining": "0",
+			},
+		},
+		{
+			name:   "X-Forwarded-For is ignored unless trusted",
+			limits: middleware.RouteRateLimit{PerIP: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-Forwarded-For", "203.0.113.1") },
+				func(r *http.Request) { r.Header.Set("X-Forwarded-For", "203.0.113.2") },
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:              "Trusted X-Forwarded-For identifies the client",
+			trustForwardedFor: true,
+			limits:            middleware.RouteRateLimit{PerIP: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) { r.Header.Set("X-Forwarded-For", "203.0.113.1, 10.0.0.1") },
+				func(r *http.Request) { r.Header.Set("X-Forwarded-For", "203.0.113.2, 10.0.0.1") },
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Requests go through when the limiter fails",
+			limiter: failingLimiter{},
+			limits:  middleware.RouteRateLimit{PerIP: perMinute(1)},
+			requests: []func(r *http.Request){
+				func(r *http.Request) {},
+				func(r *http.Request) {},
+			},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+		{
+			name:            "Route without limits",
+			requests:        []func(r *http.Request){func(r *http.Request) {}, func(r *http.Request) {}},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			limiter := tc.limiter
+			if limiter == nil {
+				limiter = ratelimit.NewMemory()
+			}
+			rateLimiter := middleware.NewRateLimiter(limiter, tc.trustForwardedFor, logging.Discard())
+			handler := rateLimiter.Limit(route, tc.limits)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			// Act
+			var recorder *httptest.ResponseRecorder
+			for _, setupRequest := range tc.requests {
+				request := httptest.NewRequest(http.MethodPost, route, nil)
+				setupRequest(request)
+				recorder = httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+			}
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			for header, expected := range tc.expectedHeaders {
+				assert.Equal(t, expected, recorder.Header().Get(header), header)
+			}
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+)
+
+const (
+	// RequestIDHeader carries the request ID, both in the request and in the response.
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength caps the IDs accepted from callers, so they can't bloat the logs.
+	maxRequestIDLength = 128
+)
+
+// RequestID tags every request with an ID: the one sent by the caller in X-Request-ID when
+// it's valid, a new UUID otherwise. The ID is stored in the request context, where the
+// loggers pick it up, and echoed in the X-Request-ID response header, so an error response
+// can be matched with its log lines.
+//
+// Error responses also carry the ID in their body, as not every client keeps the headers:
+// they are sent as a JSON object with the error and its request_id.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		writer := &errorWriter{ResponseWriter: w, requestID: requestID}
+		next.ServeHTTP(writer, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+		writer.writeError()
+	})
+}
+
+// validRequestID accepts non-empty IDs of printable ASCII characters.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// errorResponse is the body of the error responses.
+type errorResponse struct {
+	Error     string `json:"error"`
+	RequestID string `json:"request_id"`
+}
+
+// errorWriter holds back the error responses of the wrapped handler, so writeError can send
+// them with the request ID. Other responses go through untouched.
+type errorWriter struct {
+	http.ResponseWriter
+	requestID   string
+	wroteHeader bool
+	// status is the status of the error response held back, 0 when there's none.
+	status int
+	body   bytes.Buffer
+}
+
+func (w *errorWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status >= http.StatusBadRequest {
+		w.status = status
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *errorWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.status != 0 {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController and the websocket upgrade reach the original writer.
+func (w *errorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeError sends the error response held back, if any, as JSON with the request ID. A JSON
+// object gets a request_id field, any other body becomes the error message.
+func (w *errorWriter) writeError() {
+	if w.status == 0 {
+		return
+	}
+
+	body, err := w.errorBody()
+	if err != nil {
+		// The original response is better than none.
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Type", "application/json")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+func (w *errorWriter) errorBody() ([]byte, error) {
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(w.body.Bytes(), &fields); err == nil && fields != nil {
+			requestID, err := json.Marshal(w.requestID)
+			if err != nil {
+				return nil, err
+			}
+			fields["request_id"] = requestID
+			return json.Marshal(fields)
+		}
+	}
+
+	message := strings.TrimSpace(w.body.String())
+	if message == "" {
+		message = http.StatusText(w.status)
+	}
+	return json.Marshal(errorResponse{Error: message, RequestID: w.requestID})
+}
@@ -0,0 +1,154 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	testCases := []struct {
+		name          string
+		header        string
+		expectedID    string
+		expectNewUUID bool
+	}{
+		{
+			name:       "Keeps the ID sent by the caller",
+			header:     "a0eebc99-req-42",
+			expectedID: "a0eebc99-req-42",
+		},
+		{
+			name:          "Generates an ID when missing",
+			expectNewUUID: true,
+		},
+		{
+			name:          "Replaces an ID with spaces",
+			header:        "not valid",
+			expectNewUUID: true,
+		},
+		{
+			name:          "Replaces an ID that is too long",
+			header:        strings.Repeat("a", 129),
+			expectNewUUID: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var contextID string
+			handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contextID = logging.RequestID(r.Context())
+				http.Error(w, "something failed", http.StatusInternalServerError)
+			}))
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/timeline", nil)
+			if tc.header != "" {
+				request.Header.Set(middleware.RequestIDHeader, tc.header)
+			}
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			responseID := recorder.Header().Get(middleware.RequestIDHeader)
+			assert.Equal(t, contextID, responseID)
+			if tc.expectNewUUID {
+				assert.NoError(t, uuid.Validate(responseID))
+			} else {
+				assert.Equal(t, tc.expectedID, responseID)
+			}
+		})
+	}
+}
+
+func TestRequestID_ErrorBody(t *testing.T) {
+	const requestID = "a0eebc99-req-42"
+
+	testCases := []struct {
+		name                string
+		handler             http.HandlerFunc
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "Client error with a plain text message",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("limit must be at most 100"))
+			},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"limit must be at most 100","request_id":"a0eebc99-req-42"}`,
+		},
+		{
+			name: "Server error written with http.Error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "error getting timeline: connection refused", http.StatusInternalServerError)
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"error getting timeline: connection refused","request_id":"a0eebc99-req-42"}`,
+		},
+		{
+			name: "JSON error body gets the request ID",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":"rate limit exceeded","retry_after":3}`))
+			},
+			expectedStatus:      http.StatusTooManyRequests,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"rate limit exceeded","retry_after":3,"request_id":"a0eebc99-req-42"}`,
+		},
+		{
+			name: "Error without a body gets the status text",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"Not Found","request_id":"a0eebc99-req-42"}`,
+		},
+		{
+			name: "Success response is untouched",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("pong"))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/plain",
+			expectedBody:        "pong",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			handler := middleware.RequestID(tc.handler)
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/timeline", nil)
+			request.Header.Set(middleware.RequestIDHeader, requestID)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Equal(t, tc.expectedContentType, recorder.Header().Get("Content-Type"))
+			if tc.expectedContentType == "application/json" {
+				assert.JSONEq(t, tc.expectedBody, recorder.Body.String())
+			} else {
+				assert.Equal(t, tc.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// untracedPaths are polled by the infrastructure, not called by users.
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// Tracing starts a server span for every request handled by next, continuing the trace of
+// the caller when the request carries a W3C traceparent header. Once routed, the span is
+// renamed after the ServeMux pattern, e.g. "GET /api/v1/timeline".
+func Tracing(next http.Handler) http.Handler {
+	routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = unmatchedRoute
+		}
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(attribute.String("http.route", route))
+	})
+
+	return otelhttp.NewHandler(routed, "http.request",
+		// Scrapes and probes would otherwise flood the traces.
+		otelhttp.WithFilter(func(r *http.Request) bool { return !untracedPaths[r.URL.Path] }),
+	)
+}
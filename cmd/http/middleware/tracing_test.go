@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
+	"github.com/renzonaitor/tweet-api/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter, tracing.Config{SampleRatio: 1})
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	var handlerSpan trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/timeline", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+	})
+
+	parentTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	testCases := []struct {
+		name          string
+		request       func() *http.Request
+		expectedName  string
+		expectedRoute string
+		expectedTrace string
+		expectedSpans int
+	}{
+		{
+			name: "Names the span after the matched route",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/api/v1/timeline?limit=10", nil)
+			},
+			expectedName:  "GET /api/v1/timeline",
+			expectedRoute: "/api/v1/timeline",
+			expectedSpans: 1,
+		},
+		{
+			name: "Continues the trace of the caller",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/api/v1/timeline", nil)
+				r.Header.Set("traceparent", "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+				return r
+			},
+			expectedName:  "GET /api/v1/timeline",
+			expectedRoute: "/api/v1/timeline",
+			expectedTrace: parentTraceID,
+			expectedSpans: 1,
+		},
+		{
+			name: "Groups unknown paths under a single name",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/not/a/route", nil)
+			},
+			expectedName:  "GET unmatched",
+			expectedRoute: "unmatched",
+			expectedSpans: 1,
+		},
+		{
+			name: "Metrics scrapes are not traced",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			},
+			expectedSpans: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			exporter.Reset()
+			handler := middleware.Tracing(mux)
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), tc.request())
+			require.NoError(t, provider.ForceFlush(context.Background()))
+
+			// Assert
+			spans := exporter.GetSpans()
+			require.Len(t, spans, tc.expectedSpans)
+			if tc.expectedSpans == 0 {
+				return
+			}
+
+			span := spans[0]
+			assert.Equal(t, tc.expectedName, span.Name)
+			assert.Contains(t, span.Attributes, attribute.String("http.route", tc.expectedRoute))
+			if tc.expectedTrace != "" {
+				assert.Equal(t, tc.expectedTrace, span.SpanContext.TraceID().String())
+			}
+			if tc.expectedRoute != "unmatched" {
+				assert.Equal(t, span.SpanContext.SpanID(), handlerSpan.SpanID())
+			}
+		})
+	}
+}
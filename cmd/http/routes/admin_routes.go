@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/dependencies"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/admin"
+)
+
+func SetupAdminRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
+	adminHandler := admin.NewHandler(dep.AdminHandler.Timeline, dep.AdminHandler.Moderation)
+	mux.Handle("/api/v1/admin/fan-out-jobs", dep.AdminOnly(http.HandlerFunc(adminHandler.HandleGetFanOutJob)))
+	mux.Handle("/api/v1/admin/moderation/reviews", dep.AdminOnly(http.HandlerFunc(adminHandler.HandleGetReviewQueue)))
+	mux.Handle("/api/v1/admin/moderation/reviews/{id}", dep.AdminOnly(http.HandlerFunc(adminHandler.HandleResolveReview)))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/dependencies"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/health"
+)
+
+func SetupHealthRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
+	healthHandler := health.NewHandler(dep.HealthHandler.Health)
+	mux.HandleFunc("/healthz", healthHandler.HandleLiveness)
+	mux.HandleFunc("/readyz", healthHandler.HandleReadiness)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/dependencies"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/media"
+)
+
+func SetupMediaRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
+	mediaHandler := media.NewHandler(dep.MediaHandler.MediaService)
+	mux.Handle("/api/v1/media", dep.RateLimit("/api/v1/media")(http.HandlerFunc(mediaHandler.HandleUploadMedia)))
+	mux.Handle("/api/v1/media/{id}", dep.RateLimit("/api/v1/media/{id}")(http.HandlerFunc(mediaHandler.HandleGetMedia)))
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+)
+
+func SetupMetricsRoutes(mux *http.ServeMux) {
+	mux.Handle("/metrics", metrics.Handler())
+}
@@ -10,5 +10,6 @@ import (
 func SetupReadRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
 	readHandler := reader.NewHandler(dep.ReaderHandler.Timeline)
 	mux.HandleFunc("/ping", readHandler.Ping)
-	mux.HandleFunc("/api/v1/timeline", readHandler.HandleGetTimeline)
+	mux.Handle("/api/v1/timeline", dep.RateLimit("/api/v1/timeline")(http.HandlerFunc(readHandler.HandleGetTimeline)))
+	mux.Handle("/api/v1/timeline/new-count", dep.RateLimit("/api/v1/timeline/new-count")(http.HandlerFunc(readHandler.HandleGetNewTweetsCount)))
 }
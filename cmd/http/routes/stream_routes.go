@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/dependencies"
+	"github.com/renzonaitor/tweet-api/cmd/http/handlers/stream"
+)
+
+func SetupStreamRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
+	streamHandler := stream.NewHandler(dep.StreamHandler.Realtime)
+	mux.Handle("/api/v1/ws", dep.RateLimit("/api/v1/ws")(http.HandlerFunc(streamHandler.HandleWebSocket)))
+}
@@ -9,6 +9,13 @@ import (
 
 func SetupWriteRoutes(mux *http.ServeMux, dep dependencies.Dependencies) {
 	writerHandler := writer.NewHandler(dep.WriterHandler.UserService)
-	mux.HandleFunc("/api/v1/tweet", writerHandler.HandlePublishTweet)
-	mux.HandleFunc("/api/v1/follow", writerHandler.HandleFollowUser)
+	mux.Handle("/api/v1/tweet", dep.RateLimit("/api/v1/tweet")(http.HandlerFunc(writerHandler.HandlePublishTweet)))
+	mux.Handle("/api/v1/follow", dep.RateLimit("/api/v1/follow")(http.HandlerFunc(writerHandler.HandleFollowUser)))
+	mux.Handle("/api/v1/blocks", dep.RateLimit("/api/v1/blocks")(http.HandlerFunc(writerHandler.HandleBlockUser)))
+	mux.Handle("/api/v1/blocks/{id}", dep.RateLimit("/api/v1/blocks/{id}")(http.HandlerFunc(writerHandler.HandleUnblockUser)))
+	mux.Handle("/api/v1/mutes", dep.RateLimit("/api/v1/mutes")(http.HandlerFunc(writerHandler.HandleMuteUser)))
+	mux.Handle("/api/v1/mutes/{id}", dep.RateLimit("/api/v1/mutes/{id}")(http.HandlerFunc(writerHandler.HandleUnmuteUser)))
+	mux.Handle("/api/v1/tweets/validate", dep.RateLimit("/api/v1/tweets/validate")(http.HandlerFunc(writerHandler.HandleValidateTweet)))
+	mux.Handle("/api/v1/tweets/{id}", dep.RateLimit("/api/v1/tweets/{id}")(http.HandlerFunc(writerHandler.HandleEditTweet)))
+	mux.Handle("/api/v1/tweets/{id}/history", dep.RateLimit("/api/v1/tweets/{id}/history")(http.HandlerFunc(writerHandler.HandleGetTweetHistory)))
 }
@@ -38,6 +38,14 @@ CREATE TABLE IF NOT EXISTS tweets
 ),
     user_id UUID NOT NULL,
     content TEXT NOT NULL,
+    -- SHA-256 of the publish request, so a reused idempotency key with a different request is rejected
+    request_fingerprint CHAR(64),
+    -- Last edit of the content, NULL if it was never edited
+    edited_at TIMESTAMPTZ,
+    -- URLs found in the content, as [{"url", "start", "end"}] with offsets in code points; NULL if none
+    url_entities JSONB,
+    -- visible, limited (not delivered to timelines), held (hidden until reviewed) or removed
+    moderation_status VARCHAR(16) NOT NULL DEFAULT 'visible',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW
 (
 ),
@@ -107,10 +115,107 @@ CREATE TABLE IF NOT EXISTS follows
 )
     );
 
+-- Tracks the fan-out of each tweet so an interrupted delivery can resume from its checkpoint
+CREATE TABLE IF NOT EXISTS fan_out_jobs
+(
+    tweet_id         UUID PRIMARY KEY REFERENCES tweets (id) ON DELETE CASCADE,
+    author_id        UUID        NOT NULL,
+    status           VARCHAR(16) NOT NULL,
+    last_follower_id UUID,
+    processed_count  INTEGER     NOT NULL DEFAULT 0,
+    attempts         INTEGER     NOT NULL DEFAULT 1,
+    last_error       TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Keeps every previous content of an edited tweet
+CREATE TABLE IF NOT EXISTS tweet_revisions
+(
+    id          BIGSERIAL PRIMARY KEY,
+    tweet_id    UUID        NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    content     TEXT        NOT NULL,
+    -- When the content was published: the tweet creation or the previous edit
+    created_at  TIMESTAMPTZ NOT NULL,
+    replaced_at TIMESTAMPTZ NOT NULL
+);
+
+-- Uploaded media. The bytes live in the blob store under the media id
+CREATE TABLE IF NOT EXISTS media
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    content_type VARCHAR(64) NOT NULL,
+    size_bytes   BIGINT      NOT NULL,
+    -- The tweet the media is attached to, NULL until it is
+    tweet_id     UUID REFERENCES tweets (id) ON DELETE CASCADE,
+    -- Order of the media within the tweet
+    position     SMALLINT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Previews of the links found in tweets, shared by every tweet linking the same URL
+CREATE TABLE IF NOT EXISTS link_previews
+(
+    url         TEXT PRIMARY KEY,
+    title       TEXT        NOT NULL DEFAULT '',
+    description TEXT        NOT NULL DEFAULT '',
+    image_url   TEXT        NOT NULL DEFAULT '',
+    -- Last fetch of the URL, also stored when it had nothing to preview so it isn't refetched
+    fetched_at  TIMESTAMPTZ NOT NULL
+);
+
+-- Why a tweet broke a moderation rule, and the review of the held ones
+CREATE TABLE IF NOT EXISTS moderation_flags
+(
+    tweet_id    UUID PRIMARY KEY REFERENCES tweets (id) ON DELETE CASCADE,
+    -- shadow_limit or hold
+    action      VARCHAR(16) NOT NULL,
+    rule        VARCHAR(64) NOT NULL,
+    reason      TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    -- approve or remove, NULL until reviewed
+    decision    VARCHAR(16),
+    reviewed_by UUID,
+    reviewed_at TIMESTAMPTZ
+);
+
+-- Users blocked by another user. A block removes the follows between both users and stops
+-- new ones, in either direction
+CREATE TABLE IF NOT EXISTS blocks
+(
+    blocker_id UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    blocked_id UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+-- Users muted by another user, whose tweets are hidden from the muter's timeline
+CREATE TABLE IF NOT EXISTS mutes
+(
+    muter_id   UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    muted_id   UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (muter_id, muted_id)
+);
+
 -- Create indexes for faster lookups on foreign keys
 CREATE INDEX IF NOT EXISTS idx_tweets_user_id ON tweets(user_id);
+-- Serves the newest-first per-user scans used to build timelines from PostgreSQL
+CREATE INDEX IF NOT EXISTS idx_tweets_user_id_created_at ON tweets(user_id, created_at DESC, id DESC);
 CREATE INDEX IF NOT EXISTS idx_follows_follower_id ON follows(follower_id);
-CREATE INDEX IF NOT EXISTS idx_follows_following_id ON follows(following_id);
+-- Serves the keyset pagination over the followers of a user used by the fan-out
+CREATE INDEX IF NOT EXISTS idx_follows_following_id_follower_id ON follows(following_id, follower_id);
+-- Serves the lookups of the users who blocked a user, e.g. by the fan-out
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked_id ON blocks(blocked_id, blocker_id);
+-- Serves the history of a tweet, newest revision first
+CREATE INDEX IF NOT EXISTS idx_tweet_revisions_tweet_id ON tweet_revisions(tweet_id, id DESC);
+-- Serves the hydration of the media of timeline tweets
+CREATE INDEX IF NOT EXISTS idx_media_tweet_id ON media(tweet_id, position) WHERE tweet_id IS NOT NULL;
+-- Serves the review queue, oldest held tweet first
+CREATE INDEX IF NOT EXISTS idx_moderation_flags_pending ON moderation_flags(created_at, tweet_id) WHERE action = 'hold' AND decision IS NULL;
+-- Serves the recovery scan over unfinished fan-out jobs
+CREATE INDEX IF NOT EXISTS idx_fan_out_jobs_pending ON fan_out_jobs(updated_at) WHERE status <> 'completed';
 
 -- =================================================================
 -- Seed Data for Testing
@@ -0,0 +1,138 @@
+// Package circuitbreaker stops calling a failing dependency for a while, so requests fail
+// fast (or take a fallback) instead of piling up on timeouts.
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a breaker.
+type State int
+
+const (
+	// Closed lets every call through.
+	Closed State = iota
+	// Open rejects every call until the cooldown is over.
+	Open
+	// HalfOpen lets a single probe call through; its outcome closes or reopens the breaker.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 10 * time.Second
+)
+
+// Config holds the thresholds of a breaker.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before letting a probe through.
+	Cooldown time.Duration
+}
+
+// Breaker is a consecutive-failures circuit breaker, safe for concurrent use.
+type Breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func New(cfg Config) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+// Allow reports whether a call may go through. Every allowed call must be followed by
+// Record with its outcome, or by Release when the outcome says nothing about the dependency.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if b.now().Sub(b.openedAt) < b.cfg.Cooldown {
+			return ErrOpen
+		}
+		b.state = HalfOpen
+		b.probing = true
+		return nil
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record reports the outcome of an allowed call: nil closes the breaker and resets the
+// failures, an error counts towards opening it (or reopens it right away after a probe).
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = Closed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = Open
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// Release gives back an allowed call that ended without a verdict, e.g. because the caller
+// cancelled it, so a half-open breaker lets the next probe through.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns the current state. An open breaker whose cooldown is over reports HalfOpen,
+// as the next call is let through.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cfg.Cooldown {
+		return HalfOpen
+	}
+	return b.state
+}
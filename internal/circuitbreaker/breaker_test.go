@@ -0,0 +1,140 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	callError := errors.New("connection refused")
+
+	testCases := []struct {
+		name          string
+		run           func(b *Breaker, advance func(time.Duration))
+		expectedState State
+		expectedAllow error
+	}{
+		{
+			name: "Stays closed below the failure threshold",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				b.Record(callError)
+				b.Record(callError)
+			},
+			expectedState: Closed,
+			expectedAllow: nil,
+		},
+		{
+			name: "A success resets the consecutive failures",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				b.Record(callError)
+				b.Record(callError)
+				b.Record(nil)
+				b.Record(callError)
+				b.Record(callError)
+			},
+			expectedState: Closed,
+			expectedAllow: nil,
+		},
+		{
+			name: "Opens at the failure threshold",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+			},
+			expectedState: Open,
+			expectedAllow: ErrOpen,
+		},
+		{
+			name: "Lets a single probe through after the cooldown",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+				advance(time.Second)
+				assert.NoError(t, b.Allow())
+			},
+			expectedState: HalfOpen,
+			expectedAllow: ErrOpen,
+		},
+		{
+			name: "Reports half-open once the cooldown is over",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+				advance(time.Second)
+			},
+			expectedState: HalfOpen,
+			expectedAllow: nil,
+		},
+		{
+			name: "A released probe lets the next one through",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+				advance(time.Second)
+				assert.NoError(t, b.Allow())
+				b.Release()
+			},
+			expectedState: HalfOpen,
+			expectedAllow: nil,
+		},
+		{
+			name: "A successful probe closes the breaker",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+				advance(time.Second)
+				assert.NoError(t, b.Allow())
+				b.Record(nil)
+			},
+			expectedState: Closed,
+			expectedAllow: nil,
+		},
+		{
+			name: "A failed probe reopens the breaker",
+			run: func(b *Breaker, advance func(time.Duration)) {
+				for range 3 {
+					b.Record(callError)
+				}
+				advance(time.Second)
+				assert.NoError(t, b.Allow())
+				b.Record(callError)
+			},
+			expectedState: Open,
+			expectedAllow: ErrOpen,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			now := time.Now()
+			breaker := New(Config{FailureThreshold: 3, Cooldown: time.Second})
+			breaker.now = func() time.Time { return now }
+
+			// Act
+			tc.run(breaker, func(d time.Duration) { now = now.Add(d) })
+
+			// Assert
+			assert.Equal(t, tc.expectedState, breaker.State())
+			assert.Equal(t, tc.expectedAllow, breaker.Allow())
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	// Act
+	breaker := New(Config{})
+
+	// Assert
+	assert.Equal(t, Config{FailureThreshold: 5, Cooldown: 10 * time.Second}, breaker.cfg)
+	assert.Equal(t, Closed, breaker.State())
+	assert.Equal(t, "closed", breaker.State().String())
+}
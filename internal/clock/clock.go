@@ -0,0 +1,25 @@
+// Package clock abstracts the current time, so code stamping records can be tested with a
+// fixed time.
+package clock
+
+import "time"
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Func adapts a function to a Clock.
+type Func func() time.Time
+
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is the Clock backed by time.Now.
+var System Clock = Func(time.Now)
+
+// Fixed returns a Clock that always tells t.
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
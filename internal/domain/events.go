@@ -0,0 +1,57 @@
+package domain
+
+import "fmt"
+
+// Event types pushed to real-time subscribers.
+const (
+	// EventTweetCreated is published on the timelines of the author's followers.
+	EventTweetCreated = "tweet_created"
+	// EventNewFollower is published on the notifications of the followed user.
+	EventNewFollower = "new_follower"
+	// EventTweetEdited is published on the thread of the edited tweet.
+	EventTweetEdited = "tweet_edited"
+)
+
+// Topics a client can subscribe to. Thread topics are built with ThreadTopic.
+const (
+	TopicTimeline      = "timeline"
+	TopicNotifications = "notifications"
+	topicThreadPrefix  = "tweet:"
+)
+
+// Redis Pub/Sub channel layout: events:<topic>:<id>
+const (
+	EventsChannelPattern      = "events:*"
+	timelineEventsChannel     = "events:timeline:%s"
+	notificationEventsChannel = "events:notifications:%s"
+	threadEventsChannel       = "events:tweet:%s"
+)
+
+// Event is the message published on Redis Pub/Sub and delivered to subscribers.
+type Event struct {
+	Topic      string `json:"topic"`
+	Type       string `json:"type"`
+	TweetID    string `json:"tweet_id,omitempty"`
+	AuthorID   string `json:"author_id,omitempty"`
+	FollowerID string `json:"follower_id,omitempty"`
+}
+
+// ThreadTopic returns the topic name used to follow a single tweet thread.
+func ThreadTopic(tweetID string) string {
+	return topicThreadPrefix + tweetID
+}
+
+// TimelineEventsChannel returns the Pub/Sub channel for a user's home timeline.
+func TimelineEventsChannel(userID string) string {
+	return fmt.Sprintf(timelineEventsChannel, userID)
+}
+
+// NotificationEventsChannel returns the Pub/Sub channel for a user's notifications.
+func NotificationEventsChannel(userID string) string {
+	return fmt.Sprintf(notificationEventsChannel, userID)
+}
+
+// ThreadEventsChannel returns the Pub/Sub channel for a tweet thread.
+func ThreadEventsChannel(tweetID string) string {
+	return fmt.Sprintf(threadEventsChannel, tweetID)
+}
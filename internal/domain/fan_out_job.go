@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Fan-out job statuses.
+const (
+	FanOutJobRunning   = "running"
+	FanOutJobCompleted = "completed"
+	FanOutJobFailed    = "failed"
+)
+
+// FanOutJob tracks the delivery of a tweet to the cached timelines of its author's followers.
+// Followers are processed in follower ID order and LastFollowerID is the checkpoint: every
+// follower up to it (inclusive) already got the tweet, so an interrupted job resumes after it.
+type FanOutJob struct {
+	TweetID        string    `json:"tweet_id"`
+	AuthorID       string    `json:"author_id"`
+	Status         string    `json:"status"`
+	LastFollowerID string    `json:"last_follower_id,omitempty"`
+	ProcessedCount int       `json:"processed_count"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// MarshalJSON encodes CreatedAt and UpdatedAt with TimestampFormat.
+func (j FanOutJob) MarshalJSON() ([]byte, error) {
+	type fanOutJob FanOutJob
+	return json.Marshal(struct {
+		fanOutJob
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+	}{fanOutJob(j), FormatTimestamp(j.CreatedAt), FormatTimestamp(j.UpdatedAt)})
+}
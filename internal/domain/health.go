@@ -0,0 +1,22 @@
+package domain
+
+// Health statuses of the service and of each of its dependencies.
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+	// HealthDraining means the instance is shutting down and must not get new traffic.
+	HealthDraining = "draining"
+)
+
+// HealthReport is the outcome of checking every dependency of the service.
+type HealthReport struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyHealth `json:"checks"`
+}
+
+// DependencyHealth is the outcome of pinging a single dependency.
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// URLEntity is a URL found in the text of a tweet. Start and End are its offsets in the text,
+// in Unicode code points, End excluded.
+type URLEntity struct {
+	URL   string `json:"url"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	// Preview is the metadata of the page behind URL, nil until it has been fetched.
+	Preview *LinkPreview `json:"preview,omitempty"`
+}
+
+// LinkPreview is the metadata of a web page, shown as a card under the tweets linking to it.
+type LinkPreview struct {
+	URL         string    `json:"-"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	FetchedAt   time.Time `json:"-"`
+}
+
+// Empty reports whether the page had none of the metadata of a preview.
+func (p LinkPreview) Empty() bool {
+	return p.Title == "" && p.Description == "" && p.ImageURL == ""
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrBlobNotFound is returned by the blob stores when no blob is stored under a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// Media is an uploaded file. Its bytes live in a blob store under its ID, and its metadata
+// in PostgreSQL. It can be attached to a single tweet of its uploader.
+type Media struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"-"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// TweetID is the tweet the media is attached to, empty until it is.
+	TweetID string `json:"-"`
+}
+
+// MarshalJSON encodes CreatedAt with TimestampFormat.
+func (m Media) MarshalJSON() ([]byte, error) {
+	type media Media
+	return json.Marshal(struct {
+		media
+		CreatedAt string `json:"created_at"`
+	}{media(m), FormatTimestamp(m.CreatedAt)})
+}
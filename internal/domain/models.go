@@ -1,10 +1,15 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type User struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Created  string `json:"created_at"`
+	ID       string    `json:"id"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+	Created  time.Time `json:"created_at"`
 }
 
 type FollowUser struct {
@@ -12,9 +17,70 @@ type FollowUser struct {
 	FollowedID string `json:"followed_id"`
 }
 
+// BlockUser is a user (BlockerID) blocking another one (BlockedID).
+type BlockUser struct {
+	BlockerID string `json:"blocker_id"`
+	BlockedID string `json:"blocked_id"`
+}
+
+// MuteUser is a user (MuterID) muting another one (MutedID).
+type MuteUser struct {
+	MuterID string `json:"muter_id"`
+	MutedID string `json:"muted_id"`
+}
+
 type Tweet struct {
-	ID        string `json:"id"`
-	Text      string `json:"text"`
-	UserID    string `json:"user_id"`
-	CreatedAt string `json:"created_at"`
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// EditedAt is when the text was last edited, nil if it never was.
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// Media are the attachments of the tweet, in the order they were attached.
+	Media []Media `json:"media,omitempty"`
+	// URLs are the links in Text, in order.
+	URLs []URLEntity `json:"urls,omitempty"`
+	// Moderation is the visibility the moderation gave the tweet. Only a held tweet says so
+	// in its JSON: a limited one must look like any other.
+	Moderation ModerationStatus `json:"-"`
+	// Flag is the verdict to store with a tweet published breaking a moderation rule.
+	Flag *ModerationVerdict `json:"-"`
+
+	// Fingerprint identifies the request that created the tweet, so a replay of its
+	// idempotency key with a different request can be told apart. Empty on tweets created
+	// before fingerprints were stored.
+	Fingerprint string `json:"-"`
+}
+
+// MarshalJSON encodes CreatedAt and EditedAt with TimestampFormat, and held tweets with
+// "held": true.
+func (t Tweet) MarshalJSON() ([]byte, error) {
+	type tweet Tweet
+	var editedAt *string
+	if t.EditedAt != nil {
+		formatted := FormatTimestamp(*t.EditedAt)
+		editedAt = &formatted
+	}
+	return json.Marshal(struct {
+		tweet
+		CreatedAt string  `json:"created_at"`
+		EditedAt  *string `json:"edited_at,omitempty"`
+		Held      bool    `json:"held,omitempty"`
+	}{tweet(t), FormatTimestamp(t.CreatedAt), editedAt, t.Moderation == TweetHeld})
+}
+
+// NewTweetsCount is the number of timeline tweets newer than a cursor.
+// Capped is true when the real number is above the configured maximum, Degraded when the
+// count was computed over PostgreSQL because the timeline cache was unavailable.
+type NewTweetsCount struct {
+	Count    int  `json:"count"`
+	Capped   bool `json:"capped"`
+	Degraded bool `json:"degraded,omitempty"`
+}
+
+// TimelinePage is a page of a home timeline. Degraded is true when the page was read from
+// PostgreSQL because the timeline cache (Redis) was unavailable.
+type TimelinePage struct {
+	Tweets   []Tweet
+	Degraded bool
 }
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ModerationAction is what the moderation does with a tweet breaking one of its rules.
+type ModerationAction string
+
+const (
+	// ModerationAllow publishes the tweet.
+	ModerationAllow ModerationAction = "allow"
+	// ModerationShadowLimit publishes the tweet without delivering it to the timelines of the
+	// followers. The author isn't told.
+	ModerationShadowLimit ModerationAction = "shadow_limit"
+	// ModerationHold stores the tweet hidden until a moderator reviews it.
+	ModerationHold ModerationAction = "hold"
+	// ModerationReject refuses the tweet.
+	ModerationReject ModerationAction = "reject"
+)
+
+// Severity orders the actions from the most lenient, zero for ModerationAllow, to the most
+// severe. Unknown actions are as lenient as ModerationAllow.
+func (a ModerationAction) Severity() int {
+	switch a {
+	case ModerationShadowLimit:
+		return 1
+	case ModerationHold:
+		return 2
+	case ModerationReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Status returns the status of a tweet published with the action.
+func (a ModerationAction) Status() ModerationStatus {
+	switch a {
+	case ModerationShadowLimit:
+		return TweetLimited
+	case ModerationHold:
+		return TweetHeld
+	default:
+		return TweetVisible
+	}
+}
+
+// ModerationStatus is the visibility of a stored tweet.
+type ModerationStatus string
+
+const (
+	// TweetVisible tweets are delivered to timelines.
+	TweetVisible ModerationStatus = "visible"
+	// TweetLimited tweets can be read but aren't delivered to timelines.
+	TweetLimited ModerationStatus = "limited"
+	// TweetHeld tweets are hidden until a moderator approves them.
+	TweetHeld ModerationStatus = "held"
+	// TweetRemoved tweets were held and then removed by a moderator.
+	TweetRemoved ModerationStatus = "removed"
+)
+
+// ModerationVerdict is the outcome of the moderation of a tweet: the action of the most
+// severe rule it broke, or ModerationAllow with no Rule.
+type ModerationVerdict struct {
+	Action ModerationAction
+	Rule   string
+	Reason string
+}
+
+// ReviewDecision is a moderator's decision on a held tweet.
+type ReviewDecision string
+
+const (
+	ReviewApprove ReviewDecision = "approve"
+	ReviewRemove  ReviewDecision = "remove"
+)
+
+// Status returns the status of a held tweet after the decision.
+func (d ReviewDecision) Status() ModerationStatus {
+	if d == ReviewApprove {
+		return TweetVisible
+	}
+	return TweetRemoved
+}
+
+// ModerationReview is a held tweet waiting in the review queue, with why it was held.
+type ModerationReview struct {
+	Tweet     Tweet     `json:"tweet"`
+	Rule      string    `json:"rule"`
+	Reason    string    `json:"reason"`
+	FlaggedAt time.Time `json:"flagged_at"`
+}
+
+// MarshalJSON encodes FlaggedAt with TimestampFormat.
+func (r ModerationReview) MarshalJSON() ([]byte, error) {
+	type review ModerationReview
+	return json.Marshal(struct {
+		review
+		FlaggedAt string `json:"flagged_at"`
+	}{review(r), FormatTimestamp(r.FlaggedAt)})
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// RateLimit allows Requests per Window. Unused requests don't pile up past Requests, so a
+// client can burst up to Requests at once, then one request every Window/Requests.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimitDecision is the outcome of counting a request against a RateLimit.
+type RateLimitDecision struct {
+	Allowed bool
+	// Remaining is how many more requests are allowed right away.
+	Remaining int
+	// Reset is how long until the limit is fully available again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, when this one wasn't.
+	RetryAfter time.Duration
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// TimestampFormat is the layout of the timestamps in API responses: RFC 3339 in UTC with
+// millisecond precision, e.g. 2025-01-02T15:04:05.000Z.
+const TimestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// FormatTimestamp formats t with TimestampFormat.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(TimestampFormat)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TweetRevision is a previous text of an edited tweet. CreatedAt is when the text was
+// published (the tweet creation or an earlier edit) and ReplacedAt when an edit replaced it.
+type TweetRevision struct {
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+// MarshalJSON encodes CreatedAt and ReplacedAt with TimestampFormat.
+func (r TweetRevision) MarshalJSON() ([]byte, error) {
+	type tweetRevision TweetRevision
+	return json.Marshal(struct {
+		tweetRevision
+		CreatedAt  string `json:"created_at"`
+		ReplacedAt string `json:"replaced_at"`
+	}{tweetRevision(r), FormatTimestamp(r.CreatedAt), FormatTimestamp(r.ReplacedAt)})
+}
+
+// TweetHistory is the current version of a tweet and its previous texts, newest first.
+type TweetHistory struct {
+	Tweet     Tweet           `json:"tweet"`
+	Revisions []TweetRevision `json:"revisions"`
+}
@@ -0,0 +1,89 @@
+package httpfetch
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+)
+
+// ErrForbiddenAddress is returned when a request would connect to an address that isn't
+// public, e.g. loopback, the private networks or the cloud metadata endpoint.
+var ErrForbiddenAddress = errors.New("address is not publicly routable")
+
+// maxRedirects bounds the redirects followed by a single fetch.
+const maxRedirects = 5
+
+// nonPublicPrefixes are the ranges not covered by the netip.Addr checks that aren't
+// reachable on the internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved
+}
+
+// NewClient returns the client fetching the pages linked by tweets. The URLs come from the
+// users, so unless Unfurl.AllowPrivateNetworks is set the client refuses to connect to any
+// address that isn't public, checked on every connection, after DNS resolution and across
+// redirects. Proxies from the environment are ignored, as they would bypass the check.
+func NewClient(cfg config.Config, logger *slog.Logger) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if cfg.Unfurl.AllowPrivateNetworks {
+		logger.Warn("link unfurling can reach private networks")
+	} else {
+		dialer.Control = denyNonPublic
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// denyNonPublic is a net.Dialer Control refusing the connections to non public addresses.
+// It runs with the resolved address, so a public host name resolving to a private address
+// is refused too.
+func denyNonPublic(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package httpfetch_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/httpfetch"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_NonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		expectedErr  error
+	}{
+		{
+			name:        "Failure - loopback is refused",
+			url:         server.URL,
+			expectedErr: httpfetch.ErrForbiddenAddress,
+		},
+		{
+			name:        "Failure - the cloud metadata endpoint is refused",
+			url:         "http://169.254.169.254/latest/meta-data/",
+			expectedErr: httpfetch.ErrForbiddenAddress,
+		},
+		{
+			name:        "Failure - private networks are refused",
+			url:         "http://10.0.0.1/",
+			expectedErr: httpfetch.ErrForbiddenAddress,
+		},
+		{
+			name:        "Failure - IPv4-mapped loopback is refused",
+			url:         "http://[::ffff:127.0.0.1]/",
+			expectedErr: httpfetch.ErrForbiddenAddress,
+		},
+		{
+			name:         "Success - loopback is reached when private networks are allowed",
+			url:          server.URL,
+			allowPrivate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			client := httpfetch.NewClient(config.Config{Unfurl: config.Unfurl{AllowPrivateNetworks: tc.allowPrivate}}, logging.Discard())
+
+			// Act
+			resp, err := client.Get(tc.url)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
+
+func TestClient_Redirects(t *testing.T) {
+	// Arrange
+	hops := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops++
+		http.Redirect(w, r, "/again", http.StatusFound)
+	}))
+	defer server.Close()
+	client := httpfetch.NewClient(config.Config{Unfurl: config.Unfurl{AllowPrivateNetworks: true}}, logging.Discard())
+
+	// Act
+	_, err := client.Get(server.URL)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stopped after 5 redirects")
+	assert.Equal(t, 5, hops)
+}
@@ -0,0 +1,102 @@
+package localfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// BlobStore keeps each blob in a file named after its key under a directory. It suits a
+// single instance or a directory shared by every instance, e.g. a network volume.
+type BlobStore struct {
+	dir string
+}
+
+// NewBlobStore creates the directory of the blobs if it doesn't exist yet.
+func NewBlobStore(cfg config.Config, logger *slog.Logger) (*BlobStore, error) {
+	dir := cfg.Media.Local.Dir
+	if dir == "" {
+		return nil, errors.New("media directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the media directory: %w", err)
+	}
+
+	logger.Info("storing media in the local filesystem", "dir", dir)
+
+	return &BlobStore{dir: dir}, nil
+}
+
+// Put writes data to a temporary file renamed to key once complete, so readers never see a
+// partial blob. The content type isn't stored: the media metadata keeps it.
+func (s *BlobStore) Put(ctx context.Context, key, contentType string, data io.Reader, size int64) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	// A no-op once renamed.
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err = io.Copy(file, data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Get opens the blob stored under key.
+func (s *BlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+func (s *BlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps key to its file, refusing keys that would escape the directory.
+func (s *BlobStore) path(key string) (string, error) {
+	if !filepath.IsLocal(key) || filepath.Base(key) != key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
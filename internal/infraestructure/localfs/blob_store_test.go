@@ -0,0 +1,123 @@
+package localfs_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/localfs"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlobStore(t *testing.T) (*localfs.BlobStore, string) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "media")
+	store, err := localfs.NewBlobStore(config.Config{Media: config.Media{Local: config.LocalStore{Dir: dir}}}, logging.Discard())
+	require.NoError(t, err)
+	return store, dir
+}
+
+func TestBlobStore_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store, dir := newBlobStore(t)
+	content := "\x89PNG\r\n\x1a\n-image"
+
+	// Act
+	err := store.Put(ctx, "blob", "image/png", strings.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	reader, err := store.Get(ctx, "blob")
+	require.NoError(t, err)
+	stored, err := io.ReadAll(reader)
+	require.NoError(t, reader.Close())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, content, string(stored))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file should have been renamed")
+
+	require.NoError(t, store.Delete(ctx, "blob"))
+	_, err = store.Get(ctx, "blob")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+func TestBlobStore_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name          string
+		act           func(store *localfs.BlobStore) error
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "Get - missing blob",
+			act: func(store *localfs.BlobStore) error {
+				_, err := store.Get(ctx, "missing")
+				return err
+			},
+			expectedErr: domain.ErrBlobNotFound,
+		},
+		{
+			name: "Delete - missing blob is not an error",
+			act: func(store *localfs.BlobStore) error {
+				return store.Delete(ctx, "missing")
+			},
+		},
+		{
+			name: "Put - key escaping the directory",
+			act: func(store *localfs.BlobStore) error {
+				return store.Put(ctx, "../escape", "image/png", strings.NewReader("x"), 1)
+			},
+			errorContains: "invalid blob key",
+		},
+		{
+			name: "Get - key with a subdirectory",
+			act: func(store *localfs.BlobStore) error {
+				_, err := store.Get(ctx, "nested/blob")
+				return err
+			},
+			errorContains: "invalid blob key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			store, _ := newBlobStore(t)
+
+			// Act
+			err := tc.act(store)
+
+			// Assert
+			switch {
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+			case tc.errorContains != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewBlobStore_RequiresDir(t *testing.T) {
+	// Act
+	_, err := localfs.NewBlobStore(config.Config{}, logging.Discard())
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "media directory is not set")
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// ClaimStaleFanOutJobs marks as running, and returns, up to limit unfinished jobs that weren't
+// updated for staleAfter and were attempted fewer than maxAttempts times. Claimed jobs get a
+// fresh updated_at, so concurrent callers (e.g. several API instances) never claim the same job.
+func (r Repository) ClaimStaleFanOutJobs(ctx context.Context, staleAfter time.Duration, maxAttempts, limit int) ([]domain.FanOutJob, error) {
+	query := `
+		UPDATE fan_out_jobs
+		SET status = $1, attempts = attempts + 1, updated_at = NOW()
+		WHERE tweet_id IN (
+			SELECT tweet_id
+			FROM fan_out_jobs
+			WHERE status <> $2
+			  AND updated_at < NOW() - make_interval(secs => $3)
+			  AND attempts < $4
+			ORDER BY updated_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + fanOutJobColumns
+
+	rows, err := r.db.QueryContext(ctx, query, domain.FanOutJobRunning, domain.FanOutJobCompleted,
+		staleAfter.Seconds(), maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.FanOutJob
+	for rows.Next() {
+		job, err := scanFanOutJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimStaleFanOutJobs(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	followerID := uuid.NewString()
+	timestamp := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	expectedQuery := regexp.QuoteMeta(`
+		UPDATE fan_out_jobs
+		SET status = $1, attempts = attempts + 1, updated_at = NOW()
+		WHERE tweet_id IN (
+			SELECT tweet_id
+			FROM fan_out_jobs
+			WHERE status <> $2
+			  AND updated_at < NOW() - make_interval(secs => $3)
+			  AND attempts < $4
+			ORDER BY updated_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING tweet_id, author_id, status, last_follower_id, processed_count, attempts, last_error, created_at, updated_at`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedJobs  []domain.FanOutJob
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "Success - returns the claimed jobs",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs("running", "completed", float64(60), 5, 10).
+					WillReturnRows(sqlmock.NewRows(fanOutJobRowColumns).
+						AddRow(tweetID, authorID, "running", followerID, 500, 2, "redis is down", timestamp, timestamp))
+			},
+			expectedJobs: []domain.FanOutJob{{
+				TweetID:        tweetID,
+				AuthorID:       authorID,
+				Status:         "running",
+				LastFollowerID: followerID,
+				ProcessedCount: 500,
+				Attempts:       2,
+				LastError:      "redis is down",
+				CreatedAt:      timestamp,
+				UpdatedAt:      timestamp,
+			}},
+		},
+		{
+			name: "Success - nothing to claim",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs("running", "completed", float64(60), 5, 10).
+					WillReturnRows(sqlmock.NewRows(fanOutJobRowColumns))
+			},
+			expectedJobs: nil,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs("running", "completed", float64(60), 5, 10).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "database connection lost",
+		},
+		{
+			name: "Failure - row iteration error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs("running", "completed", float64(60), 5, 10).
+					WillReturnRows(sqlmock.NewRows(fanOutJobRowColumns).
+						AddRow(tweetID, authorID, "running", nil, 0, 1, nil, timestamp, timestamp).
+						RowError(0, errors.New("broken row")))
+			},
+			expectError:   true,
+			errorContains: "broken row",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			jobs, err := repo.ClaimStaleFanOutJobs(ctx, time.Minute, 5, 10)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedJobs, jobs)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CountRecentDuplicates counts the tweets of authorID created at or after since whose text
+// equals text, ignoring case, leaving out excludeTweetID. The author's index narrows the scan
+// to their recent tweets before the texts are compared.
+func (r Repository) CountRecentDuplicates(ctx context.Context, authorID, text, excludeTweetID string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM tweets
+		WHERE user_id = $1
+		  AND created_at >= $4
+		  AND id <> $3
+		  AND lower(content) = lower($2)
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, authorID, text, excludeTweetID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting duplicates of tweet %s: %w", excludeTweetID, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,77 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountRecentDuplicates(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.NewString()
+	tweetID := uuid.NewString()
+	since := time.Date(2025, 1, 1, 9, 50, 0, 0, time.UTC)
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM tweets
+		WHERE user_id = $1
+		  AND created_at >= $4
+		  AND id <> $3
+		  AND lower(content) = lower($2)
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedCount int
+		errorContains string
+	}{
+		{
+			name: "Success - counts the duplicates",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(authorID, "Follow me!", tweetID, since).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+			},
+			expectedCount: 4,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(authorID, "Follow me!", tweetID, since).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "error counting duplicates of tweet",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			count, err := repo.CountRecentDuplicates(ctx, authorID, "Follow me!", tweetID, since)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// CountTweetsSince counts the visible tweets of userIDs published after sinceTweetID, up to limit.
+// Tweets are compared on (created_at, id), the timeline order, so tweets published in the same
+// instant as sinceTweetID are counted when they come before it in the timeline.
+// An unknown sinceTweetID yields zero, since there is no reference point to compare with.
+func (r Repository) CountTweetsSince(ctx context.Context, userIDs []string, sinceTweetID string, limit int) (int, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	// The inner LIMIT stops the scan as soon as the cap is reached.
+	query := `
+		SELECT COUNT(*)
+		FROM (
+			SELECT 1
+			FROM tweets
+			WHERE user_id = ANY($1)
+			  AND moderation_status = 'visible'
+			  AND (created_at, id) > (SELECT c.created_at, c.id FROM tweets AS c WHERE c.id = $2)
+			LIMIT $3
+		) AS newer
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userIDs, sinceTweetID, limit).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting tweets since %s: %w", sinceTweetID, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,89 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountTweetsSince(t *testing.T) {
+	ctx := context.Background()
+	userIDs := []string{uuid.NewString(), uuid.NewString()}
+	sinceTweetID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM (
+			SELECT 1
+			FROM tweets
+			WHERE user_id = ANY($1)
+			  AND moderation_status = 'visible'
+			  AND (created_at, id) > (SELECT c.created_at, c.id FROM tweets AS c WHERE c.id = $2)
+			LIMIT $3
+		) AS newer
+	`)
+
+	testCases := []struct {
+		name          string
+		userIDs       []string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedCount int
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:    "Success - counts newer tweets",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, sinceTweetID, 100).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+			},
+			expectedCount: 12,
+		},
+		{
+			name:          "Success - no followees skips the query",
+			userIDs:       []string{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedCount: 0,
+		},
+		{
+			name:    "Failure - database error",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, sinceTweetID, 100).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "error counting tweets since",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			count, err := repo.CountTweetsSince(ctx, tc.userIDs, sinceTweetID, 100)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
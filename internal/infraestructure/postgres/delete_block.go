@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// DeleteBlock removes the block of BlockerID on BlockedID, if any. The follows removed by the
+// block aren't restored.
+func (r Repository) DeleteBlock(ctx context.Context, block domain.BlockUser) error {
+	query := `
+		DELETE FROM blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`
+	_, err := r.db.ExecContext(ctx, query, block.BlockerID, block.BlockedID)
+
+	return err
+}
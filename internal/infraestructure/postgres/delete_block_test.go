@@ -0,0 +1,79 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteBlock(t *testing.T) {
+	ctx := context.Background()
+	block := domain.BlockUser{
+		BlockerID: uuid.NewString(),
+		BlockedID: uuid.NewString(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		DELETE FROM blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - removes the block",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Success - no block is a no-op",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.DeleteBlock(ctx, block)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// DeleteMute removes the mute of MuterID on MutedID, if any.
+func (r Repository) DeleteMute(ctx context.Context, mute domain.MuteUser) error {
+	query := `
+		DELETE FROM mutes
+		WHERE muter_id = $1 AND muted_id = $2
+	`
+	_, err := r.db.ExecContext(ctx, query, mute.MuterID, mute.MutedID)
+
+	return err
+}
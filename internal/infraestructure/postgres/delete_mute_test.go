@@ -0,0 +1,79 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteMute(t *testing.T) {
+	ctx := context.Background()
+	mute := domain.MuteUser{
+		MuterID: uuid.NewString(),
+		MutedID: uuid.NewString(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		DELETE FROM mutes
+		WHERE muter_id = $1 AND muted_id = $2
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - removes the mute",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Success - no mute is a no-op",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.DeleteMute(ctx, mute)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExistsBlockBetween reports whether either user blocks the other.
+func (r Repository) ExistsBlockBetween(ctx context.Context, userID, otherUserID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2)
+			   OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var blocked bool
+	if err := r.db.QueryRowContext(ctx, query, userID, otherUserID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("error checking blocks: %w", err)
+	}
+
+	return blocked, nil
+}
@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistsBlockBetween(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	otherUserID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2)
+			   OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`)
+
+	testCases := []struct {
+		name            string
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedBlocked bool
+		errorContains   string
+	}{
+		{
+			name: "Success - a block exists",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, otherUserID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedBlocked: true,
+		},
+		{
+			name: "Success - no block exists",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, otherUserID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedBlocked: false,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, otherUserID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "error checking blocks: database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			blocked, err := repo.ExistsBlockBetween(ctx, userID, otherUserID)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedBlocked, blocked)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
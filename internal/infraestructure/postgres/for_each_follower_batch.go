@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// ForEachFollowerBatch calls fn with the IDs of the users following userID, at most batchSize
+// at a time and ordered by ID, starting after afterFollowerID (from the first one when empty).
+// Pages are read with a keyset on follower_id, so memory stays bounded by batchSize no matter
+// how many followers the user has, and no connection is held while fn runs. Iteration stops at
+// the first error, which is returned as is when it comes from fn.
+func (r Repository) ForEachFollowerBatch(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	cursor := afterFollowerID
+	for {
+		followerIDs, err := r.selectFollowerIDsPage(ctx, userID, cursor, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(followerIDs) == 0 {
+			return nil
+		}
+
+		if err := fn(followerIDs); err != nil {
+			return err
+		}
+
+		if len(followerIDs) < batchSize {
+			return nil
+		}
+		cursor = followerIDs[len(followerIDs)-1]
+	}
+}
+
+// selectFollowerIDsPage returns up to limit followers of userID with an ID greater than cursor.
+// Followers blocking userID are left out: a block removes the follow, but one created
+// concurrently with the block could still be there.
+func (r Repository) selectFollowerIDsPage(ctx context.Context, userID, cursor string, limit int) ([]string, error) {
+	query := `
+		SELECT follower_id
+		FROM follows
+		WHERE following_id = $1
+		  AND ($2::uuid IS NULL OR follower_id > $2)
+		  AND NOT EXISTS (
+			SELECT 1 FROM blocks WHERE blocker_id = follows.follower_id AND blocked_id = $1
+		  )
+		ORDER BY follower_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, nullableCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	followerIDs := make([]string, 0, limit)
+	for rows.Next() {
+		var followerID string
+		if err := rows.Scan(&followerID); err != nil {
+			return nil, err
+		}
+		followerIDs = append(followerIDs, followerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return followerIDs, nil
+}
@@ -0,0 +1,166 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachFollowerBatch(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	follower1 := "00000000-0000-0000-0000-000000000001"
+	follower2 := "00000000-0000-0000-0000-000000000002"
+	follower3 := "00000000-0000-0000-0000-000000000003"
+	fnError := errors.New("fan-out aborted")
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT follower_id
+		FROM follows
+		WHERE following_id = $1
+		  AND ($2::uuid IS NULL OR follower_id > $2)
+		  AND NOT EXISTS (
+			SELECT 1 FROM blocks WHERE blocker_id = follows.follower_id AND blocked_id = $1
+		  )
+		ORDER BY follower_id
+		LIMIT $3
+	`)
+
+	testCases := []struct {
+		name            string
+		after           string
+		batchSize       int
+		setupMock       func(mock sqlmock.Sqlmock)
+		fnErr           error
+		expectedBatches [][]string
+		expectError     bool
+		errorContains   string
+	}{
+		{
+			name:      "Success - pages with a keyset until a short page",
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower1).AddRow(follower2))
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, follower2, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower3))
+			},
+			expectedBatches: [][]string{{follower1, follower2}, {follower3}},
+		},
+		{
+			name:      "Success - full last page ends with an empty page",
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower1).AddRow(follower2))
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, follower2, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}))
+			},
+			expectedBatches: [][]string{{follower1, follower2}},
+		},
+		{
+			name:      "Success - starts after the given follower",
+			after:     follower1,
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, follower1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower2))
+			},
+			expectedBatches: [][]string{{follower2}},
+		},
+		{
+			name:      "Success - user without followers",
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}))
+			},
+			expectedBatches: nil,
+		},
+		{
+			name:      "Failure - fn error stops the iteration",
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower1).AddRow(follower2))
+			},
+			fnErr:           fnError,
+			expectedBatches: [][]string{{follower1, follower2}},
+			expectError:     true,
+			errorContains:   "fan-out aborted",
+		},
+		{
+			name:      "Failure - database error on a later page",
+			batchSize: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower1))
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, follower1, 1).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectedBatches: [][]string{{follower1}},
+			expectError:     true,
+			errorContains:   "database connection lost",
+		},
+		{
+			name:      "Failure - row iteration error",
+			batchSize: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID, nil, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"follower_id"}).AddRow(follower1).RowError(0, errors.New("broken row")))
+			},
+			expectError:   true,
+			errorContains: "broken row",
+		},
+		{
+			name:          "Failure - invalid batch size",
+			batchSize:     0,
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectError:   true,
+			errorContains: "batch size must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			var batches [][]string
+			fn := func(followerIDs []string) error {
+				batches = append(batches, followerIDs)
+				return tc.fnErr
+			}
+
+			// Act
+			err := repo.ForEachFollowerBatch(ctx, userID, tc.after, tc.batchSize, fn)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedBatches, batches)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
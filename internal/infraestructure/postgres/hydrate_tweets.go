@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// hydrateTweets sets what every read of tweets returns besides their row: their media and
+// the previews of the links in their text.
+func (r Repository) hydrateTweets(ctx context.Context, tweets []domain.Tweet) error {
+	if err := r.loadTweetMedia(ctx, tweets); err != nil {
+		return err
+	}
+	return r.loadLinkPreviews(ctx, tweets)
+}
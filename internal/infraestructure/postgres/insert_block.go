@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// CreateBlock records that BlockerID blocks BlockedID and removes the follows between both
+// users, in either direction, in the same transaction. Blocking an already blocked user only
+// removes the follows again.
+func (r Repository) CreateBlock(ctx context.Context, block domain.BlockUser) error {
+	insertQuery := `
+		INSERT INTO blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+	unfollowQuery := `
+		DELETE FROM follows
+		WHERE (follower_id = $1 AND following_id = $2)
+		   OR (follower_id = $2 AND following_id = $1)
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// A no-op once committed.
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, insertQuery, block.BlockerID, block.BlockedID); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, unfollowQuery, block.BlockerID, block.BlockedID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,98 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBlock(t *testing.T) {
+	ctx := context.Background()
+	block := domain.BlockUser{
+		BlockerID: uuid.NewString(),
+		BlockedID: uuid.NewString(),
+	}
+
+	expectedInsert := regexp.QuoteMeta(`
+		INSERT INTO blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`)
+	expectedUnfollow := regexp.QuoteMeta(`
+		DELETE FROM follows
+		WHERE (follower_id = $1 AND following_id = $2)
+		   OR (follower_id = $2 AND following_id = $1)
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - blocks the user and removes the follows between both",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(expectedInsert).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(expectedUnfollow).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failure - insert error stores nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(expectedInsert).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnError(errors.New("database connection lost"))
+				mock.ExpectRollback()
+			},
+			errorContains: "database connection lost",
+		},
+		{
+			name: "Failure - unfollow error stores nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(expectedInsert).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(expectedUnfollow).
+					WithArgs(block.BlockerID, block.BlockedID).
+					WillReturnError(errors.New("database connection lost"))
+				mock.ExpectRollback()
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.CreateBlock(ctx, block)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// CreateFanOutJob registers a running fan-out job for tweetID. It returns false when the tweet
+// already has a job, so the same tweet is never fanned out twice.
+func (r Repository) CreateFanOutJob(ctx context.Context, tweetID, authorID string) (bool, error) {
+	query := `
+		INSERT INTO fan_out_jobs (tweet_id, author_id, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tweet_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tweetID, authorID, domain.FanOutJobRunning)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
@@ -0,0 +1,83 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFanOutJob(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		INSERT INTO fan_out_jobs (tweet_id, author_id, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tweet_id) DO NOTHING
+	`)
+
+	testCases := []struct {
+		name            string
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedCreated bool
+		expectError     bool
+		errorContains   string
+	}{
+		{
+			name: "Success - creates the job",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(tweetID, authorID, "running").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "Success - job already exists",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(tweetID, authorID, "running").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedCreated: false,
+		},
+		{
+			name: "Failure - database error on exec",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(tweetID, authorID, "running").
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			created, err := repo.CreateFanOutJob(ctx, tweetID, authorID)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedCreated, created)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
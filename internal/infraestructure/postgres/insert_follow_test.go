@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"testing"
@@ -11,16 +12,31 @@ import (
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 	"github.com/renzonaitor/tweet-api/internal/domain"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/postgres"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// arrayValueConverter lets []string arguments through to sqlmock, the same way the
+// pgx driver accepts them for `= ANY($1)` queries.
+type arrayValueConverter struct{}
+
+func (arrayValueConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if values, ok := v.([]string); ok {
+		return values, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
 // setupRepoWithMock is a test helper to create a repository instance with a mock DB.
 // It handles the boilerplate of satisfying the constructor's ping expectation.
 func setupRepoWithMock(t *testing.T) (*postgres.Repository, sqlmock.Sqlmock) {
 	t.Helper()
 
-	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	db, mock, err := sqlmock.New(
+		sqlmock.MonitorPingsOption(true),
+		sqlmock.ValueConverterOption(arrayValueConverter{}),
+	)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
 
@@ -33,7 +49,7 @@ func setupRepoWithMock(t *testing.T) (*postgres.Repository, sqlmock.Sqlmock) {
 			MaxIdleConnection: 5,
 		},
 	}
-	repo, err := postgres.NewRepositoryWithDB(db, cfg)
+	repo, err := postgres.NewRepositoryWithDB(db, cfg, logging.Discard())
 	require.NoError(t, err)
 
 	return repo, mock
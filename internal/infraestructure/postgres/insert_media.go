@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// CreateMedia inserts the metadata of an uploaded media, not yet attached to a tweet.
+func (r Repository) CreateMedia(ctx context.Context, media domain.Media) error {
+	query := `
+		INSERT INTO media (id, user_id, content_type, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.ExecContext(ctx, query, media.ID, media.UserID, media.ContentType, media.Size, media.CreatedAt)
+
+	return err
+}
@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMedia(t *testing.T) {
+	ctx := context.Background()
+	media := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		ContentType: "image/png",
+		Size:        2048,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		INSERT INTO media (id, user_id, content_type, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - inserts the media",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(media.ID, media.UserID, media.ContentType, media.Size, media.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(media.ID, media.UserID, media.ContentType, media.Size, media.CreatedAt).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.CreateMedia(ctx, media)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// CreateMute records that MuterID mutes MutedID. Muting an already muted user is a no-op.
+func (r Repository) CreateMute(ctx context.Context, mute domain.MuteUser) error {
+	query := `
+		INSERT INTO mutes (muter_id, muted_id)
+		VALUES ($1, $2)
+		ON CONFLICT (muter_id, muted_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, mute.MuterID, mute.MutedID)
+
+	return err
+}
@@ -0,0 +1,80 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMute(t *testing.T) {
+	ctx := context.Background()
+	mute := domain.MuteUser{
+		MuterID: uuid.NewString(),
+		MutedID: uuid.NewString(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		INSERT INTO mutes (muter_id, muted_id)
+		VALUES ($1, $2)
+		ON CONFLICT (muter_id, muted_id) DO NOTHING
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - mutes the user",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Success - already muted is a no-op",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(mute.MuterID, mute.MutedID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.CreateMute(ctx, mute)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
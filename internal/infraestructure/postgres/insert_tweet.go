@@ -2,29 +2,118 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
 )
 
-func (r Repository) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+// CreateTweet inserts tweet unless a tweet with its ID already exists. It returns the stored
+// tweet and whether it was created by this call; on a conflict the existing row is returned,
+// so concurrent requests with the same ID all get the same tweet and only one creates it.
+//
+// The Media of tweet are attached in the same transaction as the insert. They must belong to
+// the author and not be attached yet, otherwise nothing is stored. So is its moderation Flag,
+// when set.
+func (r Repository) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
 	query := `
-		INSERT INTO tweets (id, user_id, content, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO tweets (id, user_id, content, created_at, request_fingerprint, url_entities, moderation_status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id, user_id, content, created_at, edited_at, url_entities, moderation_status, COALESCE(request_fingerprint, '')
 	`
 
-	// `ExecContext` is used for queries that don't return rows (INSERT, UPDATE, DELETE).
-	result, err := r.db.ExecContext(ctx, query, tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return domain.Tweet{}, err
+		return domain.Tweet{}, false, err
 	}
-	rows, err := result.RowsAffected()
+	// A no-op once committed.
+	defer func() { _ = tx.Rollback() }()
+
+	status := tweet.Moderation
+	if status == "" {
+		status = domain.TweetVisible
+	}
+	row := tx.QueryRowContext(ctx, query, tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, urlEntities(tweet.URLs), status)
+
+	var created domain.Tweet
+	err = row.Scan(&created.ID, &created.UserID, &created.Text, &created.CreatedAt, &created.EditedAt, (*urlEntities)(&created.URLs), &created.Moderation, &created.Fingerprint)
+	if err == nil {
+		if err = attachMedia(ctx, tx, tweet); err != nil {
+			return domain.Tweet{}, false, err
+		}
+		if err = insertModerationFlag(ctx, tx, tweet); err != nil {
+			return domain.Tweet{}, false, err
+		}
+		if err = tx.Commit(); err != nil {
+			return domain.Tweet{}, false, err
+		}
+		created.Media = tweet.Media
+		return created, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.Tweet{}, false, err
+	}
+	_ = tx.Rollback()
+
+	// Nothing was inserted: the ID is taken. The insert waited for the conflicting transaction,
+	// so the row is committed, but it has to be read by a new statement: this one's snapshot
+	// predates it.
+	existing, err := r.SelectTweetByID(ctx, tweet.ID)
 	if err != nil {
-		return domain.Tweet{}, err
+		return domain.Tweet{}, false, err
+	}
+	if existing == nil {
+		return domain.Tweet{}, false, fmt.Errorf("tweet %s conflicted on insert but was not found", tweet.ID)
+	}
+	return *existing, false, nil
+}
+
+// insertModerationFlag stores why tweet broke a moderation rule, if it did.
+func insertModerationFlag(ctx context.Context, tx *sql.Tx, tweet domain.Tweet) error {
+	if tweet.Flag == nil {
+		return nil
+	}
+
+	query := `
+		INSERT INTO moderation_flags (tweet_id, action, rule, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := tx.ExecContext(ctx, query, tweet.ID, tweet.Flag.Action, tweet.Flag.Rule, tweet.Flag.Reason, tweet.CreatedAt)
+	return err
+}
+
+// attachMedia links the Media of tweet to it, keeping their order. It fails when one of them
+// isn't attachable any more, e.g. a concurrent request attached it to another tweet.
+func attachMedia(ctx context.Context, tx *sql.Tx, tweet domain.Tweet) error {
+	if len(tweet.Media) == 0 {
+		return nil
 	}
-	if rows != 1 {
-		// Add Warning Log
-		return tweet, nil // TODO [technical debt] improve behavior
+
+	mediaIDs := make([]string, len(tweet.Media))
+	for i, media := range tweet.Media {
+		mediaIDs[i] = media.ID
 	}
 
-	return tweet, nil
+	query := `
+		UPDATE media
+		SET tweet_id = $1, position = attached.position
+		FROM unnest($3::uuid[]) WITH ORDINALITY AS attached(id, position)
+		WHERE media.id = attached.id AND media.user_id = $2 AND media.tweet_id IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, tweet.ID, tweet.UserID, mediaIDs)
+	if err != nil {
+		return err
+	}
+	attached, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if attached != int64(len(mediaIDs)) {
+		return fmt.Errorf("attached %d of the %d media of tweet %s", attached, len(mediaIDs), tweet.ID)
+	}
+	return nil
 }
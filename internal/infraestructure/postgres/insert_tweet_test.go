@@ -0,0 +1,257 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTweet(t *testing.T) {
+	ctx := context.Background()
+	tweet := domain.Tweet{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		Text:        "hello",
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fingerprint: "fingerprint",
+		Moderation:  domain.TweetVisible,
+	}
+	editedAt := time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC)
+	existing := tweet
+	existing.CreatedAt = time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+	existing.EditedAt = &editedAt
+
+	expectedInsert := regexp.QuoteMeta(`
+		INSERT INTO tweets (id, user_id, content, created_at, request_fingerprint, url_entities, moderation_status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id, user_id, content, created_at, edited_at, url_entities, moderation_status, COALESCE(request_fingerprint, '')
+	`)
+	expectedSelect := regexp.QuoteMeta(`
+		SELECT id, user_id, content, created_at, edited_at, url_entities, moderation_status, COALESCE(request_fingerprint, '')
+		FROM tweets
+		WHERE id = $1
+	`)
+	expectedAttach := regexp.QuoteMeta(`
+		UPDATE media
+		SET tweet_id = $1, position = attached.position
+		FROM unnest($3::uuid[]) WITH ORDINALITY AS attached(id, position)
+		WHERE media.id = attached.id AND media.user_id = $2 AND media.tweet_id IS NULL
+	`)
+	expectedSelectMedia := regexp.QuoteMeta(`
+		SELECT id, user_id, content_type, size_bytes, created_at, tweet_id
+		FROM media
+		WHERE tweet_id = ANY($1)
+	`)
+	columns := []string{"id", "user_id", "content", "created_at", "edited_at", "url_entities", "moderation_status", "request_fingerprint"}
+	mediaColumns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+
+	media := []domain.Media{
+		{ID: uuid.NewString(), UserID: tweet.UserID, ContentType: "image/png", Size: 2048, CreatedAt: tweet.CreatedAt},
+		{ID: uuid.NewString(), UserID: tweet.UserID, ContentType: "video/mp4", Size: 4096, CreatedAt: tweet.CreatedAt},
+	}
+	tweetWithMedia := tweet
+	tweetWithMedia.Media = media
+	mediaIDs := []string{media[0].ID, media[1].ID}
+	existingMedia := []domain.Media{media[0]}
+	existingMedia[0].TweetID = tweet.ID
+	existingWithMedia := existing
+	existingWithMedia.Media = existingMedia
+
+	tweetWithURL := tweet
+	tweetWithURL.Text = "read https://example.com/post"
+	tweetWithURL.URLs = []domain.URLEntity{{URL: "https://example.com/post", Start: 5, End: 29}}
+	storedURLs := `[{"url":"https://example.com/post","start":5,"end":29}]`
+
+	heldTweet := tweet
+	heldTweet.Moderation = domain.TweetHeld
+	heldTweet.Flag = &domain.ModerationVerdict{Action: domain.ModerationHold, Rule: "banned_words", Reason: `contains the banned word "scam"`}
+	storedHeldTweet := heldTweet
+	storedHeldTweet.Flag = nil
+	expectedInsertFlag := regexp.QuoteMeta(`
+		INSERT INTO moderation_flags (tweet_id, action, rule, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+
+	testCases := []struct {
+		name            string
+		input           domain.Tweet
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedTweet   domain.Tweet
+		expectedCreated bool
+		errorContains   string
+	}{
+		{
+			name:  "Success - creates the tweet",
+			input: tweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, nil, "visible", tweet.Fingerprint))
+				mock.ExpectCommit()
+			},
+			expectedTweet:   tweet,
+			expectedCreated: true,
+		},
+		{
+			name:  "Success - creates the tweet with its url entities",
+			input: tweetWithURL,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweetWithURL.Text, tweet.CreatedAt, tweet.Fingerprint, storedURLs, "visible").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweetWithURL.Text, tweet.CreatedAt, nil, []byte(storedURLs), "visible", tweet.Fingerprint))
+				mock.ExpectCommit()
+			},
+			expectedTweet:   tweetWithURL,
+			expectedCreated: true,
+		},
+		{
+			name:  "Success - creates a flagged tweet with its moderation flag",
+			input: heldTweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "held").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, nil, "held", tweet.Fingerprint))
+				mock.ExpectExec(expectedInsertFlag).
+					WithArgs(tweet.ID, "hold", "banned_words", `contains the banned word "scam"`, tweet.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedTweet:   storedHeldTweet,
+			expectedCreated: true,
+		},
+		{
+			name:  "Failure - moderation flag insert error stores nothing",
+			input: heldTweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "held").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, nil, "held", tweet.Fingerprint))
+				mock.ExpectExec(expectedInsertFlag).
+					WithArgs(tweet.ID, "hold", "banned_words", `contains the banned word "scam"`, tweet.CreatedAt).
+					WillReturnError(errors.New("database connection lost"))
+				mock.ExpectRollback()
+			},
+			errorContains: "database connection lost",
+		},
+		{
+			name:  "Success - creates the tweet and attaches its media in order",
+			input: tweetWithMedia,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, nil, "visible", tweet.Fingerprint))
+				mock.ExpectExec(expectedAttach).
+					WithArgs(tweet.ID, tweet.UserID, mediaIDs).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedTweet:   tweetWithMedia,
+			expectedCreated: true,
+		},
+		{
+			name:  "Failure - a media is no longer attachable",
+			input: tweetWithMedia,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, nil, "visible", tweet.Fingerprint))
+				mock.ExpectExec(expectedAttach).
+					WithArgs(tweet.ID, tweet.UserID, mediaIDs).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			errorContains: "attached 1 of the 2 media",
+		},
+		{
+			name:  "Success - returns the existing tweet and its media on conflict",
+			input: tweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+				mock.ExpectQuery(expectedSelect).
+					WithArgs(tweet.ID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(existing.ID, existing.UserID, existing.Text, existing.CreatedAt, editedAt, nil, "visible", existing.Fingerprint))
+				mock.ExpectQuery(expectedSelectMedia).
+					WithArgs([]string{tweet.ID}).
+					WillReturnRows(sqlmock.NewRows(mediaColumns).
+						AddRow(media[0].ID, media[0].UserID, media[0].ContentType, media[0].Size, media[0].CreatedAt, tweet.ID))
+			},
+			expectedTweet:   existingWithMedia,
+			expectedCreated: false,
+		},
+		{
+			name:  "Failure - conflicting tweet was deleted",
+			input: tweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+				mock.ExpectQuery(expectedSelect).
+					WithArgs(tweet.ID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			errorContains: "conflicted on insert but was not found",
+		},
+		{
+			name:  "Failure - database error on insert",
+			input: tweet,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(expectedInsert).
+					WithArgs(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, tweet.Fingerprint, nil, "visible").
+					WillReturnError(errors.New("database connection lost"))
+				mock.ExpectRollback()
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			stored, created, err := repo.CreateTweet(ctx, tc.input)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTweet, stored)
+				assert.Equal(t, tc.expectedCreated, created)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
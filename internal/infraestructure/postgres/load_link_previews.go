@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// loadLinkPreviews sets the Preview of the URLs of tweets with a single query, skipped when
+// none of them links anywhere. URLs not unfurled yet, or without metadata, keep a nil Preview.
+func (r Repository) loadLinkPreviews(ctx context.Context, tweets []domain.Tweet) error {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, tweet := range tweets {
+		for _, entity := range tweet.URLs {
+			if !seen[entity.URL] {
+				seen[entity.URL] = true
+				urls = append(urls, entity.URL)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	previews, err := r.SelectLinkPreviews(ctx, urls)
+	if err != nil {
+		return err
+	}
+
+	previewByURL := make(map[string]domain.LinkPreview, len(previews))
+	for _, preview := range previews {
+		if !preview.Empty() {
+			previewByURL[preview.URL] = preview
+		}
+	}
+
+	for i := range tweets {
+		for j := range tweets[i].URLs {
+			if preview, ok := previewByURL[tweets[i].URLs[j].URL]; ok {
+				tweets[i].URLs[j].Preview = &preview
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// loadTweetMedia sets the Media of tweets with a single query.
+func (r Repository) loadTweetMedia(ctx context.Context, tweets []domain.Tweet) error {
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	tweetIDs := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		tweetIDs[i] = tweet.ID
+	}
+
+	query := `
+		SELECT id, user_id, content_type, size_bytes, created_at, tweet_id
+		FROM media
+		WHERE tweet_id = ANY($1)
+		ORDER BY tweet_id, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tweetIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	mediaByTweet := make(map[string][]domain.Media)
+	for rows.Next() {
+		var m domain.Media
+		if err := rows.Scan(&m.ID, &m.UserID, &m.ContentType, &m.Size, &m.CreatedAt, &m.TweetID); err != nil {
+			return err
+		}
+		mediaByTweet[m.TweetID] = append(mediaByTweet[m.TweetID], m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range tweets {
+		tweets[i].Media = mediaByTweet[tweets[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ping checks that the database is reachable, opening a connection if none is idle.
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
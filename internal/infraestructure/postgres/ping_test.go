@@ -0,0 +1,55 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "Success - database answers",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+			},
+		},
+		{
+			name: "Failure - database is offline",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing().WillReturnError(errors.New("database is offline"))
+			},
+			expectError:   true,
+			errorContains: "failed to ping database: database is offline",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.Ping(context.Background())
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,21 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	// Import the pgx driver
 	_ "github.com/jackc/pgx/v5/stdlib"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 )
 
 // Repository holds the database connection pool.
 type Repository struct {
-	db *sql.DB
+	db     *sql.DB
+	logger *slog.Logger
 }
 
-func NewRepository(cfg config.Config) (*Repository, error) {
+func NewRepository(cfg config.Config, logger *slog.Logger) (*Repository, error) {
 	// 1. Construct the Data Source Name (DSN) string from your config.
 	// This string contains all the necessary info to connect to the database.
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
@@ -31,7 +34,11 @@ func NewRepository(cfg config.Config) (*Repository, error) {
 
 	// 2. Open a connection pool.
 	// `sql.Open` doesn't actually create any connections yet, it just prepares the pool.
-	db, err := sql.Open("pgx", dsn)
+	// The driver is wrapped so every query is traced as a child span of the caller's context.
+	db, err := otelsql.Open("pgx", dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		// If this fails, the application can't start, so we panic.
 		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
@@ -54,24 +61,25 @@ func NewRepository(cfg config.Config) (*Repository, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Successfully connected to the PostgresSQL database.")
+	logger.Info("successfully connected to the PostgreSQL database")
 
 	// 5. Return the repository with the active connection pool.
 	return &Repository{
-		db: db,
+		db:     db,
+		logger: logger,
 	}, nil
 }
 
 // Close gracefully closes the database connection pool.
 func (r *Repository) Close() {
 	if err := r.db.Close(); err != nil {
-		log.Printf("Error closing the database: %v", err)
+		r.logger.Error("error closing the database", "error", err)
 	}
 }
 
 // NewRepositoryWithDB is a helper constructor that takes an existing *sql.DB.
 // This makes it easy to "inject" a mock database during tests.
-func NewRepositoryWithDB(db *sql.DB, cfg config.Config) (*Repository, error) {
+func NewRepositoryWithDB(db *sql.DB, cfg config.Config, logger *slog.Logger) (*Repository, error) {
 	// 1. Configure the connection pool.
 	db.SetMaxOpenConns(cfg.Postgres.MaxOpenConnection)
 	db.SetMaxIdleConns(cfg.Postgres.MaxIdleConnection)
@@ -89,10 +97,11 @@ func NewRepositoryWithDB(db *sql.DB, cfg config.Config) (*Repository, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Successfully connected to the PostgresSQL database.")
+	logger.Info("successfully connected to the PostgreSQL database")
 
 	// 3. Return the repository with the active connection pool.
 	return &Repository{
-		db: db,
+		db:     db,
+		logger: logger,
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/postgres"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -60,7 +61,7 @@ func TestNewRepositoryWithDB(t *testing.T) {
 				tc.setupMock(mock)
 			}
 
-			repo, err := postgres.NewRepositoryWithDB(db, cfg)
+			repo, err := postgres.NewRepositoryWithDB(db, cfg, logging.Discard())
 
 			// Assert
 			if tc.expectError {
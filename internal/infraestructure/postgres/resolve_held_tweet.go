@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// ResolveHeldTweet records the decision of reviewerID on the held tweet tweetID and sets the
+// status the decision gives it, in a single statement. It returns the resolved tweet, or nil
+// when tweetID isn't waiting for a review: the flag row is locked, so of concurrent reviews
+// only the first one resolves the tweet.
+func (r Repository) ResolveHeldTweet(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision, reviewedAt time.Time) (*domain.Tweet, error) {
+	query := `
+		WITH flag AS (
+			UPDATE moderation_flags
+			SET decision = $2, reviewed_by = $3, reviewed_at = $4
+			WHERE tweet_id = $1 AND action = 'hold' AND decision IS NULL
+			RETURNING tweet_id
+		)
+		UPDATE tweets
+		SET moderation_status = $5
+		FROM flag
+		WHERE tweets.id = flag.tweet_id
+		RETURNING tweets.id, tweets.user_id, tweets.content, tweets.created_at, tweets.edited_at, tweets.url_entities, tweets.moderation_status
+	`
+
+	row := r.db.QueryRowContext(ctx, query, tweetID, decision, reviewerID, reviewedAt, decision.Status())
+
+	var tweet domain.Tweet
+	err := row.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs), &tweet.Moderation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error resolving held tweet: %w", err)
+	}
+
+	tweets := []domain.Tweet{tweet}
+	if err := r.hydrateTweets(ctx, tweets); err != nil {
+		return nil, err
+	}
+
+	return &tweets[0], nil
+}
@@ -0,0 +1,124 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveHeldTweet(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	reviewerID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reviewedAt := createdAt.Add(time.Hour)
+
+	expectedQuery := regexp.QuoteMeta(`
+		WITH flag AS (
+			UPDATE moderation_flags
+			SET decision = $2, reviewed_by = $3, reviewed_at = $4
+			WHERE tweet_id = $1 AND action = 'hold' AND decision IS NULL
+			RETURNING tweet_id
+		)
+		UPDATE tweets
+		SET moderation_status = $5
+		FROM flag
+		WHERE tweets.id = flag.tweet_id
+		RETURNING tweets.id, tweets.user_id, tweets.content, tweets.created_at, tweets.edited_at, tweets.url_entities, tweets.moderation_status
+	`)
+	expectedMediaQuery := regexp.QuoteMeta(`
+		SELECT id, user_id, content_type, size_bytes, created_at, tweet_id
+		FROM media
+		WHERE tweet_id = ANY($1)
+		ORDER BY tweet_id, position
+	`)
+	columns := []string{"id", "user_id", "content", "created_at", "edited_at", "url_entities", "moderation_status"}
+	mediaColumns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+	photo := domain.Media{ID: uuid.NewString(), UserID: authorID, ContentType: "image/png", Size: 2048, CreatedAt: createdAt, TweetID: tweetID}
+
+	testCases := []struct {
+		name          string
+		decision      domain.ReviewDecision
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedTweet *domain.Tweet
+		errorContains string
+	}{
+		{
+			name:     "Success - approving makes the tweet visible",
+			decision: domain.ReviewApprove,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, "approve", reviewerID, reviewedAt, "visible").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tweetID, authorID, "hello", createdAt, nil, nil, "visible"))
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs([]string{tweetID}).
+					WillReturnRows(sqlmock.NewRows(mediaColumns).
+						AddRow(photo.ID, photo.UserID, photo.ContentType, photo.Size, photo.CreatedAt, tweetID))
+			},
+			expectedTweet: &domain.Tweet{ID: tweetID, UserID: authorID, Text: "hello", CreatedAt: createdAt, Moderation: domain.TweetVisible, Media: []domain.Media{photo}},
+		},
+		{
+			name:     "Success - removing removes the tweet",
+			decision: domain.ReviewRemove,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, "remove", reviewerID, reviewedAt, "removed").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tweetID, authorID, "hello", createdAt, nil, nil, "removed"))
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs([]string{tweetID}).
+					WillReturnRows(sqlmock.NewRows(mediaColumns))
+			},
+			expectedTweet: &domain.Tweet{ID: tweetID, UserID: authorID, Text: "hello", CreatedAt: createdAt, Moderation: domain.TweetRemoved},
+		},
+		{
+			name:     "Success - tweet not waiting for a review returns nil",
+			decision: domain.ReviewApprove,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, "approve", reviewerID, reviewedAt, "visible").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedTweet: nil,
+		},
+		{
+			name:     "Failure - database error",
+			decision: domain.ReviewApprove,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, "approve", reviewerID, reviewedAt, "visible").
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "error resolving held tweet: database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			got, err := repo.ResolveHeldTweet(ctx, tweetID, reviewerID, tc.decision, reviewedAt)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTweet, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
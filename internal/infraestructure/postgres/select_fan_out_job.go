@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// fanOutJobColumns lists the columns read by scanFanOutJob, in order.
+const fanOutJobColumns = `tweet_id, author_id, status, last_follower_id, processed_count, attempts, last_error, created_at, updated_at`
+
+// SelectFanOutJob returns the fan-out job of tweetID, or nil when the tweet has none.
+func (r Repository) SelectFanOutJob(ctx context.Context, tweetID string) (*domain.FanOutJob, error) {
+	query := `
+		SELECT ` + fanOutJobColumns + `
+		FROM fan_out_jobs
+		WHERE tweet_id = $1
+	`
+
+	job, err := scanFanOutJob(r.db.QueryRowContext(ctx, query, tweetID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error scanning fan-out job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// scanFanOutJob reads a row selected with fanOutJobColumns.
+func scanFanOutJob(row interface{ Scan(dest ...any) error }) (domain.FanOutJob, error) {
+	var job domain.FanOutJob
+	var lastFollowerID, lastError sql.NullString
+
+	err := row.Scan(&job.TweetID, &job.AuthorID, &job.Status, &lastFollowerID, &job.ProcessedCount,
+		&job.Attempts, &lastError, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return domain.FanOutJob{}, err
+	}
+
+	job.LastFollowerID = lastFollowerID.String
+	job.LastError = lastError.String
+	return job, nil
+}
@@ -0,0 +1,120 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fanOutJobRowColumns are the columns returned by the fan-out job queries.
+var fanOutJobRowColumns = []string{"tweet_id", "author_id", "status", "last_follower_id", "processed_count", "attempts", "last_error", "created_at", "updated_at"}
+
+func TestSelectFanOutJob(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	followerID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2025, 1, 1, 10, 0, 5, 0, time.UTC)
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT tweet_id, author_id, status, last_follower_id, processed_count, attempts, last_error, created_at, updated_at
+		FROM fan_out_jobs
+		WHERE tweet_id = $1
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedJob   *domain.FanOutJob
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "Success - job with a checkpoint and an error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID).
+					WillReturnRows(sqlmock.NewRows(fanOutJobRowColumns).
+						AddRow(tweetID, authorID, "failed", followerID, 500, 1, "redis is down", createdAt, updatedAt))
+			},
+			expectedJob: &domain.FanOutJob{
+				TweetID:        tweetID,
+				AuthorID:       authorID,
+				Status:         "failed",
+				LastFollowerID: followerID,
+				ProcessedCount: 500,
+				Attempts:       1,
+				LastError:      "redis is down",
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+			},
+		},
+		{
+			name: "Success - job without a checkpoint",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID).
+					WillReturnRows(sqlmock.NewRows(fanOutJobRowColumns).
+						AddRow(tweetID, authorID, "running", nil, 0, 1, nil, createdAt, updatedAt))
+			},
+			expectedJob: &domain.FanOutJob{
+				TweetID:   tweetID,
+				AuthorID:  authorID,
+				Status:    "running",
+				Attempts:  1,
+				CreatedAt: createdAt,
+				UpdatedAt: updatedAt,
+			},
+		},
+		{
+			name: "Success - job not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedJob: nil,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "error scanning fan-out job",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			job, err := repo.SelectFanOutJob(ctx, tweetID)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedJob, job)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
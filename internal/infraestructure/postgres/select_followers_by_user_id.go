@@ -4,6 +4,7 @@ import (
 	"context"
 )
 
+// SelectFollowersByUserID returns the IDs of the users that userID follows (its followees).
 func (r Repository) SelectFollowersByUserID(ctx context.Context, userID string) ([]string, error) {
 	query := `
 		SELECT following_id
@@ -15,6 +16,7 @@ func (r Repository) SelectFollowersByUserID(ctx context.Context, userID string)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var followers []string
 	for rows.Next() {
@@ -26,5 +28,9 @@ func (r Repository) SelectFollowersByUserID(ctx context.Context, userID string)
 		followers = append(followers, followerID)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return followers, nil
 }
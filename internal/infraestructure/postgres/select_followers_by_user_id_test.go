@@ -0,0 +1,85 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectFollowersByUserID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	followee1 := uuid.NewString()
+	followee2 := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT following_id
+		FROM follows
+		WHERE follower_id = $1
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedIDs   []string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "Success - returns the followees",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"following_id"}).AddRow(followee1).AddRow(followee2))
+			},
+			expectedIDs: []string{followee1, followee2},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "database connection lost",
+		},
+		{
+			name: "Failure - row iteration error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"following_id"}).AddRow(followee1).RowError(0, errors.New("broken row")))
+			},
+			expectError:   true,
+			errorContains: "broken row",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			followees, err := repo.SelectFollowersByUserID(ctx, userID)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedIDs, followees)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
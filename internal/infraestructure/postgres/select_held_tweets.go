@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// SelectHeldTweets returns up to limit held tweets waiting for a review, the longest waiting
+// first, with why they were held.
+func (r Repository) SelectHeldTweets(ctx context.Context, limit int) ([]domain.ModerationReview, error) {
+	query := `
+		SELECT t.id, t.user_id, t.content, t.created_at, t.edited_at, t.url_entities, t.moderation_status,
+		       f.rule, f.reason, f.created_at
+		FROM moderation_flags AS f
+		JOIN tweets AS t ON t.id = f.tweet_id
+		WHERE f.action = 'hold' AND f.decision IS NULL
+		ORDER BY f.created_at, f.tweet_id
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domain.ModerationReview, 0, limit)
+	for rows.Next() {
+		var review domain.ModerationReview
+		tweet := &review.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs),
+			&tweet.Moderation, &review.Rule, &review.Reason, &review.FlaggedAt); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The reviewers see the tweets as they would be published.
+	tweets := make([]domain.Tweet, len(reviews))
+	for i, review := range reviews {
+		tweets[i] = review.Tweet
+	}
+	if err = r.hydrateTweets(ctx, tweets); err != nil {
+		return nil, err
+	}
+	for i := range reviews {
+		reviews[i].Tweet = tweets[i]
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,129 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectHeldTweets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	flaggedAt := now.Add(time.Second)
+
+	reviews := []domain.ModerationReview{
+		{
+			Tweet:     domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "this is a scam", CreatedAt: now, Moderation: domain.TweetHeld},
+			Rule:      "banned_words",
+			Reason:    `contains the banned word "scam"`,
+			FlaggedAt: flaggedAt,
+		},
+		{
+			Tweet: domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "see https://spam.example", CreatedAt: now, Moderation: domain.TweetHeld,
+				URLs: []domain.URLEntity{{URL: "https://spam.example", Start: 4, End: 24}}},
+			Rule:      "link_blocklist",
+			Reason:    `links to the blocked domain "spam.example"`,
+			FlaggedAt: flaggedAt,
+		},
+	}
+	storedURLs := `[{"url":"https://spam.example","start":4,"end":24}]`
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT t.id, t.user_id, t.content, t.created_at, t.edited_at, t.url_entities, t.moderation_status,
+		       f.rule, f.reason, f.created_at
+		FROM moderation_flags AS f
+		JOIN tweets AS t ON t.id = f.tweet_id
+		WHERE f.action = 'hold' AND f.decision IS NULL
+		ORDER BY f.created_at, f.tweet_id
+		LIMIT $1
+	`)
+	expectedMediaQuery := regexp.QuoteMeta(`
+		SELECT id, user_id, content_type, size_bytes, created_at, tweet_id
+		FROM media
+		WHERE tweet_id = ANY($1)
+		ORDER BY tweet_id, position
+	`)
+	expectedPreviewsQuery := regexp.QuoteMeta(`
+		SELECT url, title, description, image_url, fetched_at
+		FROM link_previews
+		WHERE url = ANY($1)
+	`)
+	columns := []string{"id", "user_id", "content", "created_at", "edited_at", "url_entities", "moderation_status", "rule", "reason", "flagged_at"}
+	mediaColumns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+	previewColumns := []string{"url", "title", "description", "image_url", "fetched_at"}
+
+	testCases := []struct {
+		name            string
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedReviews []domain.ModerationReview
+		errorContains   string
+	}{
+		{
+			name: "Success - returns the held tweets hydrated",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns)
+				for i, r := range reviews {
+					var urls driver.Value
+					if i == 1 {
+						urls = []byte(storedURLs)
+					}
+					rows.AddRow(r.Tweet.ID, r.Tweet.UserID, r.Tweet.Text, r.Tweet.CreatedAt, nil, urls, "held", r.Rule, r.Reason, r.FlaggedAt)
+				}
+				mock.ExpectQuery(expectedQuery).WithArgs(10).WillReturnRows(rows)
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs([]string{reviews[0].Tweet.ID, reviews[1].Tweet.ID}).
+					WillReturnRows(sqlmock.NewRows(mediaColumns))
+				mock.ExpectQuery(expectedPreviewsQuery).
+					WithArgs([]string{"https://spam.example"}).
+					WillReturnRows(sqlmock.NewRows(previewColumns))
+			},
+			expectedReviews: reviews,
+		},
+		{
+			name: "Success - empty queue",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).WithArgs(10).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedReviews: []domain.ModerationReview{},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(10).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			got, err := repo.SelectHeldTweets(ctx, 10)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedReviews, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// SelectHiddenAuthorIDs returns the IDs of the users whose tweets are hidden from the timeline
+// of userID: the ones userID muted, and the ones blocking or blocked by userID.
+func (r Repository) SelectHiddenAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT muted_id FROM mutes WHERE muter_id = $1
+		UNION
+		SELECT blocked_id FROM blocks WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting hidden authors: %w", err)
+	}
+	defer rows.Close()
+
+	var authorIDs []string
+	for rows.Next() {
+		var authorID string
+		if err := rows.Scan(&authorID); err != nil {
+			return nil, fmt.Errorf("error scanning hidden author: %w", err)
+		}
+		authorIDs = append(authorIDs, authorID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating hidden authors: %w", err)
+	}
+
+	return authorIDs, nil
+}
@@ -0,0 +1,95 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectHiddenAuthorIDs(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	mutedID := uuid.NewString()
+	blockedID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT muted_id FROM mutes WHERE muter_id = $1
+		UNION
+		SELECT blocked_id FROM blocks WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = $1
+	`)
+
+	testCases := []struct {
+		name            string
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedAuthors []string
+		errorContains   string
+	}{
+		{
+			name: "Success - returns the muted and blocked authors",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"muted_id"}).AddRow(mutedID).AddRow(blockedID))
+			},
+			expectedAuthors: []string{mutedID, blockedID},
+		},
+		{
+			name: "Success - nobody hidden returns nil",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"muted_id"}))
+			},
+			expectedAuthors: nil,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "error selecting hidden authors: database connection lost",
+		},
+		{
+			name: "Failure - row iteration error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"muted_id"}).
+						AddRow(mutedID).
+						RowError(0, errors.New("connection reset")))
+			},
+			errorContains: "error iterating hidden authors: connection reset",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			authorIDs, err := repo.SelectHiddenAuthorIDs(ctx, userID)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedAuthors, authorIDs)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// SelectLinkPreviews returns the stored previews of urls, in no particular order, including
+// the empty ones of pages that had nothing to preview. URLs never fetched are skipped.
+func (r Repository) SelectLinkPreviews(ctx context.Context, urls []string) ([]domain.LinkPreview, error) {
+	if len(urls) == 0 {
+		return []domain.LinkPreview{}, nil
+	}
+
+	query := `
+		SELECT url, title, description, image_url, fetched_at
+		FROM link_previews
+		WHERE url = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urls)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	previews := make([]domain.LinkPreview, 0, len(urls))
+	for rows.Next() {
+		var preview domain.LinkPreview
+		if err := rows.Scan(&preview.URL, &preview.Title, &preview.Description, &preview.ImageURL, &preview.FetchedAt); err != nil {
+			return nil, err
+		}
+		previews = append(previews, preview)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,89 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectLinkPreviews(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	previews := []domain.LinkPreview{
+		{URL: "https://go.dev", Title: "The Go Programming Language", Description: "Build simple, secure, scalable systems", ImageURL: "https://go.dev/images/go-logo.png", FetchedAt: now},
+		{URL: "https://example.com/empty", FetchedAt: now},
+	}
+	urls := []string{previews[0].URL, previews[1].URL}
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT url, title, description, image_url, fetched_at
+		FROM link_previews
+		WHERE url = ANY($1)
+	`)
+	columns := []string{"url", "title", "description", "image_url", "fetched_at"}
+
+	testCases := []struct {
+		name             string
+		urls             []string
+		setupMock        func(mock sqlmock.Sqlmock)
+		expectedPreviews []domain.LinkPreview
+		errorContains    string
+	}{
+		{
+			name: "Success - returns the previews, empty or not",
+			urls: urls,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns)
+				for _, p := range previews {
+					rows.AddRow(p.URL, p.Title, p.Description, p.ImageURL, p.FetchedAt)
+				}
+				mock.ExpectQuery(expectedQuery).WithArgs(urls).WillReturnRows(rows)
+			},
+			expectedPreviews: previews,
+		},
+		{
+			name:             "Success - no URLs skips the query",
+			urls:             []string{},
+			setupMock:        func(mock sqlmock.Sqlmock) {},
+			expectedPreviews: []domain.LinkPreview{},
+		},
+		{
+			name: "Failure - database error",
+			urls: urls,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(urls).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			got, err := repo.SelectLinkPreviews(ctx, tc.urls)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedPreviews, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
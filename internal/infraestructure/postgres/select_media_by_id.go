@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// SelectMediaByID returns the metadata of a media, nil if it doesn't exist or is attached to a
+// held or removed tweet, so it isn't served while the tweet is hidden.
+func (r Repository) SelectMediaByID(ctx context.Context, mediaID string) (*domain.Media, error) {
+	query := `
+		SELECT m.id, m.user_id, m.content_type, m.size_bytes, m.created_at, COALESCE(m.tweet_id::text, '')
+		FROM media AS m
+		LEFT JOIN tweets AS t ON t.id = m.tweet_id
+		WHERE m.id = $1
+		  AND (t.moderation_status IS NULL OR t.moderation_status NOT IN ('held', 'removed'))
+	`
+
+	row := r.db.QueryRowContext(ctx, query, mediaID)
+
+	var media domain.Media
+	err := row.Scan(&media.ID, &media.UserID, &media.ContentType, &media.Size, &media.CreatedAt, &media.TweetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error scanning media: %w", err)
+	}
+
+	return &media, nil
+}
@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectMediaByID(t *testing.T) {
+	ctx := context.Background()
+	media := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		ContentType: "image/png",
+		Size:        2048,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		TweetID:     uuid.NewString(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT m.id, m.user_id, m.content_type, m.size_bytes, m.created_at, COALESCE(m.tweet_id::text, '')
+		FROM media AS m
+		LEFT JOIN tweets AS t ON t.id = m.tweet_id
+		WHERE m.id = $1
+		  AND (t.moderation_status IS NULL OR t.moderation_status NOT IN ('held', 'removed'))
+	`)
+	columns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedMedia *domain.Media
+		errorContains string
+	}{
+		{
+			name: "Success - returns the media",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(media.ID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(media.ID, media.UserID, media.ContentType, media.Size, media.CreatedAt, media.TweetID))
+			},
+			expectedMedia: &media,
+		},
+		{
+			name: "Success - unknown media returns nil",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(media.ID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "Success - media of a held or removed tweet returns nil",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				// The join on tweets filters the row out.
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(media.ID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(media.ID).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			got, err := repo.SelectMediaByID(ctx, media.ID)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMedia, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
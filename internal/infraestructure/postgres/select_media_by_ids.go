@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// SelectMediaByIDs returns the metadata of the media matching mediaIDs, in no particular
+// order. Unknown IDs are skipped.
+func (r Repository) SelectMediaByIDs(ctx context.Context, mediaIDs []string) ([]domain.Media, error) {
+	if len(mediaIDs) == 0 {
+		return []domain.Media{}, nil
+	}
+
+	query := `
+		SELECT id, user_id, content_type, size_bytes, created_at, COALESCE(tweet_id::text, '')
+		FROM media
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	media := make([]domain.Media, 0, len(mediaIDs))
+	for rows.Next() {
+		var m domain.Media
+		if err := rows.Scan(&m.ID, &m.UserID, &m.ContentType, &m.Size, &m.CreatedAt, &m.TweetID); err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
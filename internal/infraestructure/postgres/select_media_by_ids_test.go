@@ -0,0 +1,91 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectMediaByIDs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	userID := uuid.NewString()
+	media := []domain.Media{
+		{ID: uuid.NewString(), UserID: userID, ContentType: "image/png", Size: 2048, CreatedAt: now},
+		{ID: uuid.NewString(), UserID: userID, ContentType: "video/mp4", Size: 4096, CreatedAt: now, TweetID: uuid.NewString()},
+	}
+	mediaIDs := []string{media[0].ID, media[1].ID}
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT id, user_id, content_type, size_bytes, created_at, COALESCE(tweet_id::text, '')
+		FROM media
+		WHERE id = ANY($1)
+	`)
+	columns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+
+	testCases := []struct {
+		name          string
+		mediaIDs      []string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedMedia []domain.Media
+		errorContains string
+	}{
+		{
+			name:     "Success - returns the media, attached or not",
+			mediaIDs: mediaIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns)
+				for _, m := range media {
+					rows.AddRow(m.ID, m.UserID, m.ContentType, m.Size, m.CreatedAt, m.TweetID)
+				}
+				mock.ExpectQuery(expectedQuery).WithArgs(mediaIDs).WillReturnRows(rows)
+			},
+			expectedMedia: media,
+		},
+		{
+			name:          "Success - no IDs skips the query",
+			mediaIDs:      []string{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedMedia: []domain.Media{},
+		},
+		{
+			name:     "Failure - database error",
+			mediaIDs: mediaIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(mediaIDs).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			got, err := repo.SelectMediaByIDs(ctx, tc.mediaIDs)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMedia, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/renzonaitor/tweet-api/internal/domain"
 )
 
+// SelectTweetByID returns the tweet with tweetID, whatever its moderation status, or nil if it
+// doesn't exist.
 func (r Repository) SelectTweetByID(ctx context.Context, tweetID string) (*domain.Tweet, error) {
 	query := `
-		SELECT id, user_id, content, created_at
+		SELECT id, user_id, content, created_at, edited_at, url_entities, moderation_status, COALESCE(request_fingerprint, '')
 		FROM tweets
 		WHERE id = $1
 	`
@@ -21,7 +23,7 @@ func (r Repository) SelectTweetByID(ctx context.Context, tweetID string) (*domai
 	row := r.db.QueryRowContext(ctx, query, tweetID)
 
 	var tweet domain.Tweet
-	err := row.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt)
+	err := row.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs), &tweet.Moderation, &tweet.Fingerprint)
 	if err != nil {
 		// It's a best practice to check specifically for sql.ErrNoRows.
 		// This indicates that the tweet was not found, which is a different
@@ -34,5 +36,10 @@ func (r Repository) SelectTweetByID(ctx context.Context, tweetID string) (*domai
 		return nil, fmt.Errorf("error scanning tweet: %w", err)
 	}
 
-	return &tweet, nil
+	tweets := []domain.Tweet{tweet}
+	if err := r.hydrateTweets(ctx, tweets); err != nil {
+		return nil, err
+	}
+
+	return &tweets[0], nil
 }
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+)
+
+// SelectTweetIDsByUsersID returns the IDs of the newest tweets published by userIDs,
+// newest first, up to limit. It's used to rebuild a cached timeline list, so only visible
+// tweets are returned.
+func (r Repository) SelectTweetIDsByUsersID(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+	if len(userIDs) == 0 {
+		return []string{}, nil
+	}
+
+	// Same per-user LATERAL merge as SelectLastTweetsByUsersID, so both paths agree on order.
+	query := `
+		SELECT t.id
+		FROM unnest($1::uuid[]) AS followee(user_id)
+		CROSS JOIN LATERAL (
+			SELECT id, created_at
+			FROM tweets
+			WHERE tweets.user_id = followee.user_id
+			  AND moderation_status = 'visible'
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		) AS t
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tweetIDs := make([]string, 0, limit)
+	for rows.Next() {
+		var tweetID string
+		if err := rows.Scan(&tweetID); err != nil {
+			return nil, err
+		}
+		tweetIDs = append(tweetIDs, tweetID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tweetIDs, nil
+}
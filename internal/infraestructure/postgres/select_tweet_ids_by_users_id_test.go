@@ -0,0 +1,104 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTweetIDsByUsersID(t *testing.T) {
+	ctx := context.Background()
+	userIDs := []string{uuid.NewString(), uuid.NewString()}
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT t.id
+		FROM unnest($1::uuid[]) AS followee(user_id)
+		CROSS JOIN LATERAL (
+			SELECT id, created_at
+			FROM tweets
+			WHERE tweets.user_id = followee.user_id
+			  AND moderation_status = 'visible'
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		) AS t
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT $2
+	`)
+
+	testCases := []struct {
+		name          string
+		userIDs       []string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedIDs   []string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:    "Success - returns ids newest first",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, 800).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tweet2).AddRow(tweet1))
+			},
+			expectedIDs: []string{tweet2, tweet1},
+		},
+		{
+			name:        "Success - no users skips the query",
+			userIDs:     []string{},
+			setupMock:   func(mock sqlmock.Sqlmock) {},
+			expectedIDs: []string{},
+		},
+		{
+			name:    "Failure - database error",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, 800).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "database connection lost",
+		},
+		{
+			name:    "Failure - row iteration error",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, 800).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tweet1).RowError(0, errors.New("broken row")))
+			},
+			expectError:   true,
+			errorContains: "broken row",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			tweetIDs, err := repo.SelectTweetIDsByUsersID(ctx, tc.userIDs, 800)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedIDs, tweetIDs)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// SelectTweetRevisions returns the previous texts of tweetID, newest first.
+func (r Repository) SelectTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	query := `
+		SELECT content, created_at, replaced_at
+		FROM tweet_revisions
+		WHERE tweet_id = $1
+		ORDER BY id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []domain.TweetRevision{}
+	for rows.Next() {
+		var revision domain.TweetRevision
+		if err := rows.Scan(&revision.Text, &revision.CreatedAt, &revision.ReplacedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,88 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTweetRevisions(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	firstEdit := createdAt.Add(time.Minute)
+	secondEdit := createdAt.Add(2 * time.Minute)
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT content, created_at, replaced_at
+		FROM tweet_revisions
+		WHERE tweet_id = $1
+		ORDER BY id DESC
+	`)
+	columns := []string{"content", "created_at", "replaced_at"}
+
+	testCases := []struct {
+		name              string
+		setupMock         func(mock sqlmock.Sqlmock)
+		expectedRevisions []domain.TweetRevision
+		errorContains     string
+	}{
+		{
+			name: "Success - revisions newest first",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("second text", firstEdit, secondEdit).
+						AddRow("first text", createdAt, firstEdit))
+			},
+			expectedRevisions: []domain.TweetRevision{
+				{Text: "second text", CreatedAt: firstEdit, ReplacedAt: secondEdit},
+				{Text: "first text", CreatedAt: createdAt, ReplacedAt: firstEdit},
+			},
+		},
+		{
+			name: "Success - never edited",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).WithArgs(tweetID).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedRevisions: []domain.TweetRevision{},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).WithArgs(tweetID).WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			revisions, err := repo.SelectTweetRevisions(ctx, tweetID)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRevisions, revisions)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -7,16 +7,18 @@ import (
 )
 
 // SelectTweetsByTweetsIDs retrieves a slice of Tweets that match the given IDs.
+// Rows come back in no particular order and unknown IDs are skipped; callers
+// that need a specific order (e.g. the cached timeline) arrange it themselves. Held and
+// removed tweets are skipped too.
 func (r Repository) SelectTweetsByTweetsIDs(ctx context.Context, tweetIDs []string) ([]domain.Tweet, error) {
 	if len(tweetIDs) == 0 {
 		return []domain.Tweet{}, nil
 	}
 
 	query := `
-		SELECT id, user_id, content, created_at
+		SELECT id, user_id, content, created_at, edited_at, url_entities
 		FROM tweets
-		WHERE id = ANY($1)
-		ORDER BY created_at DESC
+		WHERE id = ANY($1) AND moderation_status IN ('visible', 'limited')
 	`
 
 	// QueryContext is used because we expect multiple rows in the result.
@@ -30,7 +32,7 @@ func (r Repository) SelectTweetsByTweetsIDs(ctx context.Context, tweetIDs []stri
 
 	for rows.Next() {
 		var tweet domain.Tweet
-		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt); err != nil {
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs)); err != nil {
 			return nil, err
 		}
 		tweets = append(tweets, tweet)
@@ -40,5 +42,9 @@ func (r Repository) SelectTweetsByTweetsIDs(ctx context.Context, tweetIDs []stri
 		return nil, err
 	}
 
+	if err = r.hydrateTweets(ctx, tweets); err != nil {
+		return nil, err
+	}
+
 	return tweets, nil
 }
@@ -6,30 +6,49 @@ import (
 	"github.com/renzonaitor/tweet-api/internal/domain"
 )
 
-func (r Repository) SelectLastTweetsByUsersID(ctx context.Context, userIDs []string) ([]domain.Tweet, error) {
+// maxPreallocatedRows caps the capacity reserved for a page: limit comes from the request and
+// a page is often shorter.
+const maxPreallocatedRows = 100
+
+// SelectLastTweetsByUsersID returns the newest tweets across userIDs, newest first, up to limit.
+// When cursor is set only tweets older than the cursor tweet are returned, so pages line up
+// with the cached timeline list. Only visible tweets are returned: the others aren't delivered
+// to timelines.
+//
+// Each user is read with its own index scan (LATERAL) limited to `limit` rows, and the
+// outer query merges those short lists, instead of sorting every tweet of every followee.
+func (r Repository) SelectLastTweetsByUsersID(ctx context.Context, userIDs []string, cursor string, limit int) ([]domain.Tweet, error) {
 	if len(userIDs) == 0 {
 		return []domain.Tweet{}, nil
 	}
 
 	query := `
-		SELECT DISTINCT ON (user_id)
-		id, user_id, content, created_at
-		FROM tweets
-		WHERE user_id = ANY($1)
-		ORDER BY user_id, created_at DESC
+		SELECT t.id, t.user_id, t.content, t.created_at, t.edited_at, t.url_entities
+		FROM unnest($1::uuid[]) AS followee(user_id)
+		CROSS JOIN LATERAL (
+			SELECT id, user_id, content, created_at, edited_at, url_entities
+			FROM tweets
+			WHERE tweets.user_id = followee.user_id
+			  AND moderation_status = 'visible'
+			  AND ($2::uuid IS NULL OR (created_at, id) < (SELECT c.created_at, c.id FROM tweets AS c WHERE c.id = $2))
+			ORDER BY created_at DESC, id DESC
+			LIMIT $3
+		) AS t
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userIDs)
+	rows, err := r.db.QueryContext(ctx, query, userIDs, nullableCursor(cursor), limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	tweets := make([]domain.Tweet, 0, len(userIDs))
+	tweets := make([]domain.Tweet, 0, min(limit, maxPreallocatedRows))
 
 	for rows.Next() {
 		var tweet domain.Tweet
-		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt); err != nil {
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs)); err != nil {
 			return nil, err
 		}
 		tweets = append(tweets, tweet)
@@ -39,5 +58,17 @@ func (r Repository) SelectLastTweetsByUsersID(ctx context.Context, userIDs []str
 		return nil, err
 	}
 
+	if err = r.hydrateTweets(ctx, tweets); err != nil {
+		return nil, err
+	}
+
 	return tweets, nil
 }
+
+// nullableCursor maps an empty cursor to SQL NULL, meaning "start from the first row".
+func nullableCursor(cursor string) interface{} {
+	if cursor == "" {
+		return nil
+	}
+	return cursor
+}
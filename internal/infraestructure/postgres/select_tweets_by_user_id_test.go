@@ -0,0 +1,214 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectLastTweetsByUsersID(t *testing.T) {
+	ctx := context.Background()
+	user1 := uuid.NewString()
+	user2 := uuid.NewString()
+	userIDs := []string{user1, user2}
+	cursor := uuid.NewString()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// Two tweets from the same user come back in order: the fallback is not one tweet per user.
+	expectedTweets := []domain.Tweet{
+		{ID: uuid.NewString(), UserID: user1, Text: "newest", CreatedAt: now},
+		{ID: uuid.NewString(), UserID: user1, Text: "second", CreatedAt: now},
+		{ID: uuid.NewString(), UserID: user2, Text: "third https://a.example https://b.example", CreatedAt: now, URLs: []domain.URLEntity{
+			{URL: "https://a.example", Start: 6, End: 23},
+			{URL: "https://b.example", Start: 24, End: 41},
+		}},
+	}
+	storedURLs := `[{"url":"https://a.example","start":6,"end":23},{"url":"https://b.example","start":24,"end":41}]`
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT t.id, t.user_id, t.content, t.created_at, t.edited_at, t.url_entities
+		FROM unnest($1::uuid[]) AS followee(user_id)
+		CROSS JOIN LATERAL (
+			SELECT id, user_id, content, created_at, edited_at, url_entities
+			FROM tweets
+			WHERE tweets.user_id = followee.user_id
+			  AND moderation_status = 'visible'
+			  AND ($2::uuid IS NULL OR (created_at, id) < (SELECT c.created_at, c.id FROM tweets AS c WHERE c.id = $2))
+			ORDER BY created_at DESC, id DESC
+			LIMIT $3
+		) AS t
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT $3
+	`)
+
+	tweetRows := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "content", "created_at", "edited_at", "url_entities"})
+		for _, tweet := range expectedTweets {
+			var urls interface{}
+			if len(tweet.URLs) > 0 {
+				urls = []byte(storedURLs)
+			}
+			rows.AddRow(tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, nil, urls)
+		}
+		return rows
+	}
+
+	expectedMediaQuery := regexp.QuoteMeta(`
+		SELECT id, user_id, content_type, size_bytes, created_at, tweet_id
+		FROM media
+		WHERE tweet_id = ANY($1)
+		ORDER BY tweet_id, position
+	`)
+	tweetIDs := []string{expectedTweets[0].ID, expectedTweets[1].ID, expectedTweets[2].ID}
+	media := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      user1,
+		ContentType: "image/png",
+		Size:        2048,
+		CreatedAt:   now,
+		TweetID:     expectedTweets[1].ID,
+	}
+	mediaColumns := []string{"id", "user_id", "content_type", "size_bytes", "created_at", "tweet_id"}
+
+	expectedPreviewsQuery := regexp.QuoteMeta(`
+		SELECT url, title, description, image_url, fetched_at
+		FROM link_previews
+		WHERE url = ANY($1)
+	`)
+	urls := []string{"https://a.example", "https://b.example"}
+	previewColumns := []string{"url", "title", "description", "image_url", "fetched_at"}
+	preview := domain.LinkPreview{URL: "https://a.example", Title: "A", Description: "The A site", ImageURL: "https://a.example/a.png", FetchedAt: now}
+
+	// b.example was fetched but had nothing to preview: it is left without one.
+	hydratedTweets := make([]domain.Tweet, len(expectedTweets))
+	copy(hydratedTweets, expectedTweets)
+	hydratedTweets[1].Media = []domain.Media{media}
+	hydratedTweets[2].URLs = []domain.URLEntity{
+		{URL: "https://a.example", Start: 6, End: 23, Preview: &preview},
+		{URL: "https://b.example", Start: 24, End: 41},
+	}
+
+	testCases := []struct {
+		name           string
+		userIDs        []string
+		cursor         string
+		setupMock      func(mock sqlmock.Sqlmock)
+		expectedTweets []domain.Tweet
+		expectError    bool
+		errorContains  string
+	}{
+		{
+			name:    "Success - first page passes a NULL cursor",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, nil, 10).
+					WillReturnRows(tweetRows())
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs(tweetIDs).
+					WillReturnRows(sqlmock.NewRows(mediaColumns).
+						AddRow(media.ID, media.UserID, media.ContentType, media.Size, media.CreatedAt, media.TweetID))
+				mock.ExpectQuery(expectedPreviewsQuery).
+					WithArgs(urls).
+					WillReturnRows(sqlmock.NewRows(previewColumns).
+						AddRow(preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.FetchedAt).
+						AddRow("https://b.example", "", "", "", now))
+			},
+			expectedTweets: hydratedTweets,
+		},
+		{
+			name:    "Success - next page passes the cursor",
+			userIDs: userIDs,
+			cursor:  cursor,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, cursor, 10).
+					WillReturnRows(tweetRows())
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs(tweetIDs).
+					WillReturnRows(sqlmock.NewRows(mediaColumns))
+				mock.ExpectQuery(expectedPreviewsQuery).
+					WithArgs(urls).
+					WillReturnRows(sqlmock.NewRows(previewColumns))
+			},
+			expectedTweets: expectedTweets,
+		},
+		{
+			name:           "Success - no users skips the query",
+			userIDs:        []string{},
+			setupMock:      func(mock sqlmock.Sqlmock) {},
+			expectedTweets: []domain.Tweet{},
+		},
+		{
+			name:    "Failure - database error",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, nil, 10).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			expectError:   true,
+			errorContains: "database connection lost",
+		},
+		{
+			name:    "Failure - database error loading the media",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, nil, 10).
+					WillReturnRows(tweetRows())
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs(tweetIDs).
+					WillReturnError(errors.New("media query failed"))
+			},
+			expectError:   true,
+			errorContains: "media query failed",
+		},
+		{
+			name:    "Failure - database error loading the link previews",
+			userIDs: userIDs,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(userIDs, nil, 10).
+					WillReturnRows(tweetRows())
+				mock.ExpectQuery(expectedMediaQuery).
+					WithArgs(tweetIDs).
+					WillReturnRows(sqlmock.NewRows(mediaColumns))
+				mock.ExpectQuery(expectedPreviewsQuery).
+					WithArgs(urls).
+					WillReturnError(errors.New("previews query failed"))
+			},
+			expectError:   true,
+			errorContains: "previews query failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			tweets, err := repo.SelectLastTweetsByUsersID(ctx, tc.userIDs, tc.cursor, 10)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTweets, tweets)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
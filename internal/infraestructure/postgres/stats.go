@@ -0,0 +1,8 @@
+package postgres
+
+import "database/sql"
+
+// Stats returns the connection pool statistics of the database.
+func (r *Repository) Stats() sql.DBStats {
+	return r.db.Stats()
+}
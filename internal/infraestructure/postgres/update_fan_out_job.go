@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+)
+
+// UpdateFanOutJobCheckpoint moves the checkpoint of a job forward. Checkpoints follow the
+// follower ID order, so an older checkpoint written late never overwrites a newer one.
+func (r Repository) UpdateFanOutJobCheckpoint(ctx context.Context, tweetID, lastFollowerID string, processedCount int) error {
+	query := `
+		UPDATE fan_out_jobs
+		SET last_follower_id = $2, processed_count = $3, updated_at = NOW()
+		WHERE tweet_id = $1
+		  AND (last_follower_id IS NULL OR last_follower_id < $2)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, tweetID, lastFollowerID, processedCount)
+	return err
+}
+
+// FinishFanOutJob records the final status of a job run. lastError is stored as NULL when empty.
+func (r Repository) FinishFanOutJob(ctx context.Context, tweetID, status, lastError string) error {
+	query := `
+		UPDATE fan_out_jobs
+		SET status = $2, last_error = NULLIF($3, ''), updated_at = NOW()
+		WHERE tweet_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, tweetID, status, lastError)
+	return err
+}
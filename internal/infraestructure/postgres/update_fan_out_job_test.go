@@ -0,0 +1,97 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateFanOutJobCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	followerID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		UPDATE fan_out_jobs
+		SET last_follower_id = $2, processed_count = $3, updated_at = NOW()
+		WHERE tweet_id = $1
+		  AND (last_follower_id IS NULL OR last_follower_id < $2)
+	`)
+
+	t.Run("Success - moves the checkpoint", func(t *testing.T) {
+		// Arrange
+		repo, mock := setupRepoWithMock(t)
+		mock.ExpectExec(expectedQuery).
+			WithArgs(tweetID, followerID, 500).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		err := repo.UpdateFanOutJobCheckpoint(ctx, tweetID, followerID, 500)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - database error on exec", func(t *testing.T) {
+		// Arrange
+		repo, mock := setupRepoWithMock(t)
+		mock.ExpectExec(expectedQuery).
+			WithArgs(tweetID, followerID, 500).
+			WillReturnError(errors.New("database connection lost"))
+
+		// Act
+		err := repo.UpdateFanOutJobCheckpoint(ctx, tweetID, followerID, 500)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database connection lost")
+	})
+}
+
+func TestFinishFanOutJob(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+
+	expectedQuery := regexp.QuoteMeta(`
+		UPDATE fan_out_jobs
+		SET status = $2, last_error = NULLIF($3, ''), updated_at = NOW()
+		WHERE tweet_id = $1
+	`)
+
+	t.Run("Success - records the status", func(t *testing.T) {
+		// Arrange
+		repo, mock := setupRepoWithMock(t)
+		mock.ExpectExec(expectedQuery).
+			WithArgs(tweetID, "failed", "redis is down").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		err := repo.FinishFanOutJob(ctx, tweetID, "failed", "redis is down")
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - database error on exec", func(t *testing.T) {
+		// Arrange
+		repo, mock := setupRepoWithMock(t)
+		mock.ExpectExec(expectedQuery).
+			WithArgs(tweetID, "completed", "").
+			WillReturnError(errors.New("database connection lost"))
+
+		// Act
+		err := repo.FinishFanOutJob(ctx, tweetID, "completed", "")
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database connection lost")
+	})
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// UpdateTweetText replaces the text of tweetID with text and its URLs, and stores the previous text as a
+// revision, in a single statement. The tweet is only edited when authorID wrote it and it was
+// created at or after editableSince; otherwise nil is returned and nothing changes.
+// The row is locked, so concurrent edits are applied one after the other and every replaced
+// text ends up in the history.
+func (r Repository) UpdateTweetText(ctx context.Context, tweetID, authorID, text string, urls []domain.URLEntity, editedAt, editableSince time.Time) (*domain.Tweet, error) {
+	query := `
+		WITH prior AS (
+			SELECT id, content, COALESCE(edited_at, created_at) AS published_at
+			FROM tweets
+			WHERE id = $1 AND user_id = $2 AND created_at >= $5
+			FOR UPDATE
+		), revision AS (
+			INSERT INTO tweet_revisions (tweet_id, content, created_at, replaced_at)
+			SELECT id, content, published_at, $4 FROM prior
+		)
+		UPDATE tweets
+		SET content = $3, edited_at = $4, url_entities = $6
+		FROM prior
+		WHERE tweets.id = prior.id
+		RETURNING tweets.id, tweets.user_id, tweets.content, tweets.created_at, tweets.edited_at, tweets.url_entities, tweets.moderation_status
+	`
+
+	row := r.db.QueryRowContext(ctx, query, tweetID, authorID, text, editedAt, editableSince, urlEntities(urls))
+
+	var tweet domain.Tweet
+	err := row.Scan(&tweet.ID, &tweet.UserID, &tweet.Text, &tweet.CreatedAt, &tweet.EditedAt, (*urlEntities)(&tweet.URLs), &tweet.Moderation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error updating tweet text: %w", err)
+	}
+
+	return &tweet, nil
+}
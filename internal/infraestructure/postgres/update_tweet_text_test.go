@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTweetText(t *testing.T) {
+	ctx := context.Background()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	editedAt := time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC)
+	editableSince := editedAt.Add(-30 * time.Minute)
+	text := "fixed https://go.dev"
+	urls := []domain.URLEntity{{URL: "https://go.dev", Start: 6, End: 20}}
+	storedURLs := `[{"url":"https://go.dev","start":6,"end":20}]`
+
+	expectedQuery := regexp.QuoteMeta(`
+		WITH prior AS (
+			SELECT id, content, COALESCE(edited_at, created_at) AS published_at
+			FROM tweets
+			WHERE id = $1 AND user_id = $2 AND created_at >= $5
+			FOR UPDATE
+		), revision AS (
+			INSERT INTO tweet_revisions (tweet_id, content, created_at, replaced_at)
+			SELECT id, content, published_at, $4 FROM prior
+		)
+		UPDATE tweets
+		SET content = $3, edited_at = $4, url_entities = $6
+		FROM prior
+		WHERE tweets.id = prior.id
+		RETURNING tweets.id, tweets.user_id, tweets.content, tweets.created_at, tweets.edited_at, tweets.url_entities, tweets.moderation_status
+	`)
+	columns := []string{"id", "user_id", "content", "created_at", "edited_at", "url_entities", "moderation_status"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		expectedTweet *domain.Tweet
+		errorContains string
+	}{
+		{
+			name: "Success - edits the tweet",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, authorID, text, editedAt, editableSince, storedURLs).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(tweetID, authorID, text, createdAt, editedAt, []byte(storedURLs), "limited"))
+			},
+			expectedTweet: &domain.Tweet{ID: tweetID, UserID: authorID, Text: text, CreatedAt: createdAt, EditedAt: &editedAt, URLs: urls, Moderation: domain.TweetLimited},
+		},
+		{
+			name: "Success - no editable tweet returns nil",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, authorID, text, editedAt, editableSince, storedURLs).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedTweet: nil,
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(expectedQuery).
+					WithArgs(tweetID, authorID, text, editedAt, editableSince, storedURLs).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "error updating tweet text: database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			tweet, err := repo.UpdateTweetText(ctx, tweetID, authorID, text, urls, editedAt, editableSince)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedTweet, tweet)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
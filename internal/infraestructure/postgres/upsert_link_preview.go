@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// UpsertLinkPreview stores the preview of preview.URL, replacing the one of a previous fetch.
+func (r Repository) UpsertLinkPreview(ctx context.Context, preview domain.LinkPreview) error {
+	query := `
+		INSERT INTO link_previews (url, title, description, image_url, fetched_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (url) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description,
+		    image_url = EXCLUDED.image_url, fetched_at = EXCLUDED.fetched_at
+	`
+	_, err := r.db.ExecContext(ctx, query, preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.FetchedAt)
+
+	return err
+}
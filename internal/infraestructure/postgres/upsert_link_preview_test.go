@@ -0,0 +1,77 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertLinkPreview(t *testing.T) {
+	ctx := context.Background()
+	preview := domain.LinkPreview{
+		URL:         "https://go.dev",
+		Title:       "The Go Programming Language",
+		Description: "Build simple, secure, scalable systems",
+		ImageURL:    "https://go.dev/images/go-logo.png",
+		FetchedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	expectedQuery := regexp.QuoteMeta(`
+		INSERT INTO link_previews (url, title, description, image_url, fetched_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (url) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description,
+		    image_url = EXCLUDED.image_url, fetched_at = EXCLUDED.fetched_at
+	`)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "Success - stores the preview",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.FetchedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "Failure - database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(expectedQuery).
+					WithArgs(preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.FetchedAt).
+					WillReturnError(errors.New("database connection lost"))
+			},
+			errorContains: "database connection lost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := setupRepoWithMock(t)
+			tc.setupMock(mock)
+
+			// Act
+			err := repo.UpsertLinkPreview(ctx, preview)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
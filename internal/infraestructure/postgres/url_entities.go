@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// storedURLEntity is a domain.URLEntity as stored in tweets.url_entities: the preview lives
+// in link_previews, shared by every tweet linking to the URL.
+type storedURLEntity struct {
+	URL   string `json:"url"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// urlEntities maps the URL entities of a tweet to the JSONB column tweets.url_entities,
+// NULL when the tweet has no URL.
+type urlEntities []domain.URLEntity
+
+func (u urlEntities) Value() (driver.Value, error) {
+	if len(u) == 0 {
+		return nil, nil
+	}
+	stored := make([]storedURLEntity, len(u))
+	for i, entity := range u {
+		stored[i] = storedURLEntity{URL: entity.URL, Start: entity.Start, End: entity.End}
+	}
+	encoded, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (u *urlEntities) Scan(src interface{}) error {
+	var encoded []byte
+	switch value := src.(type) {
+	case nil:
+		*u = nil
+		return nil
+	case string:
+		encoded = []byte(value)
+	case []byte:
+		encoded = value
+	default:
+		return fmt.Errorf("cannot scan %T into url entities", src)
+	}
+
+	var stored []storedURLEntity
+	if err := json.Unmarshal(encoded, &stored); err != nil {
+		return fmt.Errorf("error decoding url entities: %w", err)
+	}
+	entities := make(urlEntities, len(stored))
+	for i, entity := range stored {
+		entities[i] = domain.URLEntity{URL: entity.URL, Start: entity.Start, End: entity.End}
+	}
+	*u = entities
+	return nil
+}
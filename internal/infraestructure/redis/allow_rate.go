@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// allowRateScript is the GCRA of the ratelimit package run inside Redis, so concurrent requests
+// of every instance are counted atomically against the same state. KEYS[1] holds the theoretical
+// arrival time in microseconds; ARGV[1] is the emission interval and ARGV[2] the window, in
+// microseconds. The clock is the Redis one, so the instances' clocks don't need to agree.
+// Returns {allowed, remaining, reset, retry after}, durations in microseconds.
+var allowRateScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local interval = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - window
+if now < allow_at then
+	return {0, 0, tat - now, allow_at - now}
+end
+
+redis.call('SET', KEYS[1], new_tat, 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / interval), new_tat - now, 0}
+`)
+
+// AllowRate counts a request of key against limit.
+func (r *Repository) AllowRate(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	interval := limit.Window / time.Duration(limit.Requests)
+
+	reply, err := allowRateScript.Run(ctx, r.Client, []string{key}, interval.Microseconds(), limit.Window.Microseconds()).Int64Slice()
+	if err != nil {
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to count request of key %s in redis: %w", key, err)
+	}
+
+	return domain.RateLimitDecision{
+		Allowed:    reply[0] == 1,
+		Remaining:  int(reply[1]),
+		Reset:      time.Duration(reply[2]) * time.Microsecond,
+		RetryAfter: time.Duration(reply[3]) * time.Microsecond,
+	}, nil
+}
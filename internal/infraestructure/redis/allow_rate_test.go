@@ -0,0 +1,87 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowRate(t *testing.T) {
+	ctx := context.Background()
+	const key = "ratelimit:/api/v1/tweet:user:1"
+	limit := domain.RateLimit{Requests: 3, Window: 3 * time.Second}
+
+	testCases := []struct {
+		name          string
+		requests      []time.Duration // offsets from the start
+		setup         func(mr *miniredis.Miniredis)
+		expected      domain.RateLimitDecision
+		expectedTTL   time.Duration
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:        "Success - First request",
+			requests:    []time.Duration{0},
+			expected:    domain.RateLimitDecision{Allowed: true, Remaining: 2, Reset: time.Second},
+			expectedTTL: time.Second,
+		},
+		{
+			name:        "Success - Over the limit",
+			requests:    []time.Duration{0, 0, 0, 0},
+			expected:    domain.RateLimitDecision{Allowed: false, Reset: 3 * time.Second, RetryAfter: time.Second},
+			expectedTTL: 3 * time.Second,
+		},
+		{
+			name:        "Success - Tokens come back one interval at a time",
+			requests:    []time.Duration{0, 0, 0, 2500 * time.Millisecond},
+			expected:    domain.RateLimitDecision{Allowed: true, Remaining: 1, Reset: 1500 * time.Millisecond},
+			expectedTTL: 1500 * time.Millisecond,
+		},
+		{
+			name:     "Failure - connection error",
+			requests: []time.Duration{0},
+			setup: func(mr *miniredis.Miniredis) {
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to count request of key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+			start := time.Now()
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			var decision domain.RateLimitDecision
+			var err error
+			for _, offset := range tc.requests {
+				mockRedis.SetTime(start.Add(offset))
+				decision, err = repo.AllowRate(ctx, key, limit)
+			}
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, decision)
+			assert.Equal(t, tc.expectedTTL, mockRedis.TTL(key))
+		})
+	}
+}
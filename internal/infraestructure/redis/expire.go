@@ -0,0 +1,16 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Expire sets the time to live of key. It's a no-op when the key does not exist.
+func (r *Repository) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	err := r.Client.Expire(ctx, key, expiration).Err()
+	if err != nil {
+		return fmt.Errorf("failed to EXPIRE key %s in redis: %w", key, err)
+	}
+	return nil
+}
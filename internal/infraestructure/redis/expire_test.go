@@ -0,0 +1,77 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpire(t *testing.T) {
+	ctx := context.Background()
+	timelineKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+
+	testCases := []struct {
+		name          string
+		exists        bool
+		setup         func(mr *miniredis.Miniredis)
+		expectedTTL   time.Duration
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:        "Success - sets the TTL",
+			exists:      true,
+			expectedTTL: time.Hour,
+		},
+		{
+			name:        "Success - key does not exist",
+			exists:      false,
+			expectedTTL: 0,
+		},
+		{
+			name:   "Failure - connection error",
+			exists: true,
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to EXPIRE",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+
+			if tc.exists {
+				require.NoError(t, repo.Client.LPush(ctx, timelineKey, uuid.NewString()).Err())
+			}
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			err := repo.Expire(ctx, timelineKey, time.Hour)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTTL, mockRedis.TTL(timelineKey))
+			assert.Equal(t, tc.exists, mockRedis.Exists(timelineKey))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LPos returns the index of the first occurrence of value in the list stored at key,
+// or -1 when the value (or the key) does not exist.
+func (r *Repository) LPos(ctx context.Context, key string, value string) (int64, error) {
+	index, err := r.Client.LPos(ctx, key, value, redis.LPosArgs{}).Result()
+	if errors.Is(err, redis.Nil) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to LPOS from key %s in redis: %w", key, err)
+	}
+	return index, nil
+}
@@ -0,0 +1,92 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLPos(t *testing.T) {
+	ctx := context.Background()
+	timelineKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+	tweet3 := uuid.NewString()
+
+	testCases := []struct {
+		name          string
+		key           string
+		initialData   []interface{}
+		value         string
+		setup         func(mr *miniredis.Miniredis)
+		expectedIndex int64
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:          "Success - finds the value",
+			key:           timelineKey,
+			initialData:   []interface{}{tweet1, tweet2, tweet3},
+			value:         tweet2,
+			expectedIndex: 1,
+		},
+		{
+			name:          "Success - value not in list",
+			key:           timelineKey,
+			initialData:   []interface{}{tweet1},
+			value:         tweet2,
+			expectedIndex: -1,
+		},
+		{
+			name:          "Success - key does not exist",
+			key:           "non-existent-key",
+			value:         tweet1,
+			expectedIndex: -1,
+		},
+		{
+			name:  "Failure - connection error",
+			key:   timelineKey,
+			value: tweet1,
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to LPOS",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+
+			// Push in reverse so the list reads in the same order as initialData.
+			for i := len(tc.initialData) - 1; i >= 0; i-- {
+				require.NoError(t, repo.Client.LPush(ctx, tc.key, tc.initialData[i]).Err())
+			}
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			index, err := repo.LPos(ctx, tc.key, tc.value)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedIndex, index)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LPushTrimPipeline pushes value to the head of every list in keys using a single pipeline,
+// so a whole chunk of timelines is updated in one round-trip. Missing lists are not created,
+// lists are trimmed to maxLen elements, and the expiration is only set on lists without a TTL
+// (EXPIRE NX, Redis >= 7).
+// It returns how many lists existed and were updated, and the keys whose push failed so the
+// caller can retry them. The returned error is the first failure, if any.
+func (r *Repository) LPushTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error) {
+	if len(keys) == 0 {
+		return 0, nil, nil
+	}
+
+	pipe := r.Client.Pipeline()
+	pushes := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		pushes[i] = pipe.LPushX(ctx, key, value)
+		pipe.LTrim(ctx, key, 0, maxLen-1)
+		if expiration > 0 {
+			pipe.ExpireNX(ctx, key, expiration)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+
+	return pushPipelineResult(keys, err, func(i int) (int64, error) { return pushes[i].Result() })
+}
+
+// lpushUniqueTrimScript works like the LPushTrimPipeline commands, but skips lists that already
+// contain ARGV[3]: KEYS[1] is the list, ARGV[1] the max length and ARGV[2] the TTL in milliseconds.
+// Returns 0 when the list does not exist.
+var lpushUniqueTrimScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+if redis.call('LPOS', KEYS[1], ARGV[3]) then
+	return 1
+end
+
+redis.call('LPUSH', KEYS[1], ARGV[3])
+redis.call('LTRIM', KEYS[1], 0, tonumber(ARGV[1]) - 1)
+if tonumber(ARGV[2]) > 0 and redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// LPushUniqueTrimPipeline is LPushTrimPipeline for values that may have been pushed before,
+// e.g. when an interrupted fan-out is resumed: lists already containing value are left as is,
+// so the value is stored at most once per list. It's slower, as every list is scanned.
+func (r *Repository) LPushUniqueTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error) {
+	if len(keys) == 0 {
+		return 0, nil, nil
+	}
+
+	// EVALSHA can't fall back to EVAL inside a pipeline, so the script is loaded first.
+	if err := lpushUniqueTrimScript.Load(ctx, r.Client).Err(); err != nil {
+		return 0, keys, fmt.Errorf("failed to LPUSH to %d of %d keys in redis: %w", len(keys), len(keys), err)
+	}
+
+	pipe := r.Client.Pipeline()
+	pushes := make([]*redis.Cmd, len(keys))
+	for i, key := range keys {
+		pushes[i] = lpushUniqueTrimScript.EvalSha(ctx, pipe, []string{key}, maxLen, expiration.Milliseconds(), value)
+	}
+	_, err := pipe.Exec(ctx)
+
+	return pushPipelineResult(keys, err, func(i int) (int64, error) { return pushes[i].Int64() })
+}
+
+// pushPipelineResult counts the updated lists of a push pipeline and collects the keys whose
+// push failed. result returns the reply of the push for keys[i]: > 0 when the list exists.
+func pushPipelineResult(keys []string, err error, result func(i int) (int64, error)) (int, []string, error) {
+	var replyErr redis.Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// Network or context error: the replies are unknown, so every push is reported as failed.
+		// Retrying may push a value twice, readers already skip repeated IDs.
+		return 0, keys, fmt.Errorf("failed to LPUSH to %d of %d keys in redis: %w", len(keys), len(keys), err)
+	}
+
+	var cached int
+	var failed []string
+	for i := range keys {
+		length, pushErr := result(i)
+		if pushErr != nil {
+			failed = append(failed, keys[i])
+			continue
+		}
+		if length > 0 {
+			cached++
+		}
+	}
+
+	if err != nil {
+		return cached, failed, fmt.Errorf("failed to LPUSH to %d of %d keys in redis: %w", len(failed), len(keys), err)
+	}
+	return cached, nil, nil
+}
@@ -0,0 +1,179 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLPushTrimPipeline(t *testing.T) {
+	ctx := context.Background()
+	cachedKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	missingKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+	tweet3 := uuid.NewString()
+
+	testCases := []struct {
+		name           string
+		initialData    []interface{}
+		initialTTL     time.Duration
+		keys           []string
+		maxLen         int64
+		setup          func(mr *miniredis.Miniredis)
+		expectedCached int
+		expectedList   []string
+		expectedTTL    time.Duration
+		expectError    bool
+		errorContains  string
+	}{
+		{
+			name:           "Success - pushes and sets the TTL when the list has none",
+			initialData:    []interface{}{tweet1},
+			keys:           []string{cachedKey},
+			maxLen:         10,
+			expectedCached: 1,
+			expectedList:   []string{tweet3, tweet1},
+			expectedTTL:    time.Hour,
+		},
+		{
+			name:           "Success - trims the list to maxLen",
+			initialData:    []interface{}{tweet1, tweet2},
+			keys:           []string{cachedKey},
+			maxLen:         2,
+			expectedCached: 1,
+			expectedList:   []string{tweet3, tweet2},
+			expectedTTL:    time.Hour,
+		},
+		{
+			name:           "Success - keeps an existing TTL",
+			initialData:    []interface{}{tweet1},
+			initialTTL:     time.Minute,
+			keys:           []string{cachedKey},
+			maxLen:         10,
+			expectedCached: 1,
+			expectedList:   []string{tweet3, tweet1},
+			expectedTTL:    time.Minute,
+		},
+		{
+			name:           "Success - missing lists are not created",
+			initialData:    []interface{}{tweet1},
+			keys:           []string{missingKey, cachedKey},
+			maxLen:         10,
+			expectedCached: 1,
+			expectedList:   []string{tweet3, tweet1},
+			expectedTTL:    time.Hour,
+		},
+		{
+			name:   "Failure - connection error",
+			keys:   []string{cachedKey, missingKey},
+			maxLen: 10,
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to LPUSH to 2 of 2 keys",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+
+			for _, value := range tc.initialData {
+				require.NoError(t, repo.Client.LPush(ctx, cachedKey, value).Err())
+			}
+			if tc.initialTTL > 0 {
+				mockRedis.SetTTL(cachedKey, tc.initialTTL)
+			}
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			cached, failed, err := repo.LPushTrimPipeline(ctx, tc.keys, tc.maxLen, time.Hour, tweet3)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				assert.Equal(t, tc.keys, failed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+			assert.Equal(t, tc.expectedCached, cached)
+
+			list, err := mockRedis.List(cachedKey)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedList, list)
+			assert.Equal(t, tc.expectedTTL, mockRedis.TTL(cachedKey))
+			assert.False(t, mockRedis.Exists(missingKey))
+		})
+	}
+}
+
+func TestLPushUniqueTrimPipeline(t *testing.T) {
+	ctx := context.Background()
+	deliveredKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	pendingKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	missingKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	keys := []string{deliveredKey, pendingKey, missingKey}
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+
+	t.Run("Success - pushes only to lists without the value", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		t.Cleanup(mockRedis.Close)
+
+		// The value already reached the first list, then a newer tweet was pushed on top.
+		require.NoError(t, repo.Client.LPush(ctx, deliveredKey, tweet2).Err())
+		require.NoError(t, repo.Client.LPush(ctx, deliveredKey, tweet1).Err())
+		require.NoError(t, repo.Client.LPush(ctx, pendingKey, tweet1).Err())
+
+		// Act
+		cached, failed, err := repo.LPushUniqueTrimPipeline(ctx, keys, 10, time.Hour, tweet2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, failed)
+		assert.Equal(t, 2, cached)
+
+		delivered, err := mockRedis.List(deliveredKey)
+		require.NoError(t, err)
+		assert.Equal(t, []string{tweet1, tweet2}, delivered)
+
+		pending, err := mockRedis.List(pendingKey)
+		require.NoError(t, err)
+		assert.Equal(t, []string{tweet2, tweet1}, pending)
+		assert.Equal(t, time.Hour, mockRedis.TTL(pendingKey))
+
+		assert.False(t, mockRedis.Exists(missingKey))
+	})
+
+	t.Run("Failure - connection error", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		// Simulate a connection failure by closing the server.
+		mockRedis.Close()
+
+		// Act
+		_, failed, err := repo.LPushUniqueTrimPipeline(ctx, keys, 10, time.Hour, tweet2)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to LPUSH to 3 of 3 keys")
+		assert.Equal(t, keys, failed)
+	})
+}
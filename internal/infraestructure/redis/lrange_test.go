@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/redis"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,7 +32,7 @@ func setupTestRepo(t *testing.T) (*redis.Repository, *miniredis.Miniredis) {
 	}
 
 	// Act
-	repo, err := redis.NewRepository(cfg)
+	repo, err := redis.NewRepository(cfg, logging.Discard())
 	assert.NoError(t, err)
 
 	return repo, mockRedis
@@ -58,11 +59,11 @@ func TestLRange(t *testing.T) {
 
 		// Pre-populate the mock redis with data for the test.
 		// LPush adds to the head, so we push in reverse order.
-		err = repo.LPush(context.Background(), listKey, tweet1.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet1.String()).Err()
 		assert.NoError(t, err)
-		err = repo.LPush(context.Background(), listKey, tweet2.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet2.String()).Err()
 		assert.NoError(t, err)
-		err = repo.LPush(context.Background(), listKey, tweet3.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet3.String()).Err()
 		assert.NoError(t, err)
 
 		// Act
@@ -91,11 +92,11 @@ func TestLRange(t *testing.T) {
 
 		// Pre-populate the mock redis with data for the test.
 		// LPush adds to the head, so we push in reverse order.
-		err = repo.LPush(context.Background(), listKey, tweet1.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet1.String()).Err()
 		assert.NoError(t, err)
-		err = repo.LPush(context.Background(), listKey, tweet2.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet2.String()).Err()
 		assert.NoError(t, err)
-		err = repo.LPush(context.Background(), listKey, tweet3.String())
+		err = repo.Client.LPush(context.Background(), listKey, tweet3.String()).Err()
 		assert.NoError(t, err)
 
 		// Act
@@ -207,7 +208,7 @@ func TestLRange2(t *testing.T) {
 			if len(tc.initialData) > 0 {
 				// LPush adds to the head, so we push in reverse order of how we want to read it.
 				for i := 0; i < len(tc.initialData); i++ {
-					err := repo.LPush(ctx, tc.listKey, tc.initialData[i])
+					err := repo.Client.LPush(ctx, tc.listKey, tc.initialData[i]).Err()
 					require.NoError(t, err)
 				}
 			}
@@ -0,0 +1,16 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// LRem removes occurrences of value from the list stored at key.
+// count > 0 removes from head to tail, count < 0 from tail to head and count = 0 removes all.
+func (r *Repository) LRem(ctx context.Context, key string, count int64, value interface{}) error {
+	err := r.Client.LRem(ctx, key, count, value).Err()
+	if err != nil {
+		return fmt.Errorf("failed to LREM from key %s in redis: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRem(t *testing.T) {
+	ctx := context.Background()
+	timelineKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+
+	testCases := []struct {
+		name              string
+		initialData       []interface{}
+		count             int64
+		value             string
+		setup             func(mr *miniredis.Miniredis)
+		expectedListState []string
+		expectError       bool
+		errorContains     string
+	}{
+		{
+			name:              "Success - removes every occurrence",
+			initialData:       []interface{}{tweet1, tweet2, tweet1},
+			count:             0,
+			value:             tweet1,
+			expectedListState: []string{tweet2},
+		},
+		{
+			name:              "Success - negative count removes from the tail",
+			initialData:       []interface{}{tweet1, tweet2, tweet1},
+			count:             -1,
+			value:             tweet1,
+			expectedListState: []string{tweet1, tweet2},
+		},
+		{
+			name:              "Success - missing value leaves the list untouched",
+			initialData:       []interface{}{tweet2},
+			count:             0,
+			value:             tweet1,
+			expectedListState: []string{tweet2},
+		},
+		{
+			name:  "Failure - connection error",
+			value: tweet1,
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to LREM",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+
+			// Push in reverse so the list reads in the same order as initialData.
+			for i := len(tc.initialData) - 1; i >= 0; i-- {
+				require.NoError(t, repo.Client.LPush(ctx, timelineKey, tc.initialData[i]).Err())
+			}
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			err := repo.LRem(ctx, timelineKey, tc.count, tc.value)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+
+			actualListState, err := repo.LRange(ctx, timelineKey, 0, -1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedListState, actualListState)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ping checks that Redis is reachable.
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := r.Client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to PING redis: %w", err)
+	}
+	return nil
+}
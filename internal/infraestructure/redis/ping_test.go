@@ -0,0 +1,54 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setup         func(mr *miniredis.Miniredis)
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "Success - redis answers",
+		},
+		{
+			name: "Failure - connection error",
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to PING redis",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			// Act
+			err := repo.Ping(context.Background())
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.errorContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package redis
+
+import "github.com/redis/go-redis/v9"
+
+// PoolStats returns the connection pool statistics of the Redis client.
+func (r *Repository) PoolStats() *redis.PoolStats {
+	return r.Client.PoolStats()
+}
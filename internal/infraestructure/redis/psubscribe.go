@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// PSubscribe subscribes to every channel matching pattern and calls handler for each
+// received message. It blocks until ctx is cancelled or the subscription is closed.
+func (r *Repository) PSubscribe(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+	pubsub := r.Client.PSubscribe(ctx, pattern)
+	defer pubsub.Close()
+
+	// Wait for the subscription confirmation so connection errors surface to the caller.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to PSUBSCRIBE to pattern %s in redis: %w", pattern, err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handler(msg.Channel, msg.Payload)
+		}
+	}
+}
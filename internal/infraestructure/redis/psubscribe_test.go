@@ -0,0 +1,71 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pubSubMessage struct {
+	channel string
+	payload string
+}
+
+func TestPSubscribe(t *testing.T) {
+	t.Run("Success - receives messages matching the pattern", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		t.Cleanup(mockRedis.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		received := make(chan pubSubMessage, 1)
+		done := make(chan error, 1)
+
+		go func() {
+			done <- repo.PSubscribe(ctx, "events:*", func(channel, payload string) {
+				received <- pubSubMessage{channel: channel, payload: payload}
+			})
+		}()
+
+		// Wait until the pattern subscription is registered before publishing.
+		require.Eventually(t, func() bool { return mockRedis.PubSubNumPat() == 1 }, time.Second, 10*time.Millisecond)
+
+		// Act
+		require.NoError(t, repo.Client.Publish(context.Background(), "events:timeline:user", "hello").Err())
+		require.NoError(t, repo.Client.Publish(context.Background(), "other:channel", "ignored").Err())
+
+		// Assert
+		select {
+		case msg := <-received:
+			assert.Equal(t, pubSubMessage{channel: "events:timeline:user", payload: "hello"}, msg)
+		case <-time.After(time.Second):
+			t.Fatal("expected a message on the subscribed pattern")
+		}
+
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("PSubscribe did not return after the context was cancelled")
+		}
+		assert.Empty(t, received, "messages outside the pattern must not be delivered")
+	})
+
+	t.Run("Failure - connection error", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		// Close the server immediately to simulate a connection failure.
+		mockRedis.Close()
+
+		// Act
+		err := repo.PSubscribe(context.Background(), "events:*", func(channel, payload string) {})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to PSUBSCRIBE")
+	})
+}
@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// PublishPipeline sends the same message to every channel using a single pipeline.
+func (r *Repository) PublishPipeline(ctx context.Context, channels []string, message interface{}) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	pipe := r.Client.Pipeline()
+	for _, channel := range channels {
+		pipe.Publish(ctx, channel, message)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to PUBLISH to %d channels in redis: %w", len(channels), err)
+	}
+	return nil
+}
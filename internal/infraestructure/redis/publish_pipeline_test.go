@@ -0,0 +1,53 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishPipeline(t *testing.T) {
+	ctx := context.Background()
+	channels := []string{"events:timeline:user1", "events:timeline:user2"}
+
+	t.Run("Success - publishes to every channel", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		t.Cleanup(mockRedis.Close)
+
+		// Act
+		err := repo.PublishPipeline(ctx, channels, `{"type":"tweet_created"}`)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Success - no channels", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		// No command is sent, so a closed server doesn't matter.
+		mockRedis.Close()
+
+		// Act
+		err := repo.PublishPipeline(ctx, nil, "payload")
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure - connection error", func(t *testing.T) {
+		// Arrange
+		repo, mockRedis := setupTestRepo(t)
+		// Close the server immediately to simulate a connection failure.
+		mockRedis.Close()
+
+		// Act
+		err := repo.PublishPipeline(ctx, channels, "payload")
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to PUBLISH")
+	})
+}
@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rebuildListScript replaces a list with ARGV[3..] (kept in order), trims it to its first
+// ARGV[2] entries and sets its TTL to ARGV[1] milliseconds. Entries pushed to the old list
+// that are not part of the new contents, e.g. by a fan-out racing the rebuild, are kept at
+// the head, so the trim drops the oldest entries.
+var rebuildListScript = redis.NewScript(`
+local existing = redis.call('LRANGE', KEYS[1], 0, -1)
+redis.call('DEL', KEYS[1])
+
+local seen = {}
+for i = 3, #ARGV do
+	seen[ARGV[i]] = true
+	redis.call('RPUSH', KEYS[1], ARGV[i])
+end
+
+for i = #existing, 1, -1 do
+	if not seen[existing[i]] then
+		seen[existing[i]] = true
+		redis.call('LPUSH', KEYS[1], existing[i])
+	end
+end
+
+redis.call('LTRIM', KEYS[1], 0, tonumber(ARGV[2]) - 1)
+
+if tonumber(ARGV[1]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return redis.call('LLEN', KEYS[1])
+`)
+
+// RebuildList atomically replaces the list stored at key with values, capped to maxLen
+// entries, and sets its expiration. A zero expiration keeps the key without TTL.
+func (r *Repository) RebuildList(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(values)+2)
+	args = append(args, expiration.Milliseconds(), maxLen)
+	for _, value := range values {
+		args = append(args, value)
+	}
+
+	if err := rebuildListScript.Run(ctx, r.Client, []string{key}, args...).Err(); err != nil {
+		return fmt.Errorf("failed to rebuild list %s in redis: %w", key, err)
+	}
+	return nil
+}
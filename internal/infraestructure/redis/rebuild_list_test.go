@@ -0,0 +1,125 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildList(t *testing.T) {
+	ctx := context.Background()
+	timelineKey := fmt.Sprintf("timeline:%s", uuid.NewString())
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+	tweet3 := uuid.NewString()
+
+	testCases := []struct {
+		name              string
+		initialData       []interface{}
+		values            []string
+		maxLen            int64
+		expiration        time.Duration
+		setup             func(mr *miniredis.Miniredis)
+		expectedListState []string
+		expectedTTL       time.Duration
+		expectError       bool
+		errorContains     string
+	}{
+		{
+			name:              "Success - builds a new list in order with TTL",
+			values:            []string{tweet3, tweet2, tweet1},
+			expiration:        time.Hour,
+			expectedListState: []string{tweet3, tweet2, tweet1},
+			expectedTTL:       time.Hour,
+		},
+		{
+			name:              "Success - keeps entries pushed concurrently at the head",
+			initialData:       []interface{}{tweet3},
+			values:            []string{tweet2, tweet1},
+			expiration:        time.Hour,
+			expectedListState: []string{tweet3, tweet2, tweet1},
+			expectedTTL:       time.Hour,
+		},
+		{
+			name:              "Success - does not duplicate entries already in the new list",
+			initialData:       []interface{}{tweet2, tweet3},
+			values:            []string{tweet3, tweet2, tweet1},
+			expiration:        time.Hour,
+			expectedListState: []string{tweet3, tweet2, tweet1},
+			expectedTTL:       time.Hour,
+		},
+		{
+			name:              "Success - trims the list to maxLen, dropping the oldest entries",
+			initialData:       []interface{}{tweet3},
+			values:            []string{tweet2, tweet1},
+			maxLen:            2,
+			expiration:        time.Hour,
+			expectedListState: []string{tweet3, tweet2},
+			expectedTTL:       time.Hour,
+		},
+		{
+			name:              "Success - zero expiration keeps the key without TTL",
+			values:            []string{tweet1},
+			expectedListState: []string{tweet1},
+			expectedTTL:       0,
+		},
+		{
+			name:              "Success - empty values is a no-op",
+			values:            []string{},
+			expectedListState: []string{},
+		},
+		{
+			name:   "Failure - connection error",
+			values: []string{tweet1},
+			setup: func(mr *miniredis.Miniredis) {
+				// Simulate a connection failure by closing the server.
+				mr.Close()
+			},
+			expectError:   true,
+			errorContains: "failed to rebuild list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo, mockRedis := setupTestRepo(t)
+			t.Cleanup(mockRedis.Close)
+
+			if len(tc.initialData) > 0 {
+				require.NoError(t, repo.Client.LPush(ctx, timelineKey, tc.initialData...).Err())
+			}
+
+			if tc.setup != nil {
+				tc.setup(mockRedis)
+			}
+
+			maxLen := tc.maxLen
+			if maxLen == 0 {
+				maxLen = 100
+			}
+
+			// Act
+			err := repo.RebuildList(ctx, timelineKey, tc.values, maxLen, tc.expiration)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+
+			actualListState, err := repo.LRange(ctx, timelineKey, 0, -1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedListState, actualListState)
+			assert.Equal(t, tc.expectedTTL, mockRedis.TTL(timelineKey))
+		})
+	}
+}
@@ -3,19 +3,21 @@ package redis
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 )
 
 type Repository struct {
 	Client *redis.Client
+	logger *slog.Logger
 }
 
 // NewRepository creates and configures a new repository with a Redis connection.
-func NewRepository(cfg config.Config) (*Repository, error) {
+func NewRepository(cfg config.Config, logger *slog.Logger) (*Repository, error) {
 	// 1. Construct the connection address from your config.
 	addr := fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port)
 
@@ -27,6 +29,11 @@ func NewRepository(cfg config.Config) (*Repository, error) {
 		DB:       cfg.Redis.DB, // Default DB is 0
 	})
 
+	// Every command and pipeline is traced as a child span of the caller's context.
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis client: %w", err)
+	}
+
 	// 3. Verify the connection is alive.
 	// This is a crucial health check to ensure the application starts correctly.
 	// We use a short timeout for the initial connection test.
@@ -37,17 +44,18 @@ func NewRepository(cfg config.Config) (*Repository, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
-	log.Println("Successfully connected to Redis.")
+	logger.Info("successfully connected to Redis")
 
 	// 4. Return the repository with the active client.
 	return &Repository{
 		Client: rdb,
+		logger: logger,
 	}, nil
 }
 
 // Close gracefully closes the Redis client and its connection pool.
 func (r *Repository) Close() {
 	if err := r.Client.Close(); err != nil {
-		log.Printf("Error closing Redis client: %v", err)
+		r.logger.Error("error closing Redis client", "error", err)
 	}
 }
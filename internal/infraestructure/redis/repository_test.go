@@ -7,6 +7,7 @@ import (
 	"github.com/alicebob/miniredis/v2"
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 	"github.com/renzonaitor/tweet-api/internal/infraestructure/redis"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,7 +30,7 @@ func TestNewRepository(t *testing.T) {
 		}
 
 		// Act
-		repo, err := redis.NewRepository(cfg)
+		repo, err := redis.NewRepository(cfg, logging.Discard())
 
 		// Assert
 		require.NoError(t, err)
@@ -51,7 +52,7 @@ func TestNewRepository(t *testing.T) {
 		}
 
 		// Act
-		repo, err := redis.NewRepository(cfg)
+		repo, err := redis.NewRepository(cfg, logging.Discard())
 
 		// Assert
 		require.Error(t, err)
@@ -0,0 +1,85 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// noSuchKey is the error code of the S3 API for a missing object.
+const noSuchKey = "NoSuchKey"
+
+// BlobStore keeps each blob as an object of a bucket of any S3-compatible object storage,
+// e.g. AWS S3 or MinIO.
+type BlobStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewBlobStore connects to the object storage and checks the bucket exists.
+func NewBlobStore(cfg config.Config, logger *slog.Logger) (*BlobStore, error) {
+	s3Cfg := cfg.Media.S3
+	client, err := minio.New(s3Cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(s3Cfg.AccessKey, s3Cfg.SecretKey, ""),
+		Secure: s3Cfg.UseSSL,
+		Region: s3Cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the S3 client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, s3Cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to S3: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %s does not exist", s3Cfg.Bucket)
+	}
+
+	logger.Info("successfully connected to S3", "endpoint", s3Cfg.Endpoint, "bucket", s3Cfg.Bucket)
+
+	return &BlobStore{client: client, bucket: s3Cfg.Bucket}, nil
+}
+
+// Put uploads data as the object key, served with contentType.
+func (s *BlobStore) Put(ctx context.Context, key, contentType string, data io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, data, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Get downloads the object key.
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy: Stat sends the request, so a missing object is reported here and
+	// not on the first read.
+	if _, err = object.Stat(); err != nil {
+		_ = object.Close()
+		if minio.ToErrorResponse(err).Code == noSuchKey {
+			return nil, domain.ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// Delete removes the object key. Deleting a missing object is not an error.
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code != noSuchKey {
+		return err
+	}
+	return nil
+}
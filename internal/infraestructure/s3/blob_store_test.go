@@ -0,0 +1,88 @@
+package s3_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/s3"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bucket = "tweet-media"
+
+// newS3Config starts an in-memory S3-compatible server standing in for the object storage.
+func newS3Config(t *testing.T, buckets ...string) config.Config {
+	t.Helper()
+
+	backend := s3mem.New()
+	for _, name := range buckets {
+		require.NoError(t, backend.CreateBucket(name))
+	}
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(server.Close)
+
+	return config.Config{Media: config.Media{S3: config.S3Store{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: "access",
+		SecretKey: "secret",
+	}}}
+}
+
+func TestBlobStore_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store, err := s3.NewBlobStore(newS3Config(t, bucket), logging.Discard())
+	require.NoError(t, err)
+	content := "\x89PNG\r\n\x1a\n-image"
+
+	// Act
+	err = store.Put(ctx, "blob", "image/png", strings.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	reader, err := store.Get(ctx, "blob")
+	require.NoError(t, err)
+	stored, err := io.ReadAll(reader)
+	require.NoError(t, reader.Close())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, content, string(stored))
+
+	require.NoError(t, store.Delete(ctx, "blob"))
+	_, err = store.Get(ctx, "blob")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+func TestBlobStore_MissingObject(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store, err := s3.NewBlobStore(newS3Config(t, bucket), logging.Discard())
+	require.NoError(t, err)
+
+	// Act
+	_, getErr := store.Get(ctx, "missing")
+	deleteErr := store.Delete(ctx, "missing")
+
+	// Assert
+	assert.ErrorIs(t, getErr, domain.ErrBlobNotFound)
+	assert.NoError(t, deleteErr, "deleting a missing object is not an error")
+}
+
+func TestNewBlobStore_MissingBucket(t *testing.T) {
+	// Act
+	_, err := s3.NewBlobStore(newS3Config(t), logging.Discard())
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bucket tweet-media does not exist")
+}
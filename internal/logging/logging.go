@@ -0,0 +1,53 @@
+// Package logging builds the structured loggers of the service on top of log/slog.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Formats supported by Config.Format.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config holds the settings of the logger.
+type Config struct {
+	// Level is the minimum level logged: debug, info, warn or error. Defaults to info.
+	Level string
+	// Format is the output encoding, json or text. Defaults to json.
+	Format string
+}
+
+// New returns a logger that writes to w with the configured level and format. Records logged
+// with a context carry the request ID and the trace ID found in it.
+func New(w io.Writer, cfg Config) (*slog.Logger, error) {
+	var level slog.Level
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", cfg.Format, FormatJSON, FormatText)
+	}
+
+	return slog.New(contextHandler{Handler: handler}), nil
+}
+
+// Discard returns a logger that drops every record, for tests and benchmarks.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
@@ -0,0 +1,130 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name             string
+		cfg              logging.Config
+		expectedContains []string
+		expectedMissing  []string
+		expectError      bool
+		errorContains    string
+	}{
+		{
+			name:             "Success - JSON at info level by default",
+			cfg:              logging.Config{},
+			expectedContains: []string{`"level":"INFO"`, `"msg":"info line"`},
+			expectedMissing:  []string{"debug line"},
+		},
+		{
+			name:             "Success - text at debug level",
+			cfg:              logging.Config{Level: "debug", Format: "text"},
+			expectedContains: []string{"level=DEBUG", `msg="debug line"`, `msg="info line"`},
+		},
+		{
+			name:             "Success - warn level drops info lines",
+			cfg:              logging.Config{Level: "WARN", Format: "JSON"},
+			expectedContains: []string{`"msg":"warn line"`},
+			expectedMissing:  []string{"info line"},
+		},
+		{
+			name:          "Failure - unknown level",
+			cfg:           logging.Config{Level: "verbose"},
+			expectError:   true,
+			errorContains: `invalid log level "verbose"`,
+		},
+		{
+			name:          "Failure - unknown format",
+			cfg:           logging.Config{Format: "xml"},
+			expectError:   true,
+			errorContains: `invalid log format "xml"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var output bytes.Buffer
+
+			// Act
+			logger, err := logging.New(&output, tc.cfg)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+
+			logger.Debug("debug line")
+			logger.Info("info line")
+			logger.Warn("warn line")
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, output.String(), expected)
+			}
+			for _, missing := range tc.expectedMissing {
+				assert.NotContains(t, output.String(), missing)
+			}
+		})
+	}
+}
+
+func TestNew_ContextAttributes(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9},
+		SpanID:  trace.SpanID{1},
+	})
+
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		expectedRequestID any
+		expectedTraceID   any
+	}{
+		{
+			name:              "Adds the request ID",
+			ctx:               logging.WithRequestID(context.Background(), "req-123"),
+			expectedRequestID: "req-123",
+		},
+		{
+			name:            "Adds the trace ID",
+			ctx:             trace.ContextWithSpanContext(context.Background(), spanContext),
+			expectedTraceID: spanContext.TraceID().String(),
+		},
+		{
+			name: "Adds nothing without request or span",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var output bytes.Buffer
+			logger, err := logging.New(&output, logging.Config{})
+			require.NoError(t, err)
+
+			// Act
+			logger.With("component", "test").InfoContext(tc.ctx, "line")
+
+			// Assert
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+			assert.Equal(t, "test", record["component"])
+			assert.Equal(t, tc.expectedRequestID, record["request_id"])
+			assert.Equal(t, tc.expectedTraceID, record["trace_id"])
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SamplerConfig bounds how many records with the same level and message are logged per tick.
+type SamplerConfig struct {
+	// First is how many records of each message are logged per tick.
+	First int
+	// Thereafter logs one of every Thereafter records past First. Zero drops them all.
+	Thereafter int
+	// Tick is the period after which the counters start over.
+	Tick time.Duration
+}
+
+// Sampled returns a logger that writes through logger but samples repeated messages, for
+// lines logged per follower or per chunk of a fan-out, which would otherwise flood the logs
+// for accounts with millions of followers. The counters are shared by the loggers derived
+// from the returned one.
+func Sampled(logger *slog.Logger, cfg SamplerConfig) *slog.Logger {
+	return slog.New(&samplingHandler{
+		Handler: logger.Handler(),
+		cfg:     cfg,
+		counters: &sampleCounters{
+			counts: make(map[sampleKey]int),
+			now:    time.Now,
+		},
+	})
+}
+
+type sampleKey struct {
+	level   slog.Level
+	message string
+}
+
+type sampleCounters struct {
+	mu      sync.Mutex
+	resetAt time.Time
+	counts  map[sampleKey]int
+	now     func() time.Time
+}
+
+// next counts a record of key and returns its position within the current tick, from 1.
+func (c *sampleCounters) next(key sampleKey, tick time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now := c.now(); !now.Before(c.resetAt) {
+		clear(c.counts)
+		c.resetAt = now.Add(tick)
+	}
+	c.counts[key]++
+	return c.counts[key]
+}
+
+type samplingHandler struct {
+	slog.Handler
+	cfg      SamplerConfig
+	counters *sampleCounters
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	n := h.counters.next(sampleKey{level: record.Level, message: record.Message}, h.cfg.Tick)
+	if n > h.cfg.First && (h.cfg.Thereafter <= 0 || (n-h.cfg.First)%h.cfg.Thereafter != 0) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), cfg: h.cfg, counters: h.counters}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), cfg: h.cfg, counters: h.counters}
+}
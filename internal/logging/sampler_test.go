@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampled(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cfg           SamplerConfig
+		lines         int
+		advance       time.Duration
+		expectedLines int
+	}{
+		{
+			name:          "Logs the first lines of a message",
+			cfg:           SamplerConfig{First: 3, Thereafter: 0, Tick: time.Second},
+			lines:         10,
+			expectedLines: 3,
+		},
+		{
+			name:          "Logs one of every Thereafter lines past First",
+			cfg:           SamplerConfig{First: 2, Thereafter: 4, Tick: time.Second},
+			lines:         10,
+			expectedLines: 4, // lines 1, 2, 6 and 10
+		},
+		{
+			name:          "Starts over every tick",
+			cfg:           SamplerConfig{First: 1, Thereafter: 0, Tick: time.Second},
+			lines:         5,
+			advance:       time.Second,
+			expectedLines: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var output bytes.Buffer
+			logger := Sampled(slog.New(slog.NewTextHandler(&output, nil)), tc.cfg)
+			now := time.Now()
+			logger.Handler().(*samplingHandler).counters.now = func() time.Time { return now }
+
+			// Act
+			for range tc.lines {
+				logger.With("attempt", 1).Warn("retrying push")
+				now = now.Add(tc.advance)
+			}
+			// Other messages are counted on their own.
+			logger.Warn("another message")
+
+			// Assert
+			assert.Equal(t, tc.expectedLines, strings.Count(output.String(), "retrying push"))
+			assert.Equal(t, 1, strings.Count(output.String(), "another message"))
+		})
+	}
+}
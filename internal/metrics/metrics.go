@@ -0,0 +1,120 @@
+// Package metrics holds the Prometheus collectors of the service. They're registered on the
+// default registry and exposed by Handler at /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tweet_api"
+
+// Labels of TimelineCacheRequests.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+	// CacheUnavailable is a read that couldn't use Redis and was served in degraded mode.
+	CacheUnavailable = "unavailable"
+)
+
+// Labels of FanOutPushes.
+const (
+	PushUpdated  = "updated"
+	PushUncached = "uncached"
+	PushFailed   = "failed"
+)
+
+var (
+	// HTTPRequestDuration observes the latency of every request by route pattern, method and status.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// TimelineCacheRequests counts timeline reads answered from the cache (hit), not found in it
+	// (miss) or read without it because Redis failed (unavailable).
+	TimelineCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "timeline",
+		Name:      "cache_requests_total",
+		Help:      "Timeline reads by cache result.",
+	}, []string{"result"})
+
+	// TimelineFallbacks counts timeline pages served from PostgreSQL.
+	TimelineFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "timeline",
+		Name:      "fallback_total",
+		Help:      "Timeline pages served from PostgreSQL instead of the cache.",
+	})
+
+	// FanOutDuration observes how long a fan-out run takes.
+	FanOutDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fanout",
+		Name:      "duration_seconds",
+		Help:      "Duration of a tweet fan-out run.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 10),
+	})
+
+	// FanOutFollowers observes how many followers a fan-out run delivers to.
+	FanOutFollowers = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fanout",
+		Name:      "followers",
+		Help:      "Followers read by a tweet fan-out run.",
+		Buckets:   prometheus.ExponentialBuckets(1, 10, 8),
+	})
+
+	// FanOutPushes counts the final outcome of every timeline push of a fan-out.
+	FanOutPushes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fanout",
+		Name:      "pushes_total",
+		Help:      "Timeline pushes of fan-out runs by final result.",
+	}, []string{"result"})
+
+	// FanOutLPushFailures counts every failed LPUSH, including the ones that succeed on retry.
+	FanOutLPushFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fanout",
+		Name:      "lpush_failures_total",
+		Help:      "Failed timeline LPUSH commands, retried ones included.",
+	})
+
+	// RateLimitRejections counts the requests answered with 429, by route and by the limit
+	// they hit (user or ip).
+	RateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by a rate limit, by route and scope.",
+	}, []string{"route", "scope"})
+
+	// DependencyRetries counts the calls to PostgreSQL or Redis retried after a transient error.
+	DependencyRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dependency",
+		Name:      "retries_total",
+		Help:      "Retried calls to a dependency by operation.",
+	}, []string{"dependency", "operation"})
+
+	// DependencyRejections counts the calls rejected by the circuit breaker of a dependency.
+	DependencyRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dependency",
+		Name:      "breaker_rejections_total",
+		Help:      "Calls to a dependency rejected while its circuit breaker was open.",
+	}, []string{"dependency"})
+)
+
+// Handler serves the collected metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
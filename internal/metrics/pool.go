@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	postgresOpenConnections = prometheus.NewDesc(namespace+"_postgres_pool_open_connections",
+		"Established connections to PostgreSQL, in use and idle.", nil, nil)
+	postgresInUseConnections = prometheus.NewDesc(namespace+"_postgres_pool_in_use_connections",
+		"PostgreSQL connections currently in use.", nil, nil)
+	postgresIdleConnections = prometheus.NewDesc(namespace+"_postgres_pool_idle_connections",
+		"Idle PostgreSQL connections.", nil, nil)
+	postgresWaitCount = prometheus.NewDesc(namespace+"_postgres_pool_wait_total",
+		"Times a query waited for a free PostgreSQL connection.", nil, nil)
+	postgresWaitDuration = prometheus.NewDesc(namespace+"_postgres_pool_wait_seconds_total",
+		"Time spent waiting for a free PostgreSQL connection.", nil, nil)
+
+	redisTotalConnections = prometheus.NewDesc(namespace+"_redis_pool_total_connections",
+		"Established connections to Redis.", nil, nil)
+	redisIdleConnections = prometheus.NewDesc(namespace+"_redis_pool_idle_connections",
+		"Idle Redis connections.", nil, nil)
+	redisHits = prometheus.NewDesc(namespace+"_redis_pool_hits_total",
+		"Times a free Redis connection was found in the pool.", nil, nil)
+	redisMisses = prometheus.NewDesc(namespace+"_redis_pool_misses_total",
+		"Times a free Redis connection was not found in the pool.", nil, nil)
+	redisTimeouts = prometheus.NewDesc(namespace+"_redis_pool_timeouts_total",
+		"Times waiting for a Redis connection timed out.", nil, nil)
+)
+
+// poolCollector reads the connection pool stats of PostgreSQL and Redis on every scrape.
+type poolCollector struct {
+	dbStats    func() sql.DBStats
+	redisStats func() *redis.PoolStats
+}
+
+// RegisterPoolStats exposes the connection pool stats returned by dbStats and redisStats.
+func RegisterPoolStats(dbStats func() sql.DBStats, redisStats func() *redis.PoolStats) {
+	prometheus.MustRegister(poolCollector{dbStats: dbStats, redisStats: redisStats})
+}
+
+func (c poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- postgresOpenConnections
+	ch <- postgresInUseConnections
+	ch <- postgresIdleConnections
+	ch <- postgresWaitCount
+	ch <- postgresWaitDuration
+	ch <- redisTotalConnections
+	ch <- redisIdleConnections
+	ch <- redisHits
+	ch <- redisMisses
+	ch <- redisTimeouts
+}
+
+func (c poolCollector) Collect(ch chan<- prometheus.Metric) {
+	db := c.dbStats()
+	ch <- prometheus.MustNewConstMetric(postgresOpenConnections, prometheus.GaugeValue, float64(db.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(postgresInUseConnections, prometheus.GaugeValue, float64(db.InUse))
+	ch <- prometheus.MustNewConstMetric(postgresIdleConnections, prometheus.GaugeValue, float64(db.Idle))
+	ch <- prometheus.MustNewConstMetric(postgresWaitCount, prometheus.CounterValue, float64(db.WaitCount))
+	ch <- prometheus.MustNewConstMetric(postgresWaitDuration, prometheus.CounterValue, db.WaitDuration.Seconds())
+
+	rdb := c.redisStats()
+	ch <- prometheus.MustNewConstMetric(redisTotalConnections, prometheus.GaugeValue, float64(rdb.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisIdleConnections, prometheus.GaugeValue, float64(rdb.IdleConns))
+	ch <- prometheus.MustNewConstMetric(redisHits, prometheus.CounterValue, float64(rdb.Hits))
+	ch <- prometheus.MustNewConstMetric(redisMisses, prometheus.CounterValue, float64(rdb.Misses))
+	ch <- prometheus.MustNewConstMetric(redisTimeouts, prometheus.CounterValue, float64(rdb.Timeouts))
+}
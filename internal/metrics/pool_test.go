@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolCollector(t *testing.T) {
+	// Arrange
+	collector := poolCollector{
+		dbStats: func() sql.DBStats {
+			return sql.DBStats{OpenConnections: 5, InUse: 3, Idle: 2, WaitCount: 7, WaitDuration: 1500 * time.Millisecond}
+		},
+		redisStats: func() *redis.PoolStats {
+			return &redis.PoolStats{TotalConns: 10, IdleConns: 4, Hits: 100, Misses: 6, Timeouts: 1}
+		},
+	}
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	expected := `
+# HELP tweet_api_postgres_pool_in_use_connections PostgreSQL connections currently in use.
+# TYPE tweet_api_postgres_pool_in_use_connections gauge
+tweet_api_postgres_pool_in_use_connections 3
+# HELP tweet_api_postgres_pool_wait_seconds_total Time spent waiting for a free PostgreSQL connection.
+# TYPE tweet_api_postgres_pool_wait_seconds_total counter
+tweet_api_postgres_pool_wait_seconds_total 1.5
+# HELP tweet_api_redis_pool_idle_connections Idle Redis connections.
+# TYPE tweet_api_redis_pool_idle_connections gauge
+tweet_api_redis_pool_idle_connections 4
+# HELP tweet_api_redis_pool_timeouts_total Times waiting for a Redis connection timed out.
+# TYPE tweet_api_redis_pool_timeouts_total counter
+tweet_api_redis_pool_timeouts_total 1
+`
+
+	// Act
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"tweet_api_postgres_pool_in_use_connections",
+		"tweet_api_postgres_pool_wait_seconds_total",
+		"tweet_api_redis_pool_idle_connections",
+		"tweet_api_redis_pool_timeouts_total",
+	)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10, testutil.CollectAndCount(collector))
+}
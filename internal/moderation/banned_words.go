@@ -0,0 +1,47 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// BannedWords is broken by the tweets containing one of a list of words or phrases, as whole
+// words. The text is matched by its skeleton, so the words can't be slipped through with
+// accents, other cases, look-alike letters of other scripts, digits for letters or invisible
+// characters.
+type BannedWords struct {
+	words []bannedWord
+}
+
+type bannedWord struct {
+	word     string
+	skeleton string
+}
+
+// NewBannedWords returns the rule for words. Entries without letters or digits are ignored.
+func NewBannedWords(words []string) *BannedWords {
+	rule := &BannedWords{}
+	for _, word := range words {
+		if skeleton := wordsOf(word); skeleton != "" {
+			rule.words = append(rule.words, bannedWord{word: word, skeleton: skeleton})
+		}
+	}
+	return rule
+}
+
+func (r BannedWords) Name() string {
+	return "banned_words"
+}
+
+func (r BannedWords) Check(_ context.Context, tweet domain.Tweet) (string, error) {
+	text, plainText := wordsOf(tweet.Text), plainWordsOf(tweet.Text)
+	for _, banned := range r.words {
+		if strings.Contains(text, banned.skeleton) || strings.Contains(plainText, banned.skeleton) {
+			return fmt.Sprintf("contains the banned word %q", banned.word), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,59 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannedWords(t *testing.T) {
+	rule := moderation.NewBannedWords([]string{"scam", "Buy Followers", "  ", "***"})
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Clean text", text: "a perfectly normal tweet"},
+		{name: "Exact word", text: "this is a scam", expected: `contains the banned word "scam"`},
+		{name: "Other case", text: "SCAM alert", expected: `contains the banned word "scam"`},
+		{name: "Punctuation around the word", text: "total (scam)!", expected: `contains the banned word "scam"`},
+		{name: "Accents", text: "ścâm", expected: `contains the banned word "scam"`},
+		{name: "Cyrillic look-alikes", text: "ѕсаm", expected: `contains the banned word "scam"`},
+		{name: "Greek look-alikes", text: "scαm", expected: `contains the banned word "scam"`},
+		{name: "Digits and symbols for letters", text: "$c4m", expected: `contains the banned word "scam"`},
+		{name: "Symbols inside the word", text: "sc@m", expected: `contains the banned word "scam"`},
+		{name: "Trailing !", text: "what a scam!", expected: `contains the banned word "scam"`},
+		{name: "Trailing ! in capitals", text: "SCAM!", expected: `contains the banned word "scam"`},
+		{name: "Trailing |", text: "scam|", expected: `contains the banned word "scam"`},
+		{name: "Trailing $", text: "scam$", expected: `contains the banned word "scam"`},
+		{name: "Trailing @", text: "scam@", expected: `contains the banned word "scam"`},
+		{name: "Leading !", text: "!scam", expected: `contains the banned word "scam"`},
+		{name: "Symbols for letters and trailing !", text: "$c@m!!", expected: `contains the banned word "scam"`},
+		{name: "Phrase followed by !", text: "buy followers!", expected: `contains the banned word "Buy Followers"`},
+		{name: "Digits alone", text: "5c 4m"},
+		{name: "Fullwidth letters", text: "ｓｃａｍ", expected: `contains the banned word "scam"`},
+		{name: "Styled math letters", text: "𝐬𝐜𝐚𝐦", expected: `contains the banned word "scam"`},
+		{name: "Zero-width characters inside the word", text: "sc\u200bam", expected: `contains the banned word "scam"`},
+		{name: "Part of another word", text: "scampi for dinner"},
+		{name: "Phrase", text: "buy   followers now", expected: `contains the banned word "Buy Followers"`},
+		{name: "Phrase with look-alikes", text: "bυy f0ll0wers", expected: `contains the banned word "Buy Followers"`},
+		{name: "Phrase words apart", text: "buy more followers"},
+		{name: "Empty text", text: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			reason, err := rule.Check(context.Background(), domain.Tweet{Text: tc.text})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reason)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// lookalikes maps the lowercase letters of other scripts to the Latin letter they look like.
+var lookalikes = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'ё': 'e', 'һ': 'h', 'і': 'i', 'ї': 'i',
+	'ј': 'j', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'т': 't',
+	'у': 'y', 'ԝ': 'w', 'х': 'x', 'ү': 'y',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p',
+	'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+	// Latin letters looking alike among themselves
+	'l': 'i', 'ı': 'i', 'ſ': 's',
+}
+
+// standIns maps the digits and symbols written in place of a letter to that letter. They only
+// stand for letters inside a word with letters: "5" alone is a number and "scam!" ends with a
+// bang, not with an i.
+var standIns = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '@': 'a', '$': 's',
+	'|': 'i', '!': 'i',
+}
+
+// skeleton folds s so the texts written to look the same compare equal: compatibility
+// characters (fullwidth, styled math letters, ligatures) are decomposed, accents and
+// invisible characters dropped, case folded, and look-alike letters replaced by the Latin
+// letter they look like.
+func skeleton(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range norm.NFKD.String(s) {
+		if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Cf, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if lookalike, ok := lookalikes[r]; ok {
+			r = lookalike
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// wordsOf returns the words of the skeleton of s, joined by a single space and surrounded by
+// spaces, so a phrase can be matched as whole words with strings.Contains. A word is a run of
+// letters, digits and symbols standing for letters; the symbols ending a word are taken for
+// punctuation, and the digits and symbols left are replaced by the letter they stand for in
+// the words with letters.
+func wordsOf(s string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(skeleton(s), isWordSeparator) {
+		word = strings.TrimRightFunc(word, isSymbol)
+		if strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			words = append(words, strings.FieldsFunc(word, isSymbol)...)
+			continue
+		}
+		words = append(words, strings.Map(func(r rune) rune {
+			if standIn, ok := standIns[r]; ok {
+				return standIn
+			}
+			return r
+		}, word))
+	}
+	return joinWords(words)
+}
+
+// plainWordsOf returns the words of the skeleton of s like wordsOf, but splitting on every
+// symbol and leaving the digits as they are, so a word isn't missed when a symbol is only
+// punctuation around it, as in "!scam".
+func plainWordsOf(s string) string {
+	return joinWords(strings.FieldsFunc(skeleton(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
+func joinWords(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	return " " + strings.Join(words, " ") + " "
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !isSymbol(r)
+}
+
+// isSymbol reports whether r is one of the symbols that may stand for a letter.
+func isSymbol(r rune) bool {
+	_, ok := standIns[r]
+	return ok && !unicode.IsDigit(r)
+}
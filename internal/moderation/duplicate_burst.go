@@ -0,0 +1,60 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Defaults used when the matching DuplicateBurst setting is not set.
+const (
+	defaultMaxDuplicates   = 3
+	defaultDuplicateWindow = 10 * time.Minute
+)
+
+// DuplicateBurst is broken by the tweets repeating the text of MaxDuplicates or more tweets
+// their author published within Window, a common pattern of spam.
+type DuplicateBurst struct {
+	Counter       DuplicateCounter
+	MaxDuplicates int
+	Window        time.Duration
+	// Clock tells where the window ends. Defaults to the system clock.
+	Clock clock.Clock
+}
+
+func NewDuplicateBurst(counter DuplicateCounter, maxDuplicates int, window time.Duration) *DuplicateBurst {
+	if maxDuplicates <= 0 {
+		maxDuplicates = defaultMaxDuplicates
+	}
+	if window <= 0 {
+		window = defaultDuplicateWindow
+	}
+
+	return &DuplicateBurst{
+		Counter:       counter,
+		MaxDuplicates: maxDuplicates,
+		Window:        window,
+		Clock:         clock.System,
+	}
+}
+
+func (r DuplicateBurst) Name() string {
+	return "duplicate_burst"
+}
+
+// Check leaves tweet itself out of the count, so a replay of its publishing isn't counted
+// as a duplicate.
+func (r DuplicateBurst) Check(ctx context.Context, tweet domain.Tweet) (string, error) {
+	since := r.Clock.Now().UTC().Add(-r.Window)
+	duplicates, err := r.Counter.CountRecentDuplicates(ctx, tweet.UserID, tweet.Text, tweet.ID, since)
+	if err != nil {
+		return "", err
+	}
+	if duplicates < r.MaxDuplicates {
+		return "", nil
+	}
+	return fmt.Sprintf("repeats the text of %d tweets published in the last %s", duplicates, r.Window), nil
+}
@@ -0,0 +1,75 @@
+package moderation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+	"github.com/renzonaitor/tweet-api/internal/moderation/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewDuplicateBurst(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockCounter := mocks.NewMockDuplicateCounter(ctrl)
+
+	// Act
+	rule := moderation.NewDuplicateBurst(mockCounter, 0, 0)
+
+	// Assert
+	assert.Equal(t, mockCounter, rule.Counter)
+	assert.Equal(t, 3, rule.MaxDuplicates, "MaxDuplicates should default to 3")
+	assert.Equal(t, 10*time.Minute, rule.Window, "Window should default to 10 minutes")
+	assert.Equal(t, "duplicate_burst", rule.Name())
+}
+
+func TestDuplicateBurst(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tweet := domain.Tweet{ID: "tweet-1", UserID: "user-1", Text: "Follow me!"}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name        string
+		duplicates  int
+		countErr    error
+		expected    string
+		expectedErr error
+	}{
+		{name: "No duplicates", duplicates: 0},
+		{name: "Fewer duplicates than the maximum", duplicates: 1},
+		{name: "As many duplicates as the maximum", duplicates: 2, expected: "repeats the text of 2 tweets published in the last 5m0s"},
+		{name: "Counter error", countErr: dbError, expectedErr: dbError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockCounter := mocks.NewMockDuplicateCounter(ctrl)
+			mockCounter.EXPECT().
+				CountRecentDuplicates(gomock.Any(), "user-1", "Follow me!", "tweet-1", now.Add(-5*time.Minute)).
+				Return(tc.duplicates, tc.countErr)
+
+			rule := moderation.NewDuplicateBurst(mockCounter, 2, 5*time.Minute)
+			rule.Clock = clock.Fixed(now)
+
+			// Act
+			reason, err := rule.Check(context.Background(), tweet)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reason)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"golang.org/x/net/idna"
+)
+
+// LinkBlocklist is broken by the tweets linking to one of a list of domains or their
+// subdomains. Domains are compared in their ASCII form, so an internationalized domain
+// matches whether it's written in Unicode or punycode.
+type LinkBlocklist struct {
+	domains []string
+}
+
+// NewLinkBlocklist returns the rule for domains. Entries that aren't valid domain names are
+// ignored.
+func NewLinkBlocklist(domains []string) *LinkBlocklist {
+	rule := &LinkBlocklist{}
+	for _, domain := range domains {
+		if host := normalizeHost(domain); host != "" {
+			rule.domains = append(rule.domains, host)
+		}
+	}
+	return rule
+}
+
+func (r LinkBlocklist) Name() string {
+	return "link_blocklist"
+}
+
+// Check looks at the URLs of tweet, which the publishing sets from its text.
+func (r LinkBlocklist) Check(_ context.Context, tweet domain.Tweet) (string, error) {
+	for _, entity := range tweet.URLs {
+		parsed, err := url.Parse(entity.URL)
+		if err != nil {
+			continue
+		}
+		host := normalizeHost(parsed.Hostname())
+		if host == "" {
+			continue
+		}
+		for _, blocked := range r.domains {
+			if host == blocked || strings.HasSuffix(host, "."+blocked) {
+				return fmt.Sprintf("links to the blocked domain %q", blocked), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// normalizeHost returns host in lowercase ASCII without the trailing dot, or "" if it isn't
+// a valid domain name.
+func normalizeHost(host string) string {
+	host, err := idna.Lookup.ToASCII(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(host)
+}
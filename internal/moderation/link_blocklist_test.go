@@ -0,0 +1,48 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkBlocklist(t *testing.T) {
+	rule := moderation.NewLinkBlocklist([]string{"Spam.example", "bücher.example.", "not a domain!"})
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "No links", text: "spam.example isn't a link without a scheme"},
+		{name: "Allowed link", text: "see https://go.dev"},
+		{name: "Blocked domain", text: "see https://spam.example/offer", expected: `links to the blocked domain "spam.example"`},
+		{name: "Blocked domain in upper case with a port", text: "HTTP://SPAM.EXAMPLE:8080", expected: `links to the blocked domain "spam.example"`},
+		{name: "Subdomain", text: "https://deals.spam.example", expected: `links to the blocked domain "spam.example"`},
+		{name: "Trailing dot", text: "https://spam.example./x", expected: `links to the blocked domain "spam.example"`},
+		{name: "Domain ending with a blocked one", text: "https://notspam.example"},
+		{name: "Blocked domain in the path", text: "https://go.dev/spam.example"},
+		{name: "Unicode domain", text: "https://bücher.example", expected: `links to the blocked domain "xn--bcher-kva.example"`},
+		{name: "Punycode domain", text: "https://xn--bcher-kva.example", expected: `links to the blocked domain "xn--bcher-kva.example"`},
+		{name: "Second link blocked", text: "https://go.dev and https://spam.example", expected: `links to the blocked domain "spam.example"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tweet := domain.Tweet{Text: tc.text, URLs: tweettext.ExtractURLs(tc.text)}
+
+			// Act
+			reason, err := rule.Check(context.Background(), tweet)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reason)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=moderation.go -destination=mocks/moderation_mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRule is a mock of Rule interface.
+type MockRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleMockRecorder
+	isgomock struct{}
+}
+
+// MockRuleMockRecorder is the mock recorder for MockRule.
+type MockRuleMockRecorder struct {
+	mock *MockRule
+}
+
+// NewMockRule creates a new mock instance.
+func NewMockRule(ctrl *gomock.Controller) *MockRule {
+	mock := &MockRule{ctrl: ctrl}
+	mock.recorder = &MockRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRule) EXPECT() *MockRuleMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockRule) Check(ctx context.Context, tweet domain.Tweet) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, tweet)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockRuleMockRecorder) Check(ctx, tweet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockRule)(nil).Check), ctx, tweet)
+}
+
+// Name mocks base method.
+func (m *MockRule) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockRuleMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockRule)(nil).Name))
+}
+
+// MockDuplicateCounter is a mock of DuplicateCounter interface.
+type MockDuplicateCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateCounterMockRecorder is the mock recorder for MockDuplicateCounter.
+type MockDuplicateCounterMockRecorder struct {
+	mock *MockDuplicateCounter
+}
+
+// NewMockDuplicateCounter creates a new mock instance.
+func NewMockDuplicateCounter(ctrl *gomock.Controller) *MockDuplicateCounter {
+	mock := &MockDuplicateCounter{ctrl: ctrl}
+	mock.recorder = &MockDuplicateCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateCounter) EXPECT() *MockDuplicateCounterMockRecorder {
+	return m.recorder
+}
+
+// CountRecentDuplicates mocks base method.
+func (m *MockDuplicateCounter) CountRecentDuplicates(ctx context.Context, authorID, text, excludeTweetID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentDuplicates", ctx, authorID, text, excludeTweetID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentDuplicates indicates an expected call of CountRecentDuplicates.
+func (mr *MockDuplicateCounterMockRecorder) CountRecentDuplicates(ctx, authorID, text, excludeTweetID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentDuplicates", reflect.TypeOf((*MockDuplicateCounter)(nil).CountRecentDuplicates), ctx, authorID, text, excludeTweetID, since)
+}
@@ -0,0 +1,93 @@
+// Package moderation decides what happens to a tweet before it is published. The tweet is
+// checked against a list of rules, each configured with the action to take on the tweets
+// breaking it.
+package moderation
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Rule is a check of the tweets to publish.
+//
+//go:generate mockgen -source=moderation.go -destination=mocks/moderation_mocks.go -package=mocks
+type Rule interface {
+	// Name identifies the rule in the verdicts and the review queue.
+	Name() string
+	// Check returns why tweet breaks the rule, or "" if it doesn't.
+	Check(ctx context.Context, tweet domain.Tweet) (string, error)
+}
+
+// DuplicateCounter counts the recent tweets of an author with the same text, for
+// DuplicateBurst.
+type DuplicateCounter interface {
+	// CountRecentDuplicates counts the tweets of authorID created at or after since whose
+	// text equals text, ignoring case, leaving out excludeTweetID.
+	CountRecentDuplicates(ctx context.Context, authorID, text, excludeTweetID string, since time.Time) (int, error)
+}
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/moderation")
+
+// Policy is a rule and the action to take on the tweets breaking it.
+type Policy struct {
+	Rule   Rule
+	Action domain.ModerationAction
+}
+
+// Moderator checks the tweets against its policies.
+type Moderator struct {
+	Policies []Policy
+	Logger   *slog.Logger
+}
+
+func NewModerator(logger *slog.Logger, policies ...Policy) *Moderator {
+	return &Moderator{
+		Policies: policies,
+		Logger:   logger,
+	}
+}
+
+// Moderate returns the verdict of the most severe policy tweet breaks, the first one among
+// equally severe policies. The rules of policies that can't be more severe than the verdict
+// so far are skipped.
+//
+// Moderation fails open: a rule returning an error is logged and skipped, so an outage of
+// what a rule depends on doesn't stop the tweets from being published.
+func (m Moderator) Moderate(ctx context.Context, tweet domain.Tweet) domain.ModerationVerdict {
+	ctx, span := tracer.Start(ctx, "moderation.Moderate", trace.WithAttributes(
+		attribute.String("tweet.id", tweet.ID),
+		attribute.String("user.id", tweet.UserID),
+	))
+	defer span.End()
+
+	verdict := domain.ModerationVerdict{Action: domain.ModerationAllow}
+	for _, policy := range m.Policies {
+		if policy.Action.Severity() <= verdict.Action.Severity() {
+			continue
+		}
+		reason, err := policy.Rule.Check(ctx, tweet)
+		if err != nil {
+			m.Logger.ErrorContext(ctx, "error checking moderation rule",
+				slog.String("rule", policy.Rule.Name()),
+				slog.String("tweet_id", tweet.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if reason != "" {
+			verdict = domain.ModerationVerdict{Action: policy.Action, Rule: policy.Rule.Name(), Reason: reason}
+		}
+	}
+
+	span.SetAttributes(attribute.String("moderation.action", string(verdict.Action)))
+	if verdict.Rule != "" {
+		span.SetAttributes(attribute.String("moderation.rule", verdict.Rule))
+	}
+	return verdict
+}
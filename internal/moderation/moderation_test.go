@@ -0,0 +1,103 @@
+package moderation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+	"github.com/renzonaitor/tweet-api/internal/moderation/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestModerate(t *testing.T) {
+	tweet := domain.Tweet{ID: "tweet-1", UserID: "user-1", Text: "hello"}
+
+	// rule returns a rule named name checking tweet with check, or never checking it when
+	// check is nil.
+	rule := func(ctrl *gomock.Controller, name string, check func() (string, error)) moderation.Rule {
+		mock := mocks.NewMockRule(ctrl)
+		mock.EXPECT().Name().Return(name).AnyTimes()
+		if check != nil {
+			mock.EXPECT().Check(gomock.Any(), tweet).DoAndReturn(func(context.Context, domain.Tweet) (string, error) {
+				return check()
+			})
+		}
+		return mock
+	}
+	breaks := func(reason string) func() (string, error) {
+		return func() (string, error) { return reason, nil }
+	}
+	passes := breaks("")
+
+	testCases := []struct {
+		name     string
+		policies func(ctrl *gomock.Controller) []moderation.Policy
+		expected domain.ModerationVerdict
+	}{
+		{
+			name:     "No policies allow the tweet",
+			policies: func(ctrl *gomock.Controller) []moderation.Policy { return nil },
+			expected: domain.ModerationVerdict{Action: domain.ModerationAllow},
+		},
+		{
+			name: "No rule broken allows the tweet",
+			policies: func(ctrl *gomock.Controller) []moderation.Policy {
+				return []moderation.Policy{
+					{Rule: rule(ctrl, "a", passes), Action: domain.ModerationReject},
+					{Rule: rule(ctrl, "b", passes), Action: domain.ModerationHold},
+				}
+			},
+			expected: domain.ModerationVerdict{Action: domain.ModerationAllow},
+		},
+		{
+			name: "The most severe broken rule wins",
+			policies: func(ctrl *gomock.Controller) []moderation.Policy {
+				return []moderation.Policy{
+					{Rule: rule(ctrl, "limit", breaks("spam")), Action: domain.ModerationShadowLimit},
+					{Rule: rule(ctrl, "hold", breaks("bad word")), Action: domain.ModerationHold},
+					{Rule: rule(ctrl, "unbroken", passes), Action: domain.ModerationReject},
+				}
+			},
+			expected: domain.ModerationVerdict{Action: domain.ModerationHold, Rule: "hold", Reason: "bad word"},
+		},
+		{
+			name: "Rules that can't be more severe are skipped",
+			policies: func(ctrl *gomock.Controller) []moderation.Policy {
+				return []moderation.Policy{
+					{Rule: rule(ctrl, "first", breaks("first reason")), Action: domain.ModerationHold},
+					{Rule: rule(ctrl, "same", nil), Action: domain.ModerationHold},
+					{Rule: rule(ctrl, "lesser", nil), Action: domain.ModerationShadowLimit},
+				}
+			},
+			expected: domain.ModerationVerdict{Action: domain.ModerationHold, Rule: "first", Reason: "first reason"},
+		},
+		{
+			name: "A failing rule is skipped",
+			policies: func(ctrl *gomock.Controller) []moderation.Policy {
+				return []moderation.Policy{
+					{Rule: rule(ctrl, "failing", func() (string, error) { return "", errors.New("database down") }), Action: domain.ModerationReject},
+					{Rule: rule(ctrl, "limit", breaks("spam")), Action: domain.ModerationShadowLimit},
+				}
+			},
+			expected: domain.ModerationVerdict{Action: domain.ModerationShadowLimit, Rule: "limit", Reason: "spam"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			moderator := moderation.NewModerator(logging.Discard(), tc.policies(ctrl)...)
+
+			// Act
+			verdict := moderator.Moderate(context.Background(), tweet)
+
+			// Assert
+			assert.Equal(t, tc.expected, verdict)
+		})
+	}
+}
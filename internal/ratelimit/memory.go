@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// sweepEvery is the number of requests between two sweeps of the expired keys.
+const sweepEvery = 1024
+
+// Memory is a Limiter that keeps its state in the process: each instance of the service
+// enforces the limits on its own. It's safe for concurrent use.
+type Memory struct {
+	now func() time.Time
+
+	mu    sync.Mutex
+	tats  map[string]time.Time
+	calls int
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		now:  time.Now,
+		tats: make(map[string]time.Time),
+	}
+}
+
+func (m *Memory) AllowRate(_ context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	decision, tat := gcra(now, m.tats[key], limit)
+	m.tats[key] = tat
+
+	m.calls++
+	if m.calls%sweepEvery == 0 {
+		m.sweep(now)
+	}
+	return decision, nil
+}
+
+// sweep forgets the keys whose limit is fully available again: they'd start from scratch anyway.
+func (m *Memory) sweep(now time.Time) {
+	for key, tat := range m.tats {
+		if !tat.After(now) {
+			delete(m.tats, key)
+		}
+	}
+}
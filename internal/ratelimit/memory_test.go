@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_AllowRate(t *testing.T) {
+	limit := domain.RateLimit{Requests: 3, Window: 3 * time.Second}
+
+	testCases := []struct {
+		name     string
+		requests []time.Duration // offsets from the start
+		expected domain.RateLimitDecision
+	}{
+		{
+			name:     "First request",
+			requests: []time.Duration{0},
+			expected: domain.RateLimitDecision{Allowed: true, Remaining: 2, Reset: time.Second},
+		},
+		{
+			name:     "Burst up to the limit",
+			requests: []time.Duration{0, 0, 0},
+			expected: domain.RateLimitDecision{Allowed: true, Remaining: 0, Reset: 3 * time.Second},
+		},
+		{
+			name:     "Over the limit",
+			requests: []time.Duration{0, 0, 0, 0},
+			expected: domain.RateLimitDecision{Allowed: false, Reset: 3 * time.Second, RetryAfter: time.Second},
+		},
+		{
+			name:     "Rejected requests don't use the limit",
+			requests: []time.Duration{0, 0, 0, 0, 0, time.Second},
+			expected: domain.RateLimitDecision{Allowed: true, Remaining: 0, Reset: 3 * time.Second},
+		},
+		{
+			name:     "Tokens come back one interval at a time",
+			requests: []time.Duration{0, 0, 0, 2500 * time.Millisecond},
+			expected: domain.RateLimitDecision{Allowed: true, Remaining: 1, Reset: 1500 * time.Millisecond},
+		},
+		{
+			name:     "Unused requests don't pile up",
+			requests: []time.Duration{0, time.Hour},
+			expected: domain.RateLimitDecision{Allowed: true, Remaining: 2, Reset: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			start := time.Now()
+			limiter := NewMemory()
+
+			// Act
+			var decision domain.RateLimitDecision
+			for _, offset := range tc.requests {
+				limiter.now = func() time.Time { return start.Add(offset) }
+				var err error
+				decision, err = limiter.AllowRate(context.Background(), "user:1", limit)
+				require.NoError(t, err)
+			}
+
+			// Assert
+			assert.Equal(t, tc.expected, decision)
+		})
+	}
+}
+
+func TestMemory_KeysAreIndependent(t *testing.T) {
+	// Arrange
+	limiter := NewMemory()
+	limit := domain.RateLimit{Requests: 1, Window: time.Minute}
+
+	// Act
+	first, _ := limiter.AllowRate(context.Background(), "user:1", limit)
+	second, _ := limiter.AllowRate(context.Background(), "user:1", limit)
+	other, _ := limiter.AllowRate(context.Background(), "user:2", limit)
+
+	// Assert
+	assert.True(t, first.Allowed)
+	assert.False(t, second.Allowed)
+	assert.True(t, other.Allowed)
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	// Arrange
+	start := time.Now()
+	limiter := NewMemory()
+	limiter.now = func() time.Time { return start }
+	limit := domain.RateLimit{Requests: 10, Window: time.Second}
+	_, _ = limiter.AllowRate(context.Background(), "idle", limit)
+
+	// Act
+	limiter.now = func() time.Time { return start.Add(time.Minute) }
+	for range sweepEvery - 1 {
+		_, _ = limiter.AllowRate(context.Background(), "busy", limit)
+	}
+
+	// Assert
+	assert.NotContains(t, limiter.tats, "idle")
+	assert.Contains(t, limiter.tats, "busy")
+}
@@ -0,0 +1,42 @@
+// Package ratelimit counts requests against a domain.RateLimit with GCRA, the generic cell rate
+// algorithm: a token bucket that only stores, per key, the "theoretical arrival time" of the
+// next request. The Redis implementation shares the state between instances; Memory is meant
+// for a single instance and for tests.
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Limiter counts a request of key against limit.
+type Limiter interface {
+	AllowRate(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
+
+// gcra counts a request made at now against limit, given tat, the theoretical arrival time
+// stored for the key (zero when none). It returns the decision and the TAT to store, which is
+// tat itself when the request isn't allowed.
+func gcra(now, tat time.Time, limit domain.RateLimit) (domain.RateLimitDecision, time.Time) {
+	interval := limit.Window / time.Duration(limit.Requests)
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-limit.Window)
+	if now.Before(allowAt) {
+		return domain.RateLimitDecision{
+			Reset:      tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, tat
+	}
+
+	return domain.RateLimitDecision{
+		Allowed:   true,
+		Remaining: int(now.Sub(allowAt) / interval),
+		Reset:     newTAT.Sub(now),
+	}, newTAT
+}
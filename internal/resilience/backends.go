@@ -0,0 +1,90 @@
+package resilience
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+)
+
+// Postgres classifies the errors of the pgx driver.
+var Postgres = Backend{
+	Name:      "postgres",
+	Transient: postgresTransient,
+	Unsent:    postgresUnsent,
+}
+
+// Redis classifies the errors of the go-redis client.
+var Redis = Backend{
+	Name:      "redis",
+	Transient: redisTransient,
+	Unsent:    redisUnsent,
+}
+
+// postgresTransientCodes are the SQLSTATE codes of the server errors worth retrying.
+var postgresTransientCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+func postgresTransient(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08: connection exception.
+		return postgresTransientCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+	return pgconn.Timeout(err) || connectionError(err) || errors.Is(err, driver.ErrBadConn)
+}
+
+// postgresUnsent also accepts the server errors that roll the statement back: it never took effect.
+func postgresUnsent(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return postgresTransientCodes[pgErr.Code]
+	}
+	return pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn) || dialError(err)
+}
+
+// redisTransientPrefixes are the prefixes of the Redis error replies sent while a server
+// loads its data set, fails over or is being resharded.
+var redisTransientPrefixes = []string{"LOADING", "READONLY", "MASTERDOWN", "TRYAGAIN", "CLUSTERDOWN"}
+
+func redisTransient(err error) bool {
+	for _, prefix := range redisTransientPrefixes {
+		if redis.HasErrorPrefix(err, prefix) {
+			return true
+		}
+	}
+	return errors.Is(err, redis.ErrPoolTimeout) || connectionError(err)
+}
+
+func redisUnsent(err error) bool {
+	for _, prefix := range redisTransientPrefixes {
+		if redis.HasErrorPrefix(err, prefix) {
+			// The command was rejected without being run.
+			return true
+		}
+	}
+	return errors.Is(err, redis.ErrPoolTimeout) || dialError(err)
+}
+
+// connectionError reports whether err comes from the network: the connection was refused,
+// reset, closed or timed out.
+func connectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// dialError reports whether err happened while opening a connection, before anything was sent.
+func dialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,64 @@
+package resilience_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/stretchr/testify/assert"
+)
+
+// redisReply is an error reply of the Redis server.
+type redisReply string
+
+func (e redisReply) Error() string { return string(e) }
+func (redisReply) RedisError()     {}
+
+func TestBackends(t *testing.T) {
+	testCases := []struct {
+		name              string
+		backend           resilience.Backend
+		err               error
+		expectedTransient bool
+		expectedUnsent    bool
+	}{
+		{name: "postgres - connection refused", backend: resilience.Postgres, err: connRefused, expectedTransient: true, expectedUnsent: true},
+		{name: "postgres - connection reset", backend: resilience.Postgres, err: fmt.Errorf("error scanning tweet: %w", connReset), expectedTransient: true},
+		{name: "postgres - unexpected EOF", backend: resilience.Postgres, err: io.ErrUnexpectedEOF, expectedTransient: true},
+		{name: "postgres - bad connection", backend: resilience.Postgres, err: driver.ErrBadConn, expectedTransient: true, expectedUnsent: true},
+		{name: "postgres - deadlock", backend: resilience.Postgres, err: &pgconn.PgError{Code: "40P01"}, expectedTransient: true, expectedUnsent: true},
+		{name: "postgres - admin shutdown", backend: resilience.Postgres, err: &pgconn.PgError{Code: "57P01"}, expectedTransient: true, expectedUnsent: true},
+		{name: "postgres - connection failure", backend: resilience.Postgres, err: &pgconn.PgError{Code: "08006"}, expectedTransient: true},
+		{name: "postgres - unique violation", backend: resilience.Postgres, err: &pgconn.PgError{Code: "23505"}},
+		{name: "postgres - no rows", backend: resilience.Postgres, err: sql.ErrNoRows},
+		{name: "postgres - cancelled", backend: resilience.Postgres, err: context.Canceled},
+		{name: "redis - connection refused", backend: resilience.Redis, err: connRefused, expectedTransient: true, expectedUnsent: true},
+		{name: "redis - connection reset", backend: resilience.Redis, err: connReset, expectedTransient: true},
+		{name: "redis - pool timeout", backend: resilience.Redis, err: redis.ErrPoolTimeout, expectedTransient: true, expectedUnsent: true},
+		{name: "redis - loading", backend: resilience.Redis, err: redisReply("LOADING Redis is loading the dataset in memory"), expectedTransient: true, expectedUnsent: true},
+		{name: "redis - read only replica", backend: resilience.Redis, err: redisReply("READONLY You can't write against a read only replica."), expectedTransient: true, expectedUnsent: true},
+		{name: "redis - wrong type", backend: resilience.Redis, err: redisReply("WRONGTYPE Operation against a key holding the wrong kind of value")},
+		{name: "redis - nil", backend: resilience.Redis, err: redis.Nil},
+		{name: "redis - client closed", backend: resilience.Redis, err: redis.ErrClosed},
+		{name: "other - plain error", backend: resilience.Redis, err: errors.New("boom")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			transient := tc.backend.Transient(tc.err)
+			unsent := tc.backend.Unsent(tc.err)
+
+			// Assert
+			assert.Equal(t, tc.expectedTransient, transient)
+			assert.Equal(t, tc.expectedUnsent, unsent)
+		})
+	}
+}
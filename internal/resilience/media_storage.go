@@ -0,0 +1,32 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/media"
+)
+
+// MediaStorage decorates a media.StorageRepo with a Policy.
+type MediaStorage struct {
+	storage media.StorageRepo
+	policy  *Policy
+}
+
+func NewMediaStorage(storage media.StorageRepo, policy *Policy) *MediaStorage {
+	return &MediaStorage{storage: storage, policy: policy}
+}
+
+// CreateMedia is only retried when it never reached PostgreSQL: a repeated insert would fail
+// on the primary key of the row it already stored.
+func (s *MediaStorage) CreateMedia(ctx context.Context, m domain.Media) error {
+	return exec(ctx, s.policy, "CreateMedia", false, func(ctx context.Context) error {
+		return s.storage.CreateMedia(ctx, m)
+	})
+}
+
+func (s *MediaStorage) SelectMediaByID(ctx context.Context, mediaID string) (*domain.Media, error) {
+	return call(ctx, s.policy, "SelectMediaByID", true, func(ctx context.Context) (*domain.Media, error) {
+		return s.storage.SelectMediaByID(ctx, mediaID)
+	})
+}
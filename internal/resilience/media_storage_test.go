@@ -0,0 +1,68 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/media/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMediaStorage_CreateMedia(t *testing.T) {
+	media := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		ContentType: "image/png",
+		Size:        2048,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried when the connection couldn't be opened",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().CreateMedia(gomock.Any(), media).Return(connRefused),
+					storage.EXPECT().CreateMedia(gomock.Any(), media).Return(nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Not retried when the insert may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateMedia(gomock.Any(), media).Return(connReset).Times(1)
+			},
+			expectedErr: connReset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewMediaStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			err := storage.CreateMedia(context.Background(), media)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
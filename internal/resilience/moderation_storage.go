@@ -0,0 +1,24 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/moderation"
+)
+
+// ModerationStorage decorates a moderation.DuplicateCounter with a Policy.
+type ModerationStorage struct {
+	storage moderation.DuplicateCounter
+	policy  *Policy
+}
+
+func NewModerationStorage(storage moderation.DuplicateCounter, policy *Policy) *ModerationStorage {
+	return &ModerationStorage{storage: storage, policy: policy}
+}
+
+func (s *ModerationStorage) CountRecentDuplicates(ctx context.Context, authorID, text, excludeTweetID string, since time.Time) (int, error) {
+	return call(ctx, s.policy, "CountRecentDuplicates", true, func(ctx context.Context) (int, error) {
+		return s.storage.CountRecentDuplicates(ctx, authorID, text, excludeTweetID, since)
+	})
+}
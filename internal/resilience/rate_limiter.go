@@ -0,0 +1,25 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/ratelimit"
+)
+
+// RateLimiter decorates a ratelimit.Limiter with a Policy. A request is only counted again
+// when the first attempt never reached the store.
+type RateLimiter struct {
+	limiter ratelimit.Limiter
+	policy  *Policy
+}
+
+func NewRateLimiter(limiter ratelimit.Limiter, policy *Policy) *RateLimiter {
+	return &RateLimiter{limiter: limiter, policy: policy}
+}
+
+func (l *RateLimiter) AllowRate(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	return call(ctx, l.policy, "AllowRate", false, func(ctx context.Context) (domain.RateLimitDecision, error) {
+		return l.limiter.AllowRate(ctx, key, limit)
+	})
+}
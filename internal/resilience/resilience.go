@@ -0,0 +1,215 @@
+// Package resilience wraps the repositories used by the services with per-operation timeouts,
+// jittered retries of transient errors and a circuit breaker per dependency, so a slow or
+// failing PostgreSQL or Redis fails requests fast instead of holding them.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultTimeout         = 2 * time.Second
+	defaultMaxRetries      = 2
+	defaultRetryBackoff    = 25 * time.Millisecond
+	defaultMaxRetryBackoff = 500 * time.Millisecond
+)
+
+// Config holds the limits of the calls to a dependency.
+type Config struct {
+	// Timeout bounds every attempt of an operation, unless OperationTimeouts has its own.
+	Timeout time.Duration
+	// OperationTimeouts overrides Timeout by operation, keyed by repository method name.
+	OperationTimeouts map[string]time.Duration
+	// MaxRetries is how many times an attempt failed by a transient error is retried.
+	MaxRetries int
+	// RetryBackoff is the base wait before a retry. It doubles with each retry, up to
+	// MaxRetryBackoff, and the actual wait is picked at random below it.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// Breaker configures the circuit breaker shared by every operation of the dependency.
+	Breaker circuitbreaker.Config
+}
+
+// Backend tells how the errors of a dependency are handled.
+type Backend struct {
+	// Name identifies the dependency in errors, metrics and traces.
+	Name string
+	// Transient reports whether err is a failure of the dependency itself (connection lost,
+	// overload, failover) rather than of the request. Only transient errors are retried and
+	// count towards opening the circuit breaker.
+	Transient func(err error) bool
+	// Unsent reports whether err happened before the operation reached the dependency, so
+	// retrying it is safe even when the operation isn't idempotent.
+	Unsent func(err error) bool
+}
+
+// Policy applies a Config to the calls to a Backend. A single Policy should be shared by
+// every decorator of the same dependency, so they share its circuit breaker.
+type Policy struct {
+	backend Backend
+	cfg     Config
+	breaker *circuitbreaker.Breaker
+}
+
+func NewPolicy(backend Backend, cfg Config) *Policy {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.MaxRetryBackoff <= 0 {
+		cfg.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+
+	return &Policy{
+		backend: backend,
+		cfg:     cfg,
+		breaker: circuitbreaker.New(cfg.Breaker),
+	}
+}
+
+// Available reports whether the circuit breaker lets calls through.
+func (p *Policy) Available() bool {
+	return p.breaker.State() != circuitbreaker.Open
+}
+
+// call runs fn, the operation op, with a timeout per attempt. Attempts failed by a transient
+// error are retried after a jittered backoff when the operation is idempotent, or when the
+// error shows it never reached the dependency.
+// While the circuit breaker is open fn isn't run and an error wrapping circuitbreaker.ErrOpen
+// is returned.
+func call[T any](ctx context.Context, p *Policy, op string, idempotent bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		result, err := try(ctx, p, op, fn)
+		if lastErr != nil && errors.Is(err, circuitbreaker.ErrOpen) {
+			// The failures so far opened the breaker; the last one says more about them.
+			return result, lastErr
+		}
+		if err == nil || attempt == p.cfg.MaxRetries || !p.retryable(ctx, err, idempotent) {
+			return result, err
+		}
+		lastErr = err
+
+		metrics.DependencyRetries.WithLabelValues(p.backend.Name, op).Inc()
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.String("dependency", p.backend.Name),
+			attribute.String("operation", op),
+			attribute.Int("attempt", attempt+1),
+			attribute.String("error", err.Error()),
+		))
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+// exec is call for operations without a result.
+func exec(ctx context.Context, p *Policy, op string, idempotent bool, fn func(ctx context.Context) error) error {
+	_, err := call(ctx, p, op, idempotent, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// stream runs fn, an operation that hands its results to a callback as they arrive, behind
+// the circuit breaker only: it has no timeout, as it lasts as long as the callback needs, and
+// it isn't retried, as the callback already saw part of the results.
+func stream(ctx context.Context, p *Policy, op string, fn func(ctx context.Context) error) error {
+	if err := p.allow(op); err != nil {
+		return err
+	}
+	err := fn(ctx)
+	p.record(ctx, err)
+	return err
+}
+
+// try runs fn once, behind the circuit breaker and within the timeout of op.
+func try[T any](ctx context.Context, p *Policy, op string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := p.allow(op); err != nil {
+		return zero, err
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.timeout(op))
+	defer cancel()
+
+	result, err := fn(attemptCtx)
+	p.record(ctx, err)
+	return result, err
+}
+
+// allow asks the circuit breaker for a call to op.
+func (p *Policy) allow(op string) error {
+	if err := p.breaker.Allow(); err != nil {
+		metrics.DependencyRejections.WithLabelValues(p.backend.Name).Inc()
+		return fmt.Errorf("%s %s: %w", p.backend.Name, op, err)
+	}
+	return nil
+}
+
+// record reports the outcome of an allowed call to the circuit breaker. Only transient errors
+// are failures of the dependency; calls cancelled by the caller tell nothing about it.
+func (p *Policy) record(ctx context.Context, err error) {
+	switch {
+	case err == nil:
+		p.breaker.Record(nil)
+	case ctx.Err() != nil:
+		p.breaker.Release()
+	case p.transient(err):
+		p.breaker.Record(err)
+	default:
+		// The dependency answered, the request was wrong (not found, constraint violated...).
+		p.breaker.Record(nil)
+	}
+}
+
+// transient reports whether err is a failure of the dependency. Attempts that ran out of time
+// are, no matter how the driver reports it.
+func (p *Policy) transient(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || p.backend.Transient(err)
+}
+
+// retryable reports whether an attempt failed by err may be retried.
+func (p *Policy) retryable(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil || errors.Is(err, circuitbreaker.ErrOpen) || !p.transient(err) {
+		return false
+	}
+	return idempotent || p.backend.Unsent(err)
+}
+
+// backoff returns the wait before retry number attempt+1: a random duration below the base
+// backoff doubled attempt times ("full jitter"), so the retries of concurrent callers spread out.
+func (p *Policy) backoff(attempt int) time.Duration {
+	ceiling := p.cfg.RetryBackoff
+	for i := 0; i < attempt && ceiling < p.cfg.MaxRetryBackoff; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, p.cfg.MaxRetryBackoff)
+	return rand.N(ceiling) + 1
+}
+
+// timeout returns the timeout of an attempt of op.
+func (p *Policy) timeout(op string) time.Duration {
+	if timeout, ok := p.cfg.OperationTimeouts[op]; ok && timeout > 0 {
+		return timeout
+	}
+	return p.cfg.Timeout
+}
@@ -0,0 +1,47 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := NewPolicy(Postgres, Config{RetryBackoff: 10 * time.Millisecond, MaxRetryBackoff: 25 * time.Millisecond})
+
+	testCases := []struct {
+		attempt         int
+		expectedCeiling time.Duration
+	}{
+		{attempt: 0, expectedCeiling: 10 * time.Millisecond},
+		{attempt: 1, expectedCeiling: 20 * time.Millisecond},
+		{attempt: 2, expectedCeiling: 25 * time.Millisecond},
+		{attempt: 70, expectedCeiling: 25 * time.Millisecond},
+	}
+
+	for _, tc := range testCases {
+		for range 100 {
+			// Act
+			backoff := policy.backoff(tc.attempt)
+
+			// Assert
+			assert.Positive(t, backoff)
+			assert.LessOrEqual(t, backoff, tc.expectedCeiling)
+		}
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	// Act
+	policy := NewPolicy(Redis, Config{})
+
+	// Assert
+	assert.Equal(t, Config{
+		Timeout:         2 * time.Second,
+		MaxRetries:      2,
+		RetryBackoff:    25 * time.Millisecond,
+		MaxRetryBackoff: 500 * time.Millisecond,
+	}, policy.cfg)
+	assert.True(t, policy.Available())
+}
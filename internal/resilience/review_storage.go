@@ -0,0 +1,33 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+)
+
+// ReviewStorage decorates a review.StorageRepo with a Policy.
+type ReviewStorage struct {
+	storage review.StorageRepo
+	policy  *Policy
+}
+
+func NewReviewStorage(storage review.StorageRepo, policy *Policy) *ReviewStorage {
+	return &ReviewStorage{storage: storage, policy: policy}
+}
+
+func (s *ReviewStorage) SelectHeldTweets(ctx context.Context, limit int) ([]domain.ModerationReview, error) {
+	return call(ctx, s.policy, "SelectHeldTweets", true, func(ctx context.Context) ([]domain.ModerationReview, error) {
+		return s.storage.SelectHeldTweets(ctx, limit)
+	})
+}
+
+// ResolveHeldTweet is only retried when it never reached PostgreSQL: once an attempt landed, a
+// retry would find the tweet already reviewed.
+func (s *ReviewStorage) ResolveHeldTweet(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision, reviewedAt time.Time) (*domain.Tweet, error) {
+	return call(ctx, s.policy, "ResolveHeldTweet", false, func(ctx context.Context) (*domain.Tweet, error) {
+		return s.storage.ResolveHeldTweet(ctx, tweetID, reviewerID, decision, reviewedAt)
+	})
+}
@@ -0,0 +1,65 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/review/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReviewStorage_ResolveHeldTweet(t *testing.T) {
+	tweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "hello", Moderation: domain.TweetVisible}
+	reviewerID := uuid.NewString()
+	reviewedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried when the connection couldn't be opened",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweet.ID, reviewerID, domain.ReviewApprove, reviewedAt).Return(nil, connRefused),
+					storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweet.ID, reviewerID, domain.ReviewApprove, reviewedAt).Return(&tweet, nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Not retried when the decision may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweet.ID, reviewerID, domain.ReviewApprove, reviewedAt).Return(nil, connReset).Times(1)
+			},
+			expectedErr: connReset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewReviewStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			resolved, err := storage.ResolveHeldTweet(context.Background(), tweet.ID, reviewerID, domain.ReviewApprove, reviewedAt)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &tweet, resolved)
+		})
+	}
+}
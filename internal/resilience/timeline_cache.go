@@ -0,0 +1,85 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+)
+
+// TimelineCache decorates a timeline.CacheRepository with a Policy.
+type TimelineCache struct {
+	cache  timeline.CacheRepository
+	policy *Policy
+}
+
+func NewTimelineCache(cache timeline.CacheRepository, policy *Policy) *TimelineCache {
+	return &TimelineCache{cache: cache, policy: policy}
+}
+
+// Available reports whether calls reach Redis, i.e. its circuit breaker isn't open.
+func (c *TimelineCache) Available() bool {
+	return c.policy.Available()
+}
+
+// pushResult holds the results of a push pipeline.
+type pushResult struct {
+	cached int
+	failed []string
+}
+
+// LPushTrimPipeline is only retried when nothing was sent: a lost reply may hide pushes that landed.
+func (c *TimelineCache) LPushTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error) {
+	result, err := call(ctx, c.policy, "LPushTrimPipeline", false, func(ctx context.Context) (pushResult, error) {
+		cached, failed, err := c.cache.LPushTrimPipeline(ctx, keys, maxLen, expiration, value)
+		return pushResult{cached: cached, failed: failed}, err
+	})
+	return result.cached, result.failed, err
+}
+
+func (c *TimelineCache) LPushUniqueTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error) {
+	result, err := call(ctx, c.policy, "LPushUniqueTrimPipeline", true, func(ctx context.Context) (pushResult, error) {
+		cached, failed, err := c.cache.LPushUniqueTrimPipeline(ctx, keys, maxLen, expiration, value)
+		return pushResult{cached: cached, failed: failed}, err
+	})
+	return result.cached, result.failed, err
+}
+
+func (c *TimelineCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return exec(ctx, c.policy, "Expire", true, func(ctx context.Context) error {
+		return c.cache.Expire(ctx, key, expiration)
+	})
+}
+
+func (c *TimelineCache) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return call(ctx, c.policy, "LRange", true, func(ctx context.Context) ([]string, error) {
+		return c.cache.LRange(ctx, key, start, stop)
+	})
+}
+
+func (c *TimelineCache) LPos(ctx context.Context, key string, value string) (int64, error) {
+	return call(ctx, c.policy, "LPos", true, func(ctx context.Context) (int64, error) {
+		return c.cache.LPos(ctx, key, value)
+	})
+}
+
+// LRem is idempotent only when every occurrence is removed (count 0): repeating a bounded
+// removal would remove more copies than asked.
+func (c *TimelineCache) LRem(ctx context.Context, key string, count int64, value interface{}) error {
+	return exec(ctx, c.policy, "LRem", count == 0, func(ctx context.Context) error {
+		return c.cache.LRem(ctx, key, count, value)
+	})
+}
+
+// PublishPipeline is only retried when nothing was sent, so subscribers don't get an event twice.
+func (c *TimelineCache) PublishPipeline(ctx context.Context, channels []string, message interface{}) error {
+	return exec(ctx, c.policy, "PublishPipeline", false, func(ctx context.Context) error {
+		return c.cache.PublishPipeline(ctx, channels, message)
+	})
+}
+
+func (c *TimelineCache) RebuildList(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+	return exec(ctx, c.policy, "RebuildList", true, func(ctx context.Context) error {
+		return c.cache.RebuildList(ctx, key, values, maxLen, expiration)
+	})
+}
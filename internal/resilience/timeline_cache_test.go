@@ -0,0 +1,123 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimelineCache(t *testing.T) {
+	const key = "timeline:1"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(cache *mocks.MockCacheRepository)
+		act         func(cache *resilience.TimelineCache) error
+		expectedErr error
+	}{
+		{
+			name: "LRem of every occurrence is retried",
+			setupMocks: func(cache *mocks.MockCacheRepository) {
+				gomock.InOrder(
+					cache.EXPECT().LRem(gomock.Any(), key, int64(0), "tweet").Return(connReset),
+					cache.EXPECT().LRem(gomock.Any(), key, int64(0), "tweet").Return(nil),
+				)
+			},
+			act: func(cache *resilience.TimelineCache) error {
+				return cache.LRem(context.Background(), key, 0, "tweet")
+			},
+		},
+		{
+			name: "LRem of some occurrences is not retried",
+			setupMocks: func(cache *mocks.MockCacheRepository) {
+				cache.EXPECT().LRem(gomock.Any(), key, int64(-1), "tweet").Return(connReset).Times(1)
+			},
+			act: func(cache *resilience.TimelineCache) error {
+				return cache.LRem(context.Background(), key, -1, "tweet")
+			},
+			expectedErr: connReset,
+		},
+		{
+			name: "LPushTrimPipeline is not retried after the pipeline was sent",
+			setupMocks: func(cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), []string{key}, int64(800), time.Hour, "tweet").
+					Return(0, []string{key}, connReset).
+					Times(1)
+			},
+			act: func(cache *resilience.TimelineCache) error {
+				_, _, err := cache.LPushTrimPipeline(context.Background(), []string{key}, 800, time.Hour, "tweet")
+				return err
+			},
+			expectedErr: connReset,
+		},
+		{
+			name: "LPushTrimPipeline is retried when the connection couldn't be opened",
+			setupMocks: func(cache *mocks.MockCacheRepository) {
+				gomock.InOrder(
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), []string{key}, int64(800), time.Hour, "tweet").
+						Return(0, []string{key}, connRefused),
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), []string{key}, int64(800), time.Hour, "tweet").
+						Return(1, nil, nil),
+				)
+			},
+			act: func(cache *resilience.TimelineCache) error {
+				cached, failed, err := cache.LPushTrimPipeline(context.Background(), []string{key}, 800, time.Hour, "tweet")
+				assert.Equal(t, 1, cached)
+				assert.Empty(t, failed)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMocks(mockCache)
+			cache := resilience.NewTimelineCache(mockCache, resilience.NewPolicy(resilience.Redis, testConfig))
+
+			// Act
+			err := tc.act(cache)
+
+			// Assert - the mock expectations check how many attempts were made.
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTimelineCache_Available(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockCache := mocks.NewMockCacheRepository(ctrl)
+
+	cfg := testConfig
+	cfg.MaxRetries = 1
+	cfg.Breaker = circuitbreaker.Config{FailureThreshold: 2, Cooldown: time.Hour}
+	cache := resilience.NewTimelineCache(mockCache, resilience.NewPolicy(resilience.Redis, cfg))
+
+	mockCache.EXPECT().LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, connReset).Times(2)
+	assert.True(t, cache.Available())
+
+	// Act
+	_, err := cache.LRange(context.Background(), "timeline:1", 0, 9)
+	_, errOpen := cache.LPos(context.Background(), "timeline:1", "tweet")
+
+	// Assert
+	assert.ErrorIs(t, err, connReset)
+	assert.ErrorIs(t, errOpen, circuitbreaker.ErrOpen)
+	assert.False(t, cache.Available())
+}
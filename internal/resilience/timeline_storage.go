@@ -0,0 +1,95 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+)
+
+// TimelineStorage decorates a timeline.StorageRepo with a Policy.
+type TimelineStorage struct {
+	storage timeline.StorageRepo
+	policy  *Policy
+}
+
+func NewTimelineStorage(storage timeline.StorageRepo, policy *Policy) *TimelineStorage {
+	return &TimelineStorage{storage: storage, policy: policy}
+}
+
+func (s *TimelineStorage) SelectFollowersByUserID(ctx context.Context, userID string) ([]string, error) {
+	return call(ctx, s.policy, "SelectFollowersByUserID", true, func(ctx context.Context) ([]string, error) {
+		return s.storage.SelectFollowersByUserID(ctx, userID)
+	})
+}
+
+func (s *TimelineStorage) ForEachFollowerBatch(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+	return stream(ctx, s.policy, "ForEachFollowerBatch", func(ctx context.Context) error {
+		return s.storage.ForEachFollowerBatch(ctx, userID, afterFollowerID, batchSize, fn)
+	})
+}
+
+// CreateFanOutJob isn't retried after a timeout: a job created by the lost attempt would
+// be reported as already existing, and the fan-out skipped.
+func (s *TimelineStorage) CreateFanOutJob(ctx context.Context, tweetID, authorID string) (bool, error) {
+	return call(ctx, s.policy, "CreateFanOutJob", false, func(ctx context.Context) (bool, error) {
+		return s.storage.CreateFanOutJob(ctx, tweetID, authorID)
+	})
+}
+
+func (s *TimelineStorage) UpdateFanOutJobCheckpoint(ctx context.Context, tweetID, lastFollowerID string, processedCount int) error {
+	return exec(ctx, s.policy, "UpdateFanOutJobCheckpoint", true, func(ctx context.Context) error {
+		return s.storage.UpdateFanOutJobCheckpoint(ctx, tweetID, lastFollowerID, processedCount)
+	})
+}
+
+func (s *TimelineStorage) FinishFanOutJob(ctx context.Context, tweetID, status, lastError string) error {
+	return exec(ctx, s.policy, "FinishFanOutJob", true, func(ctx context.Context) error {
+		return s.storage.FinishFanOutJob(ctx, tweetID, status, lastError)
+	})
+}
+
+// ClaimStaleFanOutJobs isn't retried after a timeout: jobs claimed by the lost attempt
+// already spent one of their attempts.
+func (s *TimelineStorage) ClaimStaleFanOutJobs(ctx context.Context, staleAfter time.Duration, maxAttempts, limit int) ([]domain.FanOutJob, error) {
+	return call(ctx, s.policy, "ClaimStaleFanOutJobs", false, func(ctx context.Context) ([]domain.FanOutJob, error) {
+		return s.storage.ClaimStaleFanOutJobs(ctx, staleAfter, maxAttempts, limit)
+	})
+}
+
+func (s *TimelineStorage) SelectFanOutJob(ctx context.Context, tweetID string) (*domain.FanOutJob, error) {
+	return call(ctx, s.policy, "SelectFanOutJob", true, func(ctx context.Context) (*domain.FanOutJob, error) {
+		return s.storage.SelectFanOutJob(ctx, tweetID)
+	})
+}
+
+func (s *TimelineStorage) SelectTweetsByTweetsIDs(ctx context.Context, tweetIDs []string) ([]domain.Tweet, error) {
+	return call(ctx, s.policy, "SelectTweetsByTweetsIDs", true, func(ctx context.Context) ([]domain.Tweet, error) {
+		return s.storage.SelectTweetsByTweetsIDs(ctx, tweetIDs)
+	})
+}
+
+func (s *TimelineStorage) SelectLastTweetsByUsersID(ctx context.Context, userIDs []string, cursor string, limit int) ([]domain.Tweet, error) {
+	return call(ctx, s.policy, "SelectLastTweetsByUsersID", true, func(ctx context.Context) ([]domain.Tweet, error) {
+		return s.storage.SelectLastTweetsByUsersID(ctx, userIDs, cursor, limit)
+	})
+}
+
+func (s *TimelineStorage) CountTweetsSince(ctx context.Context, userIDs []string, sinceTweetID string, limit int) (int, error) {
+	return call(ctx, s.policy, "CountTweetsSince", true, func(ctx context.Context) (int, error) {
+		return s.storage.CountTweetsSince(ctx, userIDs, sinceTweetID, limit)
+	})
+}
+
+func (s *TimelineStorage) SelectTweetIDsByUsersID(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+	return call(ctx, s.policy, "SelectTweetIDsByUsersID", true, func(ctx context.Context) ([]string, error) {
+		return s.storage.SelectTweetIDsByUsersID(ctx, userIDs, limit)
+	})
+}
+
+func (s *TimelineStorage) SelectHiddenAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	return call(ctx, s.policy, "SelectHiddenAuthorIDs", true, func(ctx context.Context) ([]string, error) {
+		return s.storage.SelectHiddenAuthorIDs(ctx, userID)
+	})
+}
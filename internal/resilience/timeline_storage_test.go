@@ -0,0 +1,248 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testConfig keeps the backoff short so retries don't slow the tests down.
+var testConfig = resilience.Config{
+	Timeout:         time.Second,
+	MaxRetries:      2,
+	RetryBackoff:    time.Millisecond,
+	MaxRetryBackoff: 2 * time.Millisecond,
+	Breaker:         circuitbreaker.Config{FailureThreshold: 10, Cooldown: time.Hour},
+}
+
+// Transient errors returned after the query was sent (connReset) and before (connRefused).
+var (
+	connReset   = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	connRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+)
+
+// blockUntilDone mimics a query that doesn't answer before its context expires.
+func blockUntilDone(ctx context.Context, _ string) ([]string, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTimelineStorage(t *testing.T) {
+	userID := uuid.NewString()
+	followers := []string{uuid.NewString()}
+	queryError := errors.New("syntax error at or near \"SELEC\"")
+
+	testCases := []struct {
+		name              string
+		config            func(cfg *resilience.Config)
+		setupMocks        func(storage *mocks.MockStorageRepo)
+		expectedFollowers []string
+		expectedErr       error
+	}{
+		{
+			name: "Success - First attempt",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followers, nil)
+			},
+			expectedFollowers: followers,
+		},
+		{
+			name: "Success - Transient error is retried",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(nil, connReset),
+					storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followers, nil),
+				)
+			},
+			expectedFollowers: followers,
+		},
+		{
+			name: "Failure - Transient error after every retry",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(nil, connReset).Times(3)
+			},
+			expectedErr: connReset,
+		},
+		{
+			name: "Failure - Non-transient error is not retried",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(nil, queryError).Times(1)
+			},
+			expectedErr: queryError,
+		},
+		{
+			name: "Failure - Every attempt times out",
+			config: func(cfg *resilience.Config) {
+				cfg.Timeout = time.Hour
+				cfg.OperationTimeouts = map[string]time.Duration{"SelectFollowersByUserID": 10 * time.Millisecond}
+				cfg.MaxRetries = 1
+			},
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).DoAndReturn(blockUntilDone).Times(2)
+			},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+
+			cfg := testConfig
+			if tc.config != nil {
+				tc.config(&cfg)
+			}
+			storage := resilience.NewTimelineStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, cfg))
+
+			// Act
+			followers, err := storage.SelectFollowersByUserID(context.Background(), userID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFollowers, followers)
+		})
+	}
+}
+
+func TestTimelineStorage_CircuitBreaker(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+
+	cfg := testConfig
+	cfg.MaxRetries = 1
+	cfg.Breaker = circuitbreaker.Config{FailureThreshold: 3, Cooldown: time.Hour}
+	policy := resilience.NewPolicy(resilience.Postgres, cfg)
+	storage := resilience.NewTimelineStorage(mockStorage, policy)
+
+	// Non-transient errors mean PostgreSQL answered: they don't open the breaker.
+	mockStorage.EXPECT().SelectFanOutJob(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid input syntax for type uuid")).Times(5)
+	for range 5 {
+		_, err := storage.SelectFanOutJob(context.Background(), "not-an-id")
+		require.Error(t, err)
+	}
+	require.True(t, policy.Available())
+
+	// Every attempt counts: two calls with one retry each open the breaker.
+	mockStorage.EXPECT().SelectFollowersByUserID(gomock.Any(), gomock.Any()).Return(nil, connReset).Times(3)
+	for range 2 {
+		_, err := storage.SelectFollowersByUserID(context.Background(), uuid.NewString())
+		require.ErrorIs(t, err, connReset)
+	}
+
+	// Act - the open breaker rejects calls without reaching PostgreSQL.
+	_, err := storage.CountTweetsSince(context.Background(), nil, uuid.NewString(), 10)
+
+	// Assert
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.EqualError(t, err, "postgres CountTweetsSince: circuit breaker is open")
+	assert.False(t, policy.Available())
+}
+
+func TestTimelineStorage_CallerCancelled(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+
+	cfg := testConfig
+	cfg.Breaker = circuitbreaker.Config{FailureThreshold: 1, Cooldown: time.Hour}
+	policy := resilience.NewPolicy(resilience.Postgres, cfg)
+	storage := resilience.NewTimelineStorage(mockStorage, policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockStorage.EXPECT().
+		SelectTweetIDsByUsersID(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ []string, _ int) ([]string, error) {
+			cancel()
+			return nil, ctx.Err()
+		}).
+		Times(1)
+
+	// Act
+	_, err := storage.SelectTweetIDsByUsersID(ctx, nil, 10)
+
+	// Assert - not retried, and the dependency isn't blamed.
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, policy.Available())
+}
+
+func TestTimelineStorage_NotRetried(t *testing.T) {
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+
+	testCases := []struct {
+		name       string
+		setupMocks func(storage *mocks.MockStorageRepo)
+		act        func(storage *resilience.TimelineStorage) error
+	}{
+		{
+			name: "CreateFanOutJob after the query was sent",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(false, connReset).Times(1)
+			},
+			act: func(storage *resilience.TimelineStorage) error {
+				_, err := storage.CreateFanOutJob(context.Background(), tweetID, authorID)
+				return err
+			},
+		},
+		{
+			name: "ClaimStaleFanOutJobs after the query was sent",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().ClaimStaleFanOutJobs(gomock.Any(), time.Minute, 5, 10).Return(nil, connReset).Times(1)
+			},
+			act: func(storage *resilience.TimelineStorage) error {
+				_, err := storage.ClaimStaleFanOutJobs(context.Background(), time.Minute, 5, 10)
+				return err
+			},
+		},
+		{
+			name: "ForEachFollowerBatch, a stream already seen by the callback",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _, _ string, _ int, _ func([]string) error) error {
+						_, hasDeadline := ctx.Deadline()
+						assert.False(t, hasDeadline, "streams have no timeout")
+						return connReset
+					}).
+					Times(1)
+			},
+			act: func(storage *resilience.TimelineStorage) error {
+				return storage.ForEachFollowerBatch(context.Background(), authorID, "", 500, func([]string) error { return nil })
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewTimelineStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			err := tc.act(storage)
+
+			// Assert
+			assert.ErrorIs(t, err, connReset)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl"
+)
+
+// UnfurlStorage decorates an unfurl.StorageRepo with a Policy.
+type UnfurlStorage struct {
+	storage unfurl.StorageRepo
+	policy  *Policy
+}
+
+func NewUnfurlStorage(storage unfurl.StorageRepo, policy *Policy) *UnfurlStorage {
+	return &UnfurlStorage{storage: storage, policy: policy}
+}
+
+func (s *UnfurlStorage) SelectLinkPreviews(ctx context.Context, urls []string) ([]domain.LinkPreview, error) {
+	return call(ctx, s.policy, "SelectLinkPreviews", true, func(ctx context.Context) ([]domain.LinkPreview, error) {
+		return s.storage.SelectLinkPreviews(ctx, urls)
+	})
+}
+
+// UpsertLinkPreview is always retried: storing the same preview twice leaves the same row.
+func (s *UnfurlStorage) UpsertLinkPreview(ctx context.Context, preview domain.LinkPreview) error {
+	return exec(ctx, s.policy, "UpsertLinkPreview", true, func(ctx context.Context) error {
+		return s.storage.UpsertLinkPreview(ctx, preview)
+	})
+}
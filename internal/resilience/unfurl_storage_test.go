@@ -0,0 +1,65 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnfurlStorage_UpsertLinkPreview(t *testing.T) {
+	preview := domain.LinkPreview{
+		URL:       "https://go.dev",
+		Title:     "The Go Programming Language",
+		FetchedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried even when the upsert may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().UpsertLinkPreview(gomock.Any(), preview).Return(connReset),
+					storage.EXPECT().UpsertLinkPreview(gomock.Any(), preview).Return(nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Gives up after the retries",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), preview).Return(connRefused).Times(3)
+			},
+			expectedErr: connRefused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewUnfurlStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			err := storage.UpsertLinkPreview(context.Background(), preview)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+)
+
+// UserStorage decorates a user.StorageRepo with a Policy. The inserts are only retried when
+// they never reached PostgreSQL.
+type UserStorage struct {
+	storage user.StorageRepo
+	policy  *Policy
+}
+
+func NewUserStorage(storage user.StorageRepo, policy *Policy) *UserStorage {
+	return &UserStorage{storage: storage, policy: policy}
+}
+
+func (s *UserStorage) CreateRelation(ctx context.Context, follow domain.FollowUser) error {
+	return exec(ctx, s.policy, "CreateRelation", false, func(ctx context.Context) error {
+		return s.storage.CreateRelation(ctx, follow)
+	})
+}
+
+func (s *UserStorage) ExistsBlockBetween(ctx context.Context, userID, otherUserID string) (bool, error) {
+	return call(ctx, s.policy, "ExistsBlockBetween", true, func(ctx context.Context) (bool, error) {
+		return s.storage.ExistsBlockBetween(ctx, userID, otherUserID)
+	})
+}
+
+// CreateBlock is retried even when it may have landed: blocking again only removes the
+// follows again, which are already gone.
+func (s *UserStorage) CreateBlock(ctx context.Context, block domain.BlockUser) error {
+	return exec(ctx, s.policy, "CreateBlock", true, func(ctx context.Context) error {
+		return s.storage.CreateBlock(ctx, block)
+	})
+}
+
+func (s *UserStorage) DeleteBlock(ctx context.Context, block domain.BlockUser) error {
+	return exec(ctx, s.policy, "DeleteBlock", true, func(ctx context.Context) error {
+		return s.storage.DeleteBlock(ctx, block)
+	})
+}
+
+func (s *UserStorage) CreateMute(ctx context.Context, mute domain.MuteUser) error {
+	return exec(ctx, s.policy, "CreateMute", true, func(ctx context.Context) error {
+		return s.storage.CreateMute(ctx, mute)
+	})
+}
+
+func (s *UserStorage) DeleteMute(ctx context.Context, mute domain.MuteUser) error {
+	return exec(ctx, s.policy, "DeleteMute", true, func(ctx context.Context) error {
+		return s.storage.DeleteMute(ctx, mute)
+	})
+}
+
+// createTweetResult holds the results of CreateTweet.
+type createTweetResult struct {
+	tweet   domain.Tweet
+	created bool
+}
+
+// CreateTweet is not retried when the insert may have landed, even though the insert itself
+// is safe to repeat: the retry would find the tweet and report it as not created, so the
+// caller would treat the first publication as a replay and skip the fan-out.
+func (s *UserStorage) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
+	result, err := call(ctx, s.policy, "CreateTweet", false, func(ctx context.Context) (createTweetResult, error) {
+		created, ok, err := s.storage.CreateTweet(ctx, tweet)
+		return createTweetResult{tweet: created, created: ok}, err
+	})
+	return result.tweet, result.created, err
+}
+
+func (s *UserStorage) SelectTweetByID(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return call(ctx, s.policy, "SelectTweetByID", true, func(ctx context.Context) (*domain.Tweet, error) {
+		return s.storage.SelectTweetByID(ctx, tweetID)
+	})
+}
+
+// UpdateTweetText is only retried when it never reached PostgreSQL: a repeated edit would store
+// the new text as a revision of itself.
+func (s *UserStorage) UpdateTweetText(ctx context.Context, tweetID, authorID, text string, urls []domain.URLEntity, editedAt, editableSince time.Time) (*domain.Tweet, error) {
+	return call(ctx, s.policy, "UpdateTweetText", false, func(ctx context.Context) (*domain.Tweet, error) {
+		return s.storage.UpdateTweetText(ctx, tweetID, authorID, text, urls, editedAt, editableSince)
+	})
+}
+
+func (s *UserStorage) SelectTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	return call(ctx, s.policy, "SelectTweetRevisions", true, func(ctx context.Context) ([]domain.TweetRevision, error) {
+		return s.storage.SelectTweetRevisions(ctx, tweetID)
+	})
+}
+
+func (s *UserStorage) SelectMediaByIDs(ctx context.Context, mediaIDs []string) ([]domain.Media, error) {
+	return call(ctx, s.policy, "SelectMediaByIDs", true, func(ctx context.Context) ([]domain.Media, error) {
+		return s.storage.SelectMediaByIDs(ctx, mediaIDs)
+	})
+}
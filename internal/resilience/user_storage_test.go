@@ -0,0 +1,183 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/resilience"
+	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserStorage_CreateTweet(t *testing.T) {
+	tweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "hello"}
+	serializationFailure := &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	uniqueViolation := &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint"}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried when the connection couldn't be opened",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(domain.Tweet{}, false, connRefused),
+					storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(tweet, true, nil),
+				)
+			},
+		},
+		{
+			name: "Success - Retried after a rolled back serialization failure",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(domain.Tweet{}, false, serializationFailure),
+					storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(tweet, true, nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Not retried when the insert may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(domain.Tweet{}, false, connReset).Times(1)
+			},
+			expectedErr: connReset,
+		},
+		{
+			name: "Failure - Constraint violation is not retried",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateTweet(gomock.Any(), tweet).Return(domain.Tweet{}, false, uniqueViolation).Times(1)
+			},
+			expectedErr: uniqueViolation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewUserStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			stored, created, err := storage.CreateTweet(context.Background(), tweet)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tweet, stored)
+			assert.True(t, created)
+		})
+	}
+}
+
+func TestUserStorage_UpdateTweetText(t *testing.T) {
+	tweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "hello"}
+	editedAt := time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC)
+	editableSince := editedAt.Add(-30 * time.Minute)
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried when the connection couldn't be opened",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().UpdateTweetText(gomock.Any(), tweet.ID, tweet.UserID, tweet.Text, nil, editedAt, editableSince).Return(nil, connRefused),
+					storage.EXPECT().UpdateTweetText(gomock.Any(), tweet.ID, tweet.UserID, tweet.Text, nil, editedAt, editableSince).Return(&tweet, nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Not retried when the edit may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, tweet.UserID, tweet.Text, nil, editedAt, editableSince).
+					Return(nil, connReset).
+					Times(1)
+			},
+			expectedErr: connReset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewUserStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			edited, err := storage.UpdateTweetText(context.Background(), tweet.ID, tweet.UserID, tweet.Text, nil, editedAt, editableSince)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &tweet, edited)
+		})
+	}
+}
+
+func TestUserStorage_CreateBlock(t *testing.T) {
+	block := domain.BlockUser{BlockerID: uuid.NewString(), BlockedID: uuid.NewString()}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - Retried even when the block may have landed",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				gomock.InOrder(
+					storage.EXPECT().CreateBlock(gomock.Any(), block).Return(connReset),
+					storage.EXPECT().CreateBlock(gomock.Any(), block).Return(nil),
+				)
+			},
+		},
+		{
+			name: "Failure - Gives up after the retries",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateBlock(gomock.Any(), block).Return(connRefused).MinTimes(2)
+			},
+			expectedErr: connRefused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			storage := resilience.NewUserStorage(mockStorage, resilience.NewPolicy(resilience.Postgres, testConfig))
+
+			// Act
+			err := storage.CreateBlock(context.Background(), block)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
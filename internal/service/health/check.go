@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Check pings every dependency concurrently, each bounded by Timeout, and reports their status
+// and latency. The report is unavailable when any dependency failed and draining once Drain
+// was called, whatever the dependencies say.
+func (s *Service) Check(ctx context.Context) domain.HealthReport {
+	report := domain.HealthReport{
+		Status: domain.HealthOK,
+		Checks: make(map[string]domain.DependencyHealth, len(s.Dependencies)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, dependency := range s.Dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			check := s.ping(ctx, dependency)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = check
+			if check.Status != domain.HealthOK {
+				report.Status = domain.HealthUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Draining() {
+		report.Status = domain.HealthDraining
+	}
+
+	return report
+}
+
+func (s *Service) ping(ctx context.Context, dependency Pinger) domain.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dependency.Ping(ctx)
+	check := domain.DependencyHealth{
+		Status:    domain.HealthOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		check.Status = domain.HealthUnavailable
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+// Drain marks the instance as shutting down, so readiness checks fail from now on and load
+// balancers stop sending new requests while the in-flight ones finish.
+func (s *Service) Drain() {
+	s.draining.Store(true)
+}
+
+// Draining reports whether Drain was called.
+func (s *Service) Draining() bool {
+	return s.draining.Load()
+}
@@ -0,0 +1,98 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/health"
+	"github.com/renzonaitor/tweet-api/internal/service/health/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name             string
+		setupMocks       func(postgres, redis *mocks.MockPinger)
+		drain            bool
+		expectedStatus   string
+		expectedStatuses map[string]string
+		expectedErrors   map[string]string
+	}{
+		{
+			name: "Success - every dependency answers",
+			setupMocks: func(postgres, redis *mocks.MockPinger) {
+				postgres.EXPECT().Ping(gomock.Any()).Return(nil)
+				redis.EXPECT().Ping(gomock.Any()).Return(nil)
+			},
+			expectedStatus:   domain.HealthOK,
+			expectedStatuses: map[string]string{"postgres": domain.HealthOK, "redis": domain.HealthOK},
+		},
+		{
+			name: "Failure - a dependency fails",
+			setupMocks: func(postgres, redis *mocks.MockPinger) {
+				postgres.EXPECT().Ping(gomock.Any()).Return(nil)
+				redis.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+			},
+			expectedStatus:   domain.HealthUnavailable,
+			expectedStatuses: map[string]string{"postgres": domain.HealthOK, "redis": domain.HealthUnavailable},
+			expectedErrors:   map[string]string{"redis": "connection refused"},
+		},
+		{
+			name: "Failure - a dependency hangs past the timeout",
+			setupMocks: func(postgres, redis *mocks.MockPinger) {
+				postgres.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				})
+				redis.EXPECT().Ping(gomock.Any()).Return(nil)
+			},
+			expectedStatus:   domain.HealthUnavailable,
+			expectedStatuses: map[string]string{"postgres": domain.HealthUnavailable, "redis": domain.HealthOK},
+			expectedErrors:   map[string]string{"postgres": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "Draining - reported even when dependencies answer",
+			setupMocks: func(postgres, redis *mocks.MockPinger) {
+				postgres.EXPECT().Ping(gomock.Any()).Return(nil)
+				redis.EXPECT().Ping(gomock.Any()).Return(nil)
+			},
+			drain:            true,
+			expectedStatus:   domain.HealthDraining,
+			expectedStatuses: map[string]string{"postgres": domain.HealthOK, "redis": domain.HealthOK},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			postgres := mocks.NewMockPinger(ctrl)
+			redis := mocks.NewMockPinger(ctrl)
+			tc.setupMocks(postgres, redis)
+
+			service := health.NewService(map[string]health.Pinger{
+				"postgres": postgres,
+				"redis":    redis,
+			}, 20*time.Millisecond)
+			if tc.drain {
+				service.Drain()
+			}
+
+			// Act
+			report := service.Check(context.Background())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, report.Status)
+			assert.Equal(t, tc.drain, service.Draining())
+			for name, status := range tc.expectedStatuses {
+				assert.Equal(t, status, report.Checks[name].Status, name)
+				assert.GreaterOrEqual(t, report.Checks[name].LatencyMS, 0.0)
+				assert.Equal(t, tc.expectedErrors[name], report.Checks[name].Error, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/health_mocks.go -package=mocks
+
+// Pinger is a dependency that can be checked, e.g. a database or cache repository.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// defaultTimeout bounds each ping when no timeout is configured.
+const defaultTimeout = time.Second
+
+// Service checks the dependencies of the instance and tracks whether it's draining.
+type Service struct {
+	Dependencies map[string]Pinger
+	Timeout      time.Duration
+
+	draining atomic.Bool
+}
+
+func NewService(dependencies map[string]Pinger, timeout time.Duration) *Service {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Service{
+		Dependencies: dependencies,
+		Timeout:      timeout,
+	}
+}
@@ -0,0 +1,39 @@
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/service/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewService(t *testing.T) {
+	testCases := []struct {
+		name            string
+		timeout         time.Duration
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "Keeps the configured timeout",
+			timeout:         500 * time.Millisecond,
+			expectedTimeout: 500 * time.Millisecond,
+		},
+		{
+			name:            "Defaults a missing timeout",
+			expectedTimeout: time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			service := health.NewService(map[string]health.Pinger{}, tc.timeout)
+
+			// Assert
+			assert.NotNil(t, service)
+			assert.Equal(t, tc.expectedTimeout, service.Timeout)
+			assert.False(t, service.Draining())
+		})
+	}
+}
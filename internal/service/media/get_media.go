@@ -0,0 +1,38 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GetMedia returns the metadata of mediaID and its content, which the caller must close.
+// Media attached to a held or removed tweet isn't found.
+func (s Service) GetMedia(ctx context.Context, mediaID string) (domain.Media, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "media.GetMedia", trace.WithAttributes(
+		attribute.String("media.id", mediaID),
+	))
+	defer span.End()
+
+	media, err := s.Storage.SelectMediaByID(ctx, mediaID)
+	if err != nil {
+		return domain.Media{}, nil, err
+	}
+	if media == nil {
+		return domain.Media{}, nil, ErrMediaNotFound
+	}
+
+	content, err := s.Blobs.Get(ctx, media.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			return domain.Media{}, nil, ErrMediaNotFound
+		}
+		return domain.Media{}, nil, err
+	}
+
+	return *media, content, nil
+}
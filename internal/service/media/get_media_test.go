@@ -0,0 +1,92 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/renzonaitor/tweet-api/internal/service/media/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMedia(t *testing.T) {
+	stored := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		ContentType: "image/png",
+		Size:        5,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore)
+		expectedContent string
+		expectedErr     error
+	}{
+		{
+			name: "Success - returns the metadata and the content",
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				storage.EXPECT().SelectMediaByID(gomock.Any(), stored.ID).Return(&stored, nil)
+				blobs.EXPECT().Get(gomock.Any(), stored.ID).Return(io.NopCloser(strings.NewReader("image")), nil)
+			},
+			expectedContent: "image",
+		},
+		{
+			name: "Failure - unknown media",
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				storage.EXPECT().SelectMediaByID(gomock.Any(), stored.ID).Return(nil, nil)
+			},
+			expectedErr: media.ErrMediaNotFound,
+		},
+		{
+			name: "Failure - missing blob",
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				storage.EXPECT().SelectMediaByID(gomock.Any(), stored.ID).Return(&stored, nil)
+				blobs.EXPECT().Get(gomock.Any(), stored.ID).Return(nil, domain.ErrBlobNotFound)
+			},
+			expectedErr: media.ErrMediaNotFound,
+		},
+		{
+			name: "Failure - database error",
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				storage.EXPECT().SelectMediaByID(gomock.Any(), stored.ID).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockBlobs := mocks.NewMockBlobStore(ctrl)
+			tc.setupMocks(mockStorage, mockBlobs)
+			service := media.NewService(mockStorage, mockBlobs, media.Config{})
+
+			// Act
+			got, content, err := service.GetMedia(context.Background(), stored.ID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, stored, got)
+			read, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, string(read))
+		})
+	}
+}
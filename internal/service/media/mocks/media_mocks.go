@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/media_mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorageRepo is a mock of StorageRepo interface.
+type MockStorageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageRepoMockRecorder is the mock recorder for MockStorageRepo.
+type MockStorageRepoMockRecorder struct {
+	mock *MockStorageRepo
+}
+
+// NewMockStorageRepo creates a new mock instance.
+func NewMockStorageRepo(ctrl *gomock.Controller) *MockStorageRepo {
+	mock := &MockStorageRepo{ctrl: ctrl}
+	mock.recorder = &MockStorageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateMedia mocks base method.
+func (m *MockStorageRepo) CreateMedia(ctx context.Context, media domain.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedia", ctx, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockStorageRepoMockRecorder) CreateMedia(ctx, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockStorageRepo)(nil).CreateMedia), ctx, media)
+}
+
+// SelectMediaByID mocks base method.
+func (m *MockStorageRepo) SelectMediaByID(ctx context.Context, mediaID string) (*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectMediaByID", ctx, mediaID)
+	ret0, _ := ret[0].(*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectMediaByID indicates an expected call of SelectMediaByID.
+func (mr *MockStorageRepoMockRecorder) SelectMediaByID(ctx, mediaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectMediaByID", reflect.TypeOf((*MockStorageRepo)(nil).SelectMediaByID), ctx, mediaID)
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStoreMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStore)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(ctx context.Context, key, contentType string, data io.Reader, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, contentType, data, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(ctx, key, contentType, data, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), ctx, key, contentType, data, size)
+}
@@ -0,0 +1,80 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/media_mocks.go -package=mocks
+type StorageRepo interface {
+	CreateMedia(ctx context.Context, media domain.Media) error
+	SelectMediaByID(ctx context.Context, mediaID string) (*domain.Media, error)
+}
+
+// BlobStore keeps the bytes of the media. Get returns domain.ErrBlobNotFound when key holds
+// no blob.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultMaxSize = 5 << 20
+)
+
+// defaultAllowedTypes are the content types accepted when Config.AllowedTypes is empty.
+var defaultAllowedTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "video/mp4"}
+
+var (
+	// ErrMediaTooLarge is returned when an upload is bigger than Config.MaxSize.
+	ErrMediaTooLarge = errors.New("media is too large")
+	// ErrEmptyMedia is returned when an upload has no content.
+	ErrEmptyMedia = errors.New("media is empty")
+	// ErrUnsupportedMediaType is returned when the content of an upload isn't one of
+	// Config.AllowedTypes, or doesn't match the declared content type.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrMediaNotFound is returned when a media doesn't exist.
+	ErrMediaNotFound = errors.New("media not found")
+)
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/service/media")
+
+// Config holds the tunable limits of the media service.
+type Config struct {
+	// MaxSize is the largest upload accepted, in bytes.
+	MaxSize int64
+	// AllowedTypes are the content types accepted, detected from the uploaded bytes.
+	AllowedTypes []string
+}
+
+// Service depends on the interfaces, not concrete types.
+type Service struct {
+	Storage StorageRepo
+	Blobs   BlobStore
+	Config  Config
+	// Clock stamps the uploads. Defaults to the system clock.
+	Clock clock.Clock
+}
+
+func NewService(storage StorageRepo, blobs BlobStore, cfg Config) *Service {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	if len(cfg.AllowedTypes) == 0 {
+		cfg.AllowedTypes = defaultAllowedTypes
+	}
+
+	return &Service{
+		Storage: storage,
+		Blobs:   blobs,
+		Config:  cfg,
+		Clock:   clock.System,
+	}
+}
@@ -0,0 +1,27 @@
+package media_test
+
+import (
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/renzonaitor/tweet-api/internal/service/media/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockBlobs := mocks.NewMockBlobStore(ctrl)
+
+	// Act
+	service := media.NewService(mockStorage, mockBlobs, media.Config{})
+
+	// Assert
+	assert.NotNil(t, service)
+	assert.Equal(t, mockStorage, service.Storage)
+	assert.Equal(t, mockBlobs, service.Blobs)
+	assert.Equal(t, int64(5<<20), service.Config.MaxSize, "MaxSize should default to 5 MiB")
+	assert.Equal(t, []string{"image/jpeg", "image/png", "image/gif", "image/webp", "video/mp4"}, service.Config.AllowedTypes)
+}
@@ -0,0 +1,87 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UploadMedia stores the content read from data as a new media of userID, not attached to any
+// tweet yet. The content type is detected from the bytes, so a client can't smuggle another
+// format under an allowed type; contentType, when set, must match it.
+func (s Service) UploadMedia(ctx context.Context, userID, contentType string, data io.Reader) (domain.Media, error) {
+	ctx, span := tracer.Start(ctx, "media.UploadMedia", trace.WithAttributes(
+		attribute.String("user.id", userID),
+	))
+	defer span.End()
+
+	// One byte past the limit is enough to tell the upload is too large.
+	content, err := io.ReadAll(io.LimitReader(data, s.Config.MaxSize+1))
+	if err != nil {
+		return domain.Media{}, fmt.Errorf("error reading media: %w", err)
+	}
+	if int64(len(content)) > s.Config.MaxSize {
+		return domain.Media{}, fmt.Errorf("%w: the limit is %d bytes", ErrMediaTooLarge, s.Config.MaxSize)
+	}
+	if len(content) == 0 {
+		return domain.Media{}, ErrEmptyMedia
+	}
+
+	detected, err := s.detectContentType(content, contentType)
+	if err != nil {
+		return domain.Media{}, err
+	}
+
+	media := domain.Media{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		ContentType: detected,
+		Size:        int64(len(content)),
+		CreatedAt:   s.Clock.Now().UTC().Truncate(time.Millisecond),
+	}
+	span.SetAttributes(
+		attribute.String("media.id", media.ID),
+		attribute.String("media.content_type", media.ContentType),
+		attribute.Int64("media.size", media.Size),
+	)
+
+	// The blob goes first: a media row always points to stored bytes.
+	if err = s.Blobs.Put(ctx, media.ID, media.ContentType, bytes.NewReader(content), media.Size); err != nil {
+		return domain.Media{}, fmt.Errorf("error storing media: %w", err)
+	}
+	if err = s.Storage.CreateMedia(ctx, media); err != nil {
+		// Best effort: a blob left behind is never served, as no row points to it.
+		_ = s.Blobs.Delete(context.WithoutCancel(ctx), media.ID)
+		return domain.Media{}, err
+	}
+
+	return media, nil
+}
+
+// detectContentType sniffs the type of content and checks it is allowed and matches the
+// declared one.
+func (s Service) detectContentType(content []byte, declared string) (string, error) {
+	// DetectContentType may add parameters, e.g. "text/plain; charset=utf-8".
+	detected, _, err := mime.ParseMediaType(http.DetectContentType(content))
+	if err != nil || !slices.Contains(s.Config.AllowedTypes, detected) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedMediaType, http.DetectContentType(content))
+	}
+
+	if declared != "" {
+		declaredType, _, err := mime.ParseMediaType(declared)
+		if err != nil || declaredType != detected {
+			return "", fmt.Errorf("%w: declared %s but the content is %s", ErrUnsupportedMediaType, declared, detected)
+		}
+	}
+	return detected, nil
+}
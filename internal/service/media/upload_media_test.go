@@ -0,0 +1,167 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/media"
+	"github.com/renzonaitor/tweet-api/internal/service/media/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUploadMedia(t *testing.T) {
+	userID := uuid.NewString()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 123456789, time.UTC)
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("p", 8)
+	gif := "GIF89a" + strings.Repeat("g", 4)
+	dbError := errors.New("database connection lost")
+
+	// isMedia matches the media stored by the upload of content.
+	isMedia := func(contentType string, size int64) gomock.Matcher {
+		return gomock.Cond(func(m domain.Media) bool {
+			return uuid.Validate(m.ID) == nil && m.UserID == userID && m.ContentType == contentType &&
+				m.Size == size && m.CreatedAt.Equal(now.Truncate(time.Millisecond))
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		content       string
+		setupMocks    func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore)
+		expectedType  string
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name:        "Success - stores the blob then the metadata",
+			contentType: "image/png",
+			content:     png,
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				gomock.InOrder(
+					blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/png", gomock.Any(), int64(len(png))).
+						DoAndReturn(func(_ context.Context, _, _ string, data io.Reader, _ int64) error {
+							stored, err := io.ReadAll(data)
+							require.NoError(t, err)
+							assert.Equal(t, png, string(stored))
+							return nil
+						}),
+					storage.EXPECT().CreateMedia(gomock.Any(), isMedia("image/png", int64(len(png)))).Return(nil),
+				)
+			},
+			expectedType: "image/png",
+		},
+		{
+			name:        "Success - missing content type uses the detected one",
+			contentType: "",
+			content:     gif,
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/gif", gomock.Any(), int64(len(gif))).Return(nil)
+				storage.EXPECT().CreateMedia(gomock.Any(), isMedia("image/gif", int64(len(gif)))).Return(nil)
+			},
+			expectedType: "image/gif",
+		},
+		{
+			name:        "Failure - larger than MaxSize",
+			contentType: "image/png",
+			content:     png + "x",
+			setupMocks:  func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {},
+			expectedErr: media.ErrMediaTooLarge,
+		},
+		{
+			name:        "Failure - empty content",
+			contentType: "image/png",
+			content:     "",
+			setupMocks:  func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {},
+			expectedErr: media.ErrEmptyMedia,
+		},
+		{
+			name:          "Failure - content type not allowed",
+			contentType:   "text/plain",
+			content:       "just some text",
+			setupMocks:    func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {},
+			expectedErr:   media.ErrUnsupportedMediaType,
+			errorContains: "text/plain",
+		},
+		{
+			name:          "Failure - declared type doesn't match the content",
+			contentType:   "image/png",
+			content:       gif,
+			setupMocks:    func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {},
+			expectedErr:   media.ErrUnsupportedMediaType,
+			errorContains: "declared image/png but the content is image/gif",
+		},
+		{
+			name:        "Failure - blob store error stores no metadata",
+			contentType: "image/png",
+			content:     png,
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/png", gomock.Any(), int64(len(png))).Return(errors.New("disk full"))
+			},
+			errorContains: "error storing media: disk full",
+		},
+		{
+			name:        "Failure - database error deletes the blob",
+			contentType: "image/png",
+			content:     png,
+			setupMocks: func(storage *mocks.MockStorageRepo, blobs *mocks.MockBlobStore) {
+				var key string
+				blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/png", gomock.Any(), int64(len(png))).
+					DoAndReturn(func(_ context.Context, k, _ string, _ io.Reader, _ int64) error {
+						key = k
+						return nil
+					})
+				storage.EXPECT().CreateMedia(gomock.Any(), gomock.Any()).Return(dbError)
+				blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, k string) error {
+						assert.Equal(t, key, k)
+						return nil
+					})
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockBlobs := mocks.NewMockBlobStore(ctrl)
+			tc.setupMocks(mockStorage, mockBlobs)
+
+			service := media.NewService(mockStorage, mockBlobs, media.Config{MaxSize: int64(len(png))})
+			service.Clock = clock.Fixed(now)
+
+			// Act
+			uploaded, err := service.UploadMedia(context.Background(), userID, tc.contentType, strings.NewReader(tc.content))
+
+			// Assert
+			if tc.expectedErr != nil || tc.errorContains != "" {
+				require.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(t, err, tc.expectedErr)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, uuid.Validate(uploaded.ID))
+			assert.Equal(t, userID, uploaded.UserID)
+			assert.Equal(t, tc.expectedType, uploaded.ContentType)
+			assert.Equal(t, int64(len(tc.content)), uploaded.Size)
+			assert.Equal(t, now.Truncate(time.Millisecond), uploaded.CreatedAt)
+		})
+	}
+}
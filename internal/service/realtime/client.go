@@ -0,0 +1,20 @@
+package realtime
+
+import (
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Client is a single real-time connection owned by a user.
+type Client struct {
+	UserID string
+
+	events chan domain.Event
+	// channels maps each subscribed Pub/Sub channel to the topic name the client used.
+	// It is guarded by the service mutex.
+	channels map[string]string
+}
+
+// Events returns the stream of events for the client. It is closed on Disconnect.
+func (c *Client) Events() <-chan domain.Event {
+	return c.events
+}
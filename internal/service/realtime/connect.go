@@ -0,0 +1,45 @@
+package realtime
+
+import (
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Connect registers a new client for userID, enforcing the per-user connection limit.
+func (s *Service) Connect(userID string) (*Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.MaxConnectionsPerUser > 0 && s.connections[userID] >= s.MaxConnectionsPerUser {
+		return nil, ErrTooManyConnections
+	}
+	s.connections[userID]++
+
+	return &Client{
+		UserID:   userID,
+		events:   make(chan domain.Event, defaultEventsBuffer),
+		channels: make(map[string]string),
+	}, nil
+}
+
+// Disconnect removes every subscription of the client and closes its events stream.
+func (s *Service) Disconnect(client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A nil channels map means the client was already disconnected.
+	if client.channels == nil {
+		return
+	}
+
+	for channel := range client.channels {
+		s.removeSubscriber(channel, client)
+	}
+	client.channels = nil
+
+	s.connections[client.UserID]--
+	if s.connections[client.UserID] <= 0 {
+		delete(s.connections, client.UserID)
+	}
+
+	close(client.events)
+}
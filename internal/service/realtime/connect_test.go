@@ -0,0 +1,96 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnect(t *testing.T) {
+	userID := uuid.NewString()
+
+	testCases := []struct {
+		name            string
+		maxConnections  int
+		openConnections int
+		expectedErr     error
+	}{
+		{
+			name:            "Success - first connection",
+			maxConnections:  2,
+			openConnections: 0,
+		},
+		{
+			name:            "Success - unlimited connections when limit is zero",
+			maxConnections:  0,
+			openConnections: 10,
+		},
+		{
+			name:            "Failure - per-user limit reached",
+			maxConnections:  2,
+			openConnections: 2,
+			expectedErr:     realtime.ErrTooManyConnections,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service := realtime.NewService(nil, tc.maxConnections, logging.Discard())
+			for i := 0; i < tc.openConnections; i++ {
+				_, err := service.Connect(userID)
+				require.NoError(t, err)
+			}
+
+			// Act
+			client, err := service.Connect(userID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, client)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+				assert.Equal(t, userID, client.UserID)
+			}
+		})
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	userID := uuid.NewString()
+
+	t.Run("Success - frees a connection slot and closes the events stream", func(t *testing.T) {
+		// Arrange
+		service := realtime.NewService(nil, 1, logging.Discard())
+		client, err := service.Connect(userID)
+		require.NoError(t, err)
+		require.NoError(t, service.Subscribe(client, "timeline"))
+
+		// Act
+		service.Disconnect(client)
+
+		// Assert
+		_, open := <-client.Events()
+		assert.False(t, open, "events stream should be closed")
+
+		_, err = service.Connect(userID)
+		assert.NoError(t, err, "the slot should be available again")
+	})
+
+	t.Run("Success - disconnecting twice is a no-op", func(t *testing.T) {
+		// Arrange
+		service := realtime.NewService(nil, 1, logging.Discard())
+		client, err := service.Connect(userID)
+		require.NoError(t, err)
+
+		// Act & Assert
+		service.Disconnect(client)
+		assert.NotPanics(t, func() { service.Disconnect(client) })
+	})
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/realtime_mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBroker is a mock of Broker interface.
+type MockBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrokerMockRecorder
+	isgomock struct{}
+}
+
+// MockBrokerMockRecorder is the mock recorder for MockBroker.
+type MockBrokerMockRecorder struct {
+	mock *MockBroker
+}
+
+// NewMockBroker creates a new mock instance.
+func NewMockBroker(ctrl *gomock.Controller) *MockBroker {
+	mock := &MockBroker{ctrl: ctrl}
+	mock.recorder = &MockBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBroker) EXPECT() *MockBrokerMockRecorder {
+	return m.recorder
+}
+
+// PSubscribe mocks base method.
+func (m *MockBroker) PSubscribe(ctx context.Context, pattern string, handler func(string, string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PSubscribe", ctx, pattern, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PSubscribe indicates an expected call of PSubscribe.
+func (mr *MockBrokerMockRecorder) PSubscribe(ctx, pattern, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PSubscribe", reflect.TypeOf((*MockBroker)(nil).PSubscribe), ctx, pattern, handler)
+}
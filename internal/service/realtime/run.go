@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Run listens to every event channel and dispatches messages to subscribed clients.
+// It blocks until ctx is cancelled or the subscription fails or is closed.
+func (s *Service) Run(ctx context.Context) error {
+	return s.Broker.PSubscribe(ctx, domain.EventsChannelPattern, s.dispatch)
+}
+
+// Listen calls Run until ctx is cancelled, subscribing again after every failure, including
+// a subscription closed without error. The wait between attempts doubles while they keep
+// failing and starts over once a subscription lasted longer than MaxResubscribeBackoff.
+// It's meant to be started in its own goroutine.
+func (s *Service) Listen(ctx context.Context) {
+	backoff := s.ResubscribeBackoff
+	for {
+		started := time.Now()
+		err := s.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > s.MaxResubscribeBackoff {
+			backoff = s.ResubscribeBackoff
+		}
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "realtime event subscription failed", "error", err, "retry_in", backoff)
+		} else {
+			s.Logger.ErrorContext(ctx, "realtime event subscription closed", "retry_in", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, s.MaxResubscribeBackoff)
+	}
+}
+
+func (s *Service) dispatch(channel, payload string) {
+	var event domain.Event
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		s.Logger.Error("discarding malformed event", "channel", channel, "error", err)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for client := range s.subscribers[channel] {
+		delivered := event
+		delivered.Topic = client.channels[channel]
+
+		// Never block the dispatcher on a slow consumer; drop the event instead.
+		select {
+		case client.events <- delivered:
+		default:
+			s.dropLogger.Warn("dropping event for slow client", "user_id", client.UserID, "channel", channel)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package realtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRun(t *testing.T) {
+	user1 := uuid.NewString()
+	user2 := uuid.NewString()
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	payload := fmt.Sprintf(`{"type":"tweet_created","tweet_id":"%s","author_id":"%s"}`, tweetID, authorID)
+
+	brokerError := errors.New("redis connection refused")
+
+	t.Run("Success - dispatches events to subscribed clients only", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+
+		subscribed, err := service.Connect(user1)
+		require.NoError(t, err)
+		require.NoError(t, service.Subscribe(subscribed, "timeline"))
+
+		other, err := service.Connect(user2)
+		require.NoError(t, err)
+		require.NoError(t, service.Subscribe(other, "timeline"))
+
+		// The broker delivers one event for user1, one malformed message and returns.
+		mockBroker.EXPECT().
+			PSubscribe(gomock.Any(), "events:*", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+				handler(domain.TimelineEventsChannel(user1), payload)
+				handler(domain.TimelineEventsChannel(user1), "not json")
+				return nil
+			})
+
+		// Act
+		err = service.Run(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, subscribed.Events(), 1)
+		assert.Equal(t, domain.Event{
+			Topic:    "timeline",
+			Type:     domain.EventTweetCreated,
+			TweetID:  tweetID,
+			AuthorID: authorID,
+		}, <-subscribed.Events())
+		assert.Empty(t, other.Events(), "clients of other users must not receive the event")
+	})
+
+	t.Run("Success - dispatches follow notifications and tweet edits", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+
+		client, err := service.Connect(user1)
+		require.NoError(t, err)
+		require.NoError(t, service.Subscribe(client, "notifications"))
+		require.NoError(t, service.Subscribe(client, "tweet:"+tweetID))
+
+		// The payloads are the ones published by the user service on follows and edits.
+		newFollower, err := json.Marshal(domain.Event{Type: domain.EventNewFollower, FollowerID: user2})
+		require.NoError(t, err)
+		tweetEdited, err := json.Marshal(domain.Event{Type: domain.EventTweetEdited, TweetID: tweetID, AuthorID: authorID})
+		require.NoError(t, err)
+
+		mockBroker.EXPECT().
+			PSubscribe(gomock.Any(), "events:*", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+				handler(domain.NotificationEventsChannel(user1), string(newFollower))
+				handler(domain.NotificationEventsChannel(user2), string(newFollower))
+				handler(domain.ThreadEventsChannel(tweetID), string(tweetEdited))
+				return nil
+			})
+
+		// Act
+		err = service.Run(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, client.Events(), 2, "the notifications of other users must not be received")
+		assert.Equal(t, domain.Event{
+			Topic:      "notifications",
+			Type:       domain.EventNewFollower,
+			FollowerID: user2,
+		}, <-client.Events())
+		assert.Equal(t, domain.Event{
+			Topic:    "tweet:" + tweetID,
+			Type:     domain.EventTweetEdited,
+			TweetID:  tweetID,
+			AuthorID: authorID,
+		}, <-client.Events())
+	})
+
+	t.Run("Success - drops events for a slow client instead of blocking", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+
+		client, err := service.Connect(user1)
+		require.NoError(t, err)
+		require.NoError(t, service.Subscribe(client, "tweet:"+tweetID))
+
+		mockBroker.EXPECT().
+			PSubscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+				for i := 0; i < cap(client.Events())+10; i++ {
+					handler(domain.ThreadEventsChannel(tweetID), payload)
+				}
+				return nil
+			})
+
+		// Act
+		err = service.Run(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, client.Events(), cap(client.Events()))
+		assert.Equal(t, "tweet:"+tweetID, (<-client.Events()).Topic)
+	})
+
+	t.Run("Failure - broker error is returned", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+
+		mockBroker.EXPECT().
+			PSubscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(brokerError)
+
+		// Act
+		err := service.Run(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, brokerError)
+	})
+}
+
+func TestListen(t *testing.T) {
+	brokerError := errors.New("redis connection refused")
+
+	t.Run("Success - subscribes again after failures and closed subscriptions", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+		service.ResubscribeBackoff = time.Millisecond
+		service.MaxResubscribeBackoff = 2 * time.Millisecond
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		gomock.InOrder(
+			mockBroker.EXPECT().PSubscribe(gomock.Any(), "events:*", gomock.Any()).Return(brokerError),
+			mockBroker.EXPECT().PSubscribe(gomock.Any(), "events:*", gomock.Any()).Return(nil),
+			mockBroker.EXPECT().PSubscribe(gomock.Any(), "events:*", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, pattern string, handler func(channel, payload string)) error {
+					cancel()
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+		)
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			service.Listen(ctx)
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Listen did not stop after the context was cancelled")
+		}
+	})
+
+	t.Run("Success - stops waiting for the next attempt when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		mockBroker := mocks.NewMockBroker(ctrl)
+		service := realtime.NewService(mockBroker, 0, logging.Discard())
+		service.ResubscribeBackoff = time.Hour
+		service.MaxResubscribeBackoff = time.Hour
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockBroker.EXPECT().
+			PSubscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, string, func(channel, payload string)) error {
+				cancel()
+				return brokerError
+			})
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			service.Listen(ctx)
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Listen did not stop after the context was cancelled")
+		}
+	})
+}
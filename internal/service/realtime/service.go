@@ -0,0 +1,66 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/realtime_mocks.go -package=mocks
+
+// defaultEventsBuffer is the number of events buffered per client before new ones are dropped.
+const defaultEventsBuffer = 64
+
+// defaultResubscribeBackoff and defaultMaxResubscribeBackoff bound the wait before Listen
+// subscribes again after the subscription failed or was closed.
+const (
+	defaultResubscribeBackoff    = 100 * time.Millisecond
+	defaultMaxResubscribeBackoff = 10 * time.Second
+)
+
+// dropLogSampling bounds the lines logged for events dropped on slow clients, which are
+// logged per client and event.
+var dropLogSampling = logging.SamplerConfig{First: 10, Thereafter: 100, Tick: time.Second}
+
+var (
+	ErrTooManyConnections = errors.New("too many open connections for user")
+	ErrInvalidTopic       = errors.New("invalid topic")
+)
+
+type Broker interface {
+	PSubscribe(ctx context.Context, pattern string, handler func(channel, payload string)) error
+}
+
+// Service keeps track of connected clients and routes Pub/Sub messages to them.
+// A single pattern subscription per instance is shared by every client.
+type Service struct {
+	Broker                Broker
+	MaxConnectionsPerUser int
+	Logger                *slog.Logger
+	// ResubscribeBackoff is the first wait before subscribing again. It doubles with every
+	// failure in a row, up to MaxResubscribeBackoff.
+	ResubscribeBackoff    time.Duration
+	MaxResubscribeBackoff time.Duration
+
+	dropLogger  *slog.Logger
+	mu          sync.RWMutex
+	connections map[string]int
+	subscribers map[string]map[*Client]struct{}
+}
+
+func NewService(broker Broker, maxConnectionsPerUser int, logger *slog.Logger) *Service {
+	return &Service{
+		Broker:                broker,
+		MaxConnectionsPerUser: maxConnectionsPerUser,
+		Logger:                logger,
+		ResubscribeBackoff:    defaultResubscribeBackoff,
+		MaxResubscribeBackoff: defaultMaxResubscribeBackoff,
+		dropLogger:            logging.Sampled(logger, dropLogSampling),
+		connections:           make(map[string]int),
+		subscribers:           make(map[string]map[*Client]struct{}),
+	}
+}
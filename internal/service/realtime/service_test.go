@@ -0,0 +1,27 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// TestNewService verifies that the service constructor correctly initializes
+// the service with its broker and connection limit.
+func TestNewService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockBroker := mocks.NewMockBroker(ctrl)
+
+	// Act
+	service := realtime.NewService(mockBroker, 3, logging.Discard())
+
+	// Assert
+	assert.NotNil(t, service)
+	assert.Equal(t, mockBroker, service.Broker, "Broker should be the provided mock instance")
+	assert.Equal(t, 3, service.MaxConnectionsPerUser)
+}
@@ -0,0 +1,83 @@
+package realtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// Subscribe attaches the client to a topic. Timeline and notification topics are always
+// scoped to the client's own user, so a client cannot listen to someone else's feed.
+func (s *Service) Subscribe(client *Client, topic string) error {
+	channel, err := resolveChannel(client.UserID, topic)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client.channels == nil {
+		return fmt.Errorf("client is disconnected")
+	}
+
+	client.channels[channel] = topic
+	if s.subscribers[channel] == nil {
+		s.subscribers[channel] = make(map[*Client]struct{})
+	}
+	s.subscribers[channel][client] = struct{}{}
+
+	return nil
+}
+
+// Unsubscribe detaches the client from a topic. Unknown subscriptions are ignored.
+func (s *Service) Unsubscribe(client *Client, topic string) error {
+	channel, err := resolveChannel(client.UserID, topic)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client.channels == nil {
+		return nil
+	}
+
+	delete(client.channels, channel)
+	s.removeSubscriber(channel, client)
+
+	return nil
+}
+
+// removeSubscriber must be called with the service mutex held.
+func (s *Service) removeSubscriber(channel string, client *Client) {
+	clients, ok := s.subscribers[channel]
+	if !ok {
+		return
+	}
+	delete(clients, client)
+	if len(clients) == 0 {
+		delete(s.subscribers, channel)
+	}
+}
+
+// resolveChannel maps a client-facing topic to its Pub/Sub channel.
+func resolveChannel(userID, topic string) (string, error) {
+	switch {
+	case topic == domain.TopicTimeline:
+		return domain.TimelineEventsChannel(userID), nil
+	case topic == domain.TopicNotifications:
+		return domain.NotificationEventsChannel(userID), nil
+	case strings.HasPrefix(topic, domain.ThreadTopic("")):
+		tweetID := strings.TrimPrefix(topic, domain.ThreadTopic(""))
+		if err := uuid.Validate(tweetID); err != nil {
+			return "", fmt.Errorf("%w: tweet id must be a valid UUID", ErrInvalidTopic)
+		}
+		return domain.ThreadEventsChannel(tweetID), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+	}
+}
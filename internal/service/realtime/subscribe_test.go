@@ -0,0 +1,75 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	userID := uuid.NewString()
+
+	testCases := []struct {
+		name        string
+		topic       string
+		expectedErr error
+	}{
+		{name: "Success - home timeline", topic: "timeline"},
+		{name: "Success - notifications", topic: "notifications"},
+		{name: "Success - tweet thread", topic: "tweet:" + uuid.NewString()},
+		{name: "Failure - thread with invalid tweet id", topic: "tweet:not-a-uuid", expectedErr: realtime.ErrInvalidTopic},
+		{name: "Failure - unknown topic", topic: "timeline:" + uuid.NewString(), expectedErr: realtime.ErrInvalidTopic},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service := realtime.NewService(nil, 0, logging.Discard())
+			client, err := service.Connect(userID)
+			require.NoError(t, err)
+
+			// Act
+			err = service.Subscribe(client, tc.topic)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Failure - client already disconnected", func(t *testing.T) {
+		service := realtime.NewService(nil, 0, logging.Discard())
+		client, err := service.Connect(userID)
+		require.NoError(t, err)
+		service.Disconnect(client)
+
+		assert.Error(t, service.Subscribe(client, "timeline"))
+	})
+}
+
+func TestUnsubscribe(t *testing.T) {
+	userID := uuid.NewString()
+
+	t.Run("Success - unknown subscription is ignored", func(t *testing.T) {
+		service := realtime.NewService(nil, 0, logging.Discard())
+		client, err := service.Connect(userID)
+		require.NoError(t, err)
+
+		assert.NoError(t, service.Unsubscribe(client, "notifications"))
+	})
+
+	t.Run("Failure - invalid topic", func(t *testing.T) {
+		service := realtime.NewService(nil, 0, logging.Discard())
+		client, err := service.Connect(userID)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, service.Unsubscribe(client, "unknown"), realtime.ErrInvalidTopic)
+	})
+}
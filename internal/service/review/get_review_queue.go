@@ -0,0 +1,17 @@
+package review
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// GetReviewQueue returns the held tweets waiting for a review, the longest waiting first, up
+// to Config.QueueSize. Reviewed tweets leave the queue, so the next call returns the next
+// ones.
+func (s Service) GetReviewQueue(ctx context.Context) ([]domain.ModerationReview, error) {
+	ctx, span := tracer.Start(ctx, "review.GetReviewQueue")
+	defer span.End()
+
+	return s.Storage.SelectHeldTweets(ctx, s.Config.QueueSize)
+}
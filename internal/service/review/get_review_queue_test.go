@@ -0,0 +1,70 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+	"github.com/renzonaitor/tweet-api/internal/service/review/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetReviewQueue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reviews := []domain.ModerationReview{{
+		Tweet:     domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "this is a scam", CreatedAt: now, Moderation: domain.TweetHeld},
+		Rule:      "banned_words",
+		Reason:    `contains the banned word "scam"`,
+		FlaggedAt: now,
+	}}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(storage *mocks.MockStorageRepo)
+		expectedReviews []domain.ModerationReview
+		expectedErr     error
+	}{
+		{
+			name: "Success - returns the held tweets",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectHeldTweets(gomock.Any(), 20).Return(reviews, nil)
+			},
+			expectedReviews: reviews,
+		},
+		{
+			name: "Failure - database error",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectHeldTweets(gomock.Any(), 20).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			service := review.NewService(mockStorage, nil, review.Config{QueueSize: 20})
+
+			// Act
+			got, err := service.GetReviewQueue(context.Background())
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReviews, got)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/review_mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorageRepo is a mock of StorageRepo interface.
+type MockStorageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageRepoMockRecorder is the mock recorder for MockStorageRepo.
+type MockStorageRepoMockRecorder struct {
+	mock *MockStorageRepo
+}
+
+// NewMockStorageRepo creates a new mock instance.
+func NewMockStorageRepo(ctrl *gomock.Controller) *MockStorageRepo {
+	mock := &MockStorageRepo{ctrl: ctrl}
+	mock.recorder = &MockStorageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
+	return m.recorder
+}
+
+// ResolveHeldTweet mocks base method.
+func (m *MockStorageRepo) ResolveHeldTweet(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision, reviewedAt time.Time) (*domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveHeldTweet", ctx, tweetID, reviewerID, decision, reviewedAt)
+	ret0, _ := ret[0].(*domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveHeldTweet indicates an expected call of ResolveHeldTweet.
+func (mr *MockStorageRepoMockRecorder) ResolveHeldTweet(ctx, tweetID, reviewerID, decision, reviewedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveHeldTweet", reflect.TypeOf((*MockStorageRepo)(nil).ResolveHeldTweet), ctx, tweetID, reviewerID, decision, reviewedAt)
+}
+
+// SelectHeldTweets mocks base method.
+func (m *MockStorageRepo) SelectHeldTweets(ctx context.Context, limit int) ([]domain.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectHeldTweets", ctx, limit)
+	ret0, _ := ret[0].([]domain.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectHeldTweets indicates an expected call of SelectHeldTweets.
+func (mr *MockStorageRepoMockRecorder) SelectHeldTweets(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectHeldTweets", reflect.TypeOf((*MockStorageRepo)(nil).SelectHeldTweets), ctx, limit)
+}
+
+// MockTimelineUpdater is a mock of TimelineUpdater interface.
+type MockTimelineUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimelineUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockTimelineUpdaterMockRecorder is the mock recorder for MockTimelineUpdater.
+type MockTimelineUpdaterMockRecorder struct {
+	mock *MockTimelineUpdater
+}
+
+// NewMockTimelineUpdater creates a new mock instance.
+func NewMockTimelineUpdater(ctrl *gomock.Controller) *MockTimelineUpdater {
+	mock := &MockTimelineUpdater{ctrl: ctrl}
+	mock.recorder = &MockTimelineUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimelineUpdater) EXPECT() *MockTimelineUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateTimeline mocks base method.
+func (m *MockTimelineUpdater) UpdateTimeline(ctx context.Context, tweetAuthorID, tweetID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateTimeline", ctx, tweetAuthorID, tweetID)
+}
+
+// UpdateTimeline indicates an expected call of UpdateTimeline.
+func (mr *MockTimelineUpdaterMockRecorder) UpdateTimeline(ctx, tweetAuthorID, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeline", reflect.TypeOf((*MockTimelineUpdater)(nil).UpdateTimeline), ctx, tweetAuthorID, tweetID)
+}
@@ -0,0 +1,43 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ResolveReview records the decision of reviewerID on the held tweet tweetID. An approved
+// tweet becomes visible and is delivered to the timelines of the followers of its author, as
+// if it was just published; a removed one stays hidden. Only the first decision on a tweet
+// counts: later ones return ErrReviewNotFound.
+func (s Service) ResolveReview(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision) (domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "review.ResolveReview", trace.WithAttributes(
+		attribute.String("tweet.id", tweetID),
+		attribute.String("reviewer.id", reviewerID),
+		attribute.String("review.decision", string(decision)),
+	))
+	defer span.End()
+
+	if decision != domain.ReviewApprove && decision != domain.ReviewRemove {
+		return domain.Tweet{}, ErrInvalidDecision
+	}
+
+	reviewedAt := s.Clock.Now().UTC().Truncate(time.Millisecond)
+	tweet, err := s.Storage.ResolveHeldTweet(ctx, tweetID, reviewerID, decision, reviewedAt)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+	if tweet == nil {
+		return domain.Tweet{}, ErrReviewNotFound
+	}
+
+	if decision == domain.ReviewApprove {
+		// Same asynchronous fan-out as a published tweet.
+		go s.Timeline.UpdateTimeline(context.WithoutCancel(ctx), tweet.UserID, tweet.ID)
+	}
+
+	return *tweet, nil
+}
@@ -0,0 +1,108 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+	"github.com/renzonaitor/tweet-api/internal/service/review/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResolveReview(t *testing.T) {
+	tweetID := uuid.NewString()
+	authorID := uuid.NewString()
+	reviewerID := uuid.NewString()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 123456789, time.UTC)
+	reviewedAt := now.Truncate(time.Millisecond)
+
+	approved := domain.Tweet{ID: tweetID, UserID: authorID, Text: "hello", CreatedAt: now.Add(-time.Hour), Moderation: domain.TweetVisible}
+	removed := approved
+	removed.Moderation = domain.TweetRemoved
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name          string
+		decision      domain.ReviewDecision
+		setupMocks    func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup)
+		expectedTweet domain.Tweet
+		expectedErr   error
+	}{
+		{
+			name:     "Success - approving fans the tweet out",
+			decision: domain.ReviewApprove,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
+				storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweetID, reviewerID, domain.ReviewApprove, reviewedAt).Return(&approved, nil)
+				wg.Add(1)
+				timeline.EXPECT().
+					UpdateTimeline(gomock.Any(), authorID, tweetID).
+					Do(func(ctx context.Context, authorID, tweetID string) { wg.Done() })
+			},
+			expectedTweet: approved,
+		},
+		{
+			name:     "Success - removing doesn't fan the tweet out",
+			decision: domain.ReviewRemove,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
+				storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweetID, reviewerID, domain.ReviewRemove, reviewedAt).Return(&removed, nil)
+			},
+			expectedTweet: removed,
+		},
+		{
+			name:        "Failure - invalid decision",
+			decision:    "maybe",
+			setupMocks:  func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {},
+			expectedErr: review.ErrInvalidDecision,
+		},
+		{
+			name:     "Failure - tweet not waiting for a review",
+			decision: domain.ReviewApprove,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
+				storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweetID, reviewerID, domain.ReviewApprove, reviewedAt).Return(nil, nil)
+			},
+			expectedErr: review.ErrReviewNotFound,
+		},
+		{
+			name:     "Failure - database error",
+			decision: domain.ReviewRemove,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
+				storage.EXPECT().ResolveHeldTweet(gomock.Any(), tweetID, reviewerID, domain.ReviewRemove, reviewedAt).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+			wg := &sync.WaitGroup{}
+			tc.setupMocks(mockStorage, mockTimeline, wg)
+
+			service := review.NewService(mockStorage, mockTimeline, review.Config{})
+			service.Clock = clock.Fixed(now)
+
+			// Act
+			tweet, err := service.ResolveReview(context.Background(), tweetID, reviewerID, tc.decision)
+
+			// Assert
+			wg.Wait()
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTweet, tweet)
+		})
+	}
+}
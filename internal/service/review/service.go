@@ -0,0 +1,66 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/review_mocks.go -package=mocks
+type StorageRepo interface {
+	SelectHeldTweets(ctx context.Context, limit int) ([]domain.ModerationReview, error)
+	// ResolveHeldTweet records the decision on the held tweet tweetID. It returns nil when
+	// the tweet isn't waiting for a review.
+	ResolveHeldTweet(ctx context.Context, tweetID, reviewerID string, decision domain.ReviewDecision, reviewedAt time.Time) (*domain.Tweet, error)
+}
+
+type TimelineUpdater interface {
+	UpdateTimeline(ctx context.Context, tweetAuthorID, tweetID string)
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultQueueSize = 50
+)
+
+var (
+	// ErrReviewNotFound is returned when a decision is made on a tweet that isn't held, or
+	// was already reviewed.
+	ErrReviewNotFound = errors.New("tweet is not waiting for a review")
+	// ErrInvalidDecision is returned when a decision is neither approve nor remove.
+	ErrInvalidDecision = errors.New("decision must be approve or remove")
+)
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/service/review")
+
+// Config holds the tunable limits of the review service.
+type Config struct {
+	// QueueSize is how many held tweets the review queue returns at once.
+	QueueSize int
+}
+
+// Service depends on the interfaces, not concrete types.
+type Service struct {
+	Storage  StorageRepo
+	Timeline TimelineUpdater
+	Config   Config
+	// Clock stamps the reviews. Defaults to the system clock.
+	Clock clock.Clock
+}
+
+func NewService(storage StorageRepo, timeline TimelineUpdater, cfg Config) *Service {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+
+	return &Service{
+		Storage:  storage,
+		Timeline: timeline,
+		Config:   cfg,
+		Clock:    clock.System,
+	}
+}
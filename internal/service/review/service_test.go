@@ -0,0 +1,26 @@
+package review_test
+
+import (
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/service/review"
+	"github.com/renzonaitor/tweet-api/internal/service/review/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+
+	// Act
+	service := review.NewService(mockStorage, mockTimeline, review.Config{})
+
+	// Assert
+	assert.NotNil(t, service)
+	assert.Equal(t, mockStorage, service.Storage)
+	assert.Equal(t, mockTimeline, service.Timeline)
+	assert.Equal(t, 50, service.Config.QueueSize, "QueueSize should default to 50")
+}
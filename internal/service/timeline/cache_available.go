@@ -0,0 +1,8 @@
+package timeline
+
+// cacheAvailable reports whether Redis calls are let through. Caches that don't implement
+// CacheAvailability are always available.
+func (s Service) cacheAvailable() bool {
+	availability, ok := s.Cache.(CacheAvailability)
+	return !ok || availability.Available()
+}
@@ -0,0 +1,105 @@
+package timeline_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// breakerCache is a cache that reports its availability, like one behind a circuit breaker.
+type breakerCache struct {
+	*mocks.MockCacheRepository
+	*mocks.MockCacheAvailability
+}
+
+// TestCacheUnavailable walks the service through a Redis outage seen through an open circuit
+// breaker: reads are degraded, and fan-out jobs are left for the recovery once Redis is back.
+func TestCacheUnavailable(t *testing.T) {
+	// Arrange
+	userID := uuid.NewString()
+	followeeID := uuid.NewString()
+	tweetID := uuid.NewString()
+	openErr := fmt.Errorf("redis LRange: %w", circuitbreaker.ErrOpen)
+	fallbackTweets := []domain.Tweet{{ID: tweetID, UserID: followeeID, Text: "from PostgreSQL"}}
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	cache := breakerCache{mocks.NewMockCacheRepository(ctrl), mocks.NewMockCacheAvailability(ctrl)}
+	cache.MockCacheAvailability.EXPECT().Available().Return(false).AnyTimes()
+
+	service := timeline.NewService(mockStorage, cache, timeline.Config{}, logging.Discard())
+
+	// Act & Assert - reads are served in degraded mode.
+	cache.MockCacheRepository.EXPECT().LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, openErr)
+	mockStorage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return([]string{followeeID}, nil)
+	mockStorage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+	mockStorage.EXPECT().
+		SelectLastTweetsByUsersID(gomock.Any(), []string{followeeID}, "", 10).
+		Return(fallbackTweets, nil)
+
+	page, err := service.GetTimeline(context.Background(), userID, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.TimelinePage{Tweets: fallbackTweets, Degraded: true}, page)
+
+	// Act & Assert - the fan-out doesn't retry the rejected pushes and leaves the job failed at its checkpoint.
+	mockStorage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, followeeID).Return(true, nil)
+	mockStorage.EXPECT().
+		ForEachFollowerBatch(gomock.Any(), followeeID, "", gomock.Any(), gomock.Any()).
+		DoAndReturn(followerBatches([]string{userID}))
+	cache.MockCacheRepository.EXPECT().
+		LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), tweetID).
+		Return(0, nil, fmt.Errorf("redis LPushTrimPipeline: %w", circuitbreaker.ErrOpen)).
+		Times(1)
+	cache.MockCacheRepository.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockStorage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockStorage.EXPECT().
+		FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobFailed, gomock.Any()).
+		Return(nil)
+
+	service.UpdateTimeline(context.Background(), followeeID, tweetID)
+
+	// Act & Assert - the recovery doesn't claim jobs, so they don't spend their attempts.
+	mockStorage.EXPECT().ClaimStaleFanOutJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	resumed, err := service.ResumeFanOutJobs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, resumed)
+}
+
+// TestCacheUnavailable_NoWarmUp checks that a cold cache isn't rebuilt while Redis is unavailable.
+func TestCacheUnavailable_NoWarmUp(t *testing.T) {
+	// Arrange
+	userID := uuid.NewString()
+	followeeID := uuid.NewString()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	cache := breakerCache{mocks.NewMockCacheRepository(ctrl), mocks.NewMockCacheAvailability(ctrl)}
+	cache.MockCacheAvailability.EXPECT().Available().Return(false)
+
+	cache.MockCacheRepository.EXPECT().LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{}, nil)
+	mockStorage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return([]string{followeeID}, nil)
+	mockStorage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+	mockStorage.EXPECT().SelectLastTweetsByUsersID(gomock.Any(), gomock.Any(), "", 10).Return(nil, nil)
+	mockStorage.EXPECT().SelectTweetIDsByUsersID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	cache.MockCacheRepository.EXPECT().RebuildList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	service := timeline.NewService(mockStorage, cache, timeline.Config{}, logging.Discard())
+
+	// Act
+	page, err := service.GetTimeline(context.Background(), userID, 10, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, page.Degraded)
+}
@@ -0,0 +1,106 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CountNewTweets returns how many timeline tweets are newer than sinceCursor, capped at
+// Config.MaxNewTweetsCount. The cached timeline list is used when warm; otherwise the
+// count is computed over PostgreSQL, and flagged as degraded when Redis failed. Tweets of
+// the authors hidden from userID aren't counted, as they aren't shown in the timeline.
+func (s Service) CountNewTweets(ctx context.Context, userID, sinceCursor string) (domain.NewTweetsCount, error) {
+	ctx, span := tracer.Start(ctx, "timeline.CountNewTweets", trace.WithAttributes(attribute.String("user.id", userID)))
+	defer span.End()
+
+	maxCount := s.Config.MaxNewTweetsCount
+	timelineKey := fmt.Sprintf(timelineKeyFormat, userID)
+
+	// Read one extra ID so "exactly max" can be told apart from "more than max".
+	tweetIDs, cacheErr := s.Cache.LRange(ctx, timelineKey, 0, int64(maxCount))
+
+	switch {
+	case cacheErr != nil:
+		span.SetAttributes(attribute.Bool("timeline.degraded", true))
+		s.fanOutLogger.WarnContext(ctx, "timeline cache unavailable, counting new tweets from PostgreSQL",
+			"user_id", userID, "error", cacheErr)
+	case len(tweetIDs) > 0:
+		// The list is newest-first, so the cursor position is the number of newer tweets.
+		// A cursor missing from the window means every cached ID is newer.
+		newer := tweetIDs
+		for i, tweetID := range tweetIDs {
+			if tweetID == sinceCursor {
+				newer = tweetIDs[:i]
+				break
+			}
+		}
+		count, err := s.countVisibleTweets(ctx, userID, newer)
+		if err != nil {
+			return domain.NewTweetsCount{}, err
+		}
+		return capNewTweetsCount(count, maxCount), nil
+	default:
+		s.Logger.InfoContext(ctx, "timeline cache is empty, counting new tweets from PostgreSQL", "key", timelineKey)
+	}
+
+	followees, err := s.Storage.SelectFollowersByUserID(ctx, userID)
+	if err != nil {
+		return domain.NewTweetsCount{}, err
+	}
+
+	hidden, err := s.hiddenAuthors(ctx, userID)
+	if err != nil {
+		return domain.NewTweetsCount{}, err
+	}
+
+	count, err := s.Storage.CountTweetsSince(ctx, visibleAuthors(followees, hidden), sinceCursor, maxCount+1)
+	if err != nil {
+		return domain.NewTweetsCount{}, err
+	}
+
+	newTweets := capNewTweetsCount(count, maxCount)
+	newTweets.Degraded = cacheErr != nil
+	return newTweets, nil
+}
+
+// countVisibleTweets returns how many of the cached tweetIDs aren't from an author hidden from
+// userID. Tweets are only loaded when userID hides someone; IDs without a row are still counted,
+// as the cached count always did. Hidden tweets are only discounted within the window read, so
+// the count may fall short when the cursor is past it.
+func (s Service) countVisibleTweets(ctx context.Context, userID string, tweetIDs []string) (int, error) {
+	if len(tweetIDs) == 0 {
+		return 0, nil
+	}
+
+	hidden, err := s.hiddenAuthors(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(hidden) == 0 {
+		return len(tweetIDs), nil
+	}
+
+	tweets, err := s.Storage.SelectTweetsByTweetsIDs(ctx, tweetIDs)
+	if err != nil {
+		return 0, fmt.Errorf("error loading new tweets from storage: %w", err)
+	}
+
+	count := len(tweetIDs)
+	for _, tweet := range tweets {
+		if _, ok := hidden[tweet.UserID]; ok {
+			count--
+		}
+	}
+	return count, nil
+}
+
+func capNewTweetsCount(count, maxCount int) domain.NewTweetsCount {
+	if count > maxCount {
+		return domain.NewTweetsCount{Count: maxCount, Capped: true}
+	}
+	return domain.NewTweetsCount{Count: count}
+}
@@ -0,0 +1,204 @@
+package timeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCountNewTweets(t *testing.T) {
+	userID := uuid.NewString()
+	cursor := uuid.NewString()
+	tweet1 := uuid.NewString()
+	tweet2 := uuid.NewString()
+	tweet3 := uuid.NewString()
+	followees := []string{uuid.NewString(), uuid.NewString()}
+	maxCount := 2
+
+	cacheError := errors.New("redis connection refused")
+	dbError := errors.New("postgres connection failed")
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+		expectedCount domain.NewTweetsCount
+		expectedErr   error
+	}{
+		{
+			name: "Success - Cache Hit, cursor found",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				// One extra ID is requested to detect the cap.
+				cache.EXPECT().
+					LRange(gomock.Any(), "timeline:"+userID, int64(0), int64(maxCount)).
+					Return([]string{tweet1, cursor, tweet2}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 1},
+		},
+		{
+			name: "Success - Cache Hit, cursor is the newest tweet",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{cursor, tweet1}, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 0},
+		},
+		{
+			name: "Success - Cache Hit, cursor beyond the cap",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{tweet1, tweet2, tweet3}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: maxCount, Capped: true},
+		},
+		{
+			name: "Success - Cache Hit, cursor not in a short list",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{tweet1}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 1},
+		},
+		{
+			name: "Success - Cache Hit, tweets of hidden authors aren't counted",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{tweet1, tweet2, cursor}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return([]string{followees[0]}, nil)
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1, tweet2}).
+					Return([]domain.Tweet{
+						{ID: tweet1, UserID: followees[0]},
+						{ID: tweet2, UserID: followees[1]},
+					}, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 1},
+		},
+		{
+			name: "Success - Cache Miss, counted from storage",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followees, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+				storage.EXPECT().
+					CountTweetsSince(gomock.Any(), followees, cursor, maxCount+1).
+					Return(maxCount+1, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: maxCount, Capped: true},
+		},
+		{
+			name: "Success - Cache Miss, tweets of hidden authors aren't counted",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followees, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return([]string{followees[0]}, nil)
+				storage.EXPECT().
+					CountTweetsSince(gomock.Any(), followees[1:], cursor, maxCount+1).
+					Return(1, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 1},
+		},
+		{
+			name: "Success - Cache Error, degraded count from storage",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, cacheError)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followees, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+				storage.EXPECT().
+					CountTweetsSince(gomock.Any(), followees, cursor, maxCount+1).
+					Return(2, nil)
+			},
+			expectedCount: domain.NewTweetsCount{Count: 2, Degraded: true},
+		},
+		{
+			name: "Failure - Cache Miss, SelectFollowersByUserID fails",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name: "Failure - Cache Hit, SelectHiddenAuthorIDs fails",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{tweet1, cursor}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name: "Failure - Cache Hit, SelectTweetsByTweetsIDs fails",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{tweet1, cursor}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return([]string{followees[0]}, nil)
+				storage.EXPECT().SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1}).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name: "Failure - Cache Miss, CountTweetsSince fails",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followees, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+				storage.EXPECT().
+					CountTweetsSince(gomock.Any(), followees, cursor, gomock.Any()).
+					Return(0, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMocks(mockStorage, mockCache)
+
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{MaxNewTweetsCount: maxCount}, logging.Discard())
+
+			// Act
+			result, err := service.CountNewTweets(context.Background(), userID, cursor)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, result)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package timeline
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// errFanOutPageFailed stops a fan-out run once a page of followers could not be delivered.
+var errFanOutPageFailed = errors.New("some follower timelines could not be updated")
+
+// fanOutPage is a page of followers, numbered in the order it was read.
+type fanOutPage struct {
+	seq         int
+	followerIDs []string
+}
+
+// fanOutCheckpoint tracks the pages of a fan-out run, which may complete out of order.
+// The checkpoint only covers the contiguous run of delivered pages from the start, so every
+// follower up to it got the tweet. A failed page blocks the checkpoint for the rest of the run.
+type fanOutCheckpoint struct {
+	mu             sync.Mutex
+	next           int
+	blockedAt      int
+	done           map[int][]string
+	lastFollowerID string
+	processed      int
+}
+
+func newFanOutCheckpoint(lastFollowerID string, processed int) *fanOutCheckpoint {
+	return &fanOutCheckpoint{
+		blockedAt:      math.MaxInt,
+		done:           make(map[int][]string),
+		lastFollowerID: lastFollowerID,
+		processed:      processed,
+	}
+}
+
+// complete records the outcome of page and returns the checkpoint, and whether it moved forward.
+func (c *fanOutCheckpoint) complete(page fanOutPage, delivered bool) (string, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !delivered {
+		c.blockedAt = min(c.blockedAt, page.seq)
+		return c.lastFollowerID, c.processed, false
+	}
+	c.done[page.seq] = page.followerIDs
+
+	advanced := false
+	for c.next < c.blockedAt {
+		followerIDs, ok := c.done[c.next]
+		if !ok {
+			break
+		}
+		delete(c.done, c.next)
+		c.next++
+		c.lastFollowerID = followerIDs[len(followerIDs)-1]
+		c.processed += len(followerIDs)
+		advanced = true
+	}
+
+	return c.lastFollowerID, c.processed, advanced
+}
+
+// blocked reports whether a page failed, so the checkpoint can't move past it in this run.
+func (c *fanOutCheckpoint) blocked() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.blockedAt != math.MaxInt
+}
@@ -0,0 +1,26 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GetFanOutJob returns the fan-out job of tweetID, or ErrFanOutJobNotFound.
+func (s Service) GetFanOutJob(ctx context.Context, tweetID string) (domain.FanOutJob, error) {
+	ctx, span := tracer.Start(ctx, "timeline.GetFanOutJob", trace.WithAttributes(attribute.String("tweet.id", tweetID)))
+	defer span.End()
+
+	job, err := s.Storage.SelectFanOutJob(ctx, tweetID)
+	if err != nil {
+		return domain.FanOutJob{}, fmt.Errorf("error fetching fan-out job: %w", err)
+	}
+	if job == nil {
+		return domain.FanOutJob{}, ErrFanOutJobNotFound
+	}
+
+	return *job, nil
+}
@@ -0,0 +1,75 @@
+package timeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetFanOutJob(t *testing.T) {
+	tweetID := uuid.NewString()
+	job := domain.FanOutJob{TweetID: tweetID, AuthorID: uuid.NewString(), Status: domain.FanOutJobCompleted}
+	dbError := errors.New("database connection failed")
+
+	testCases := []struct {
+		name        string
+		setupMock   func(storage *mocks.MockStorageRepo)
+		expectedJob domain.FanOutJob
+		expectedErr error
+	}{
+		{
+			name: "Success - Job found",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFanOutJob(gomock.Any(), tweetID).Return(&job, nil)
+			},
+			expectedJob: job,
+		},
+		{
+			name: "Failure - Job not found",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFanOutJob(gomock.Any(), tweetID).Return(nil, nil)
+			},
+			expectedErr: timeline.ErrFanOutJobNotFound,
+		},
+		{
+			name: "Failure - Storage error",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectFanOutJob(gomock.Any(), tweetID).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMock(mockStorage)
+
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+			// Act
+			result, err := service.GetFanOutJob(context.Background(), tweetID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedJob, result)
+		})
+	}
+}
@@ -3,36 +3,89 @@ package timeline
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (s Service) GetTimeline(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+// GetTimeline returns up to limit tweets of the user's home timeline, newest first.
+// When cursor is set, the page starts right after the tweet with that ID.
+// If Redis fails, or the cache circuit breaker is open, the page is read from PostgreSQL
+// and flagged as degraded instead of failing the request.
+// Tweets of the authors the user muted, blocks or is blocked by are left out of the page.
+func (s Service) GetTimeline(ctx context.Context, userID string, limit int, cursor string) (domain.TimelinePage, error) {
+	ctx, span := tracer.Start(ctx, "timeline.GetTimeline", trace.WithAttributes(
+		attribute.String("user.id", userID),
+		attribute.Int("timeline.limit", limit),
+	))
+	defer span.End()
+
 	timelineKey := fmt.Sprintf(timelineKeyFormat, userID)
 
-	tweetIDs, err := s.Cache.LRange(ctx, timelineKey, 0, int64(limit-1))
+	start := int64(0)
+	if cursor != "" {
+		position, err := s.Cache.LPos(ctx, timelineKey, cursor)
+		if err != nil {
+			return s.getDegradedTimeline(ctx, span, userID, limit, cursor, err)
+		}
+		if position < 0 {
+			// The cursor is older than the cached window (or the cache is cold).
+			metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheMiss).Inc()
+			span.SetAttributes(attribute.Bool("timeline.cache_hit", false))
+			s.Logger.InfoContext(ctx, "cursor not found in cached timeline, getting tweets from PostgreSQL",
+				"cursor", cursor, "key", timelineKey)
+			tweets, err := s.getTimelineFallback(ctx, userID, limit, cursor, true)
+			return domain.TimelinePage{Tweets: tweets}, err
+		}
+		start = position + 1
+	}
+
+	tweetIDs, err := s.Cache.LRange(ctx, timelineKey, start, start+int64(limit)-1)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching timeline from cache: %w", err)
+		return s.getDegradedTimeline(ctx, span, userID, limit, cursor, err)
 	}
 
 	if len(tweetIDs) > 0 {
-		// TODO add metric cache hit. This response round the 4-7 ms on localhost test (using Postman)
-		log.Printf("INFO: cache hit for key: %s", timelineKey)
+		metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheHit).Inc()
+		span.SetAttributes(attribute.Bool("timeline.cache_hit", true))
+		s.Logger.DebugContext(ctx, "timeline cache hit", "key", timelineKey)
 
-		// "Hydrate" the tweet IDs.
-		tweets, err := s.Storage.SelectTweetsByTweetsIDs(ctx, tweetIDs)
+		// "Hydrate" the tweet IDs, keeping the cache order.
+		tweets, err := s.hydrateTimeline(ctx, userID, timelineKey, tweetIDs)
 		if err != nil {
-			return nil, fmt.Errorf("error hydrating tweets from storage: %w", err)
+			return domain.TimelinePage{}, err
 		}
 
-		// TODO add metric response ok using cache-first pattern.
-		log.Printf("INFO: Hydrated [%d] tweets from cache", len(tweetIDs))
-		return tweets, nil
+		// Reading keeps the timeline alive: only lists of inactive users reach their TTL.
+		if err := s.Cache.Expire(ctx, timelineKey, s.Config.TimelineTTL); err != nil {
+			s.Logger.ErrorContext(ctx, "failed to refresh timeline TTL", "key", timelineKey, "error", err)
+		}
+
+		s.Logger.DebugContext(ctx, "hydrated tweets from cache", "key", timelineKey, "tweets", len(tweets))
+		return domain.TimelinePage{Tweets: tweets}, nil
 	}
 
-	// TODO add metric cache miss. This response round the 8-10 ms on localhost test (using Postman)
-	log.Printf("WARN: cache is empty for key: %s. Getting tweets from fallback PostgreSQL", timelineKey)
+	metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheMiss).Inc()
+	span.SetAttributes(attribute.Bool("timeline.cache_hit", false))
+	s.Logger.InfoContext(ctx, "timeline cache is empty, getting tweets from PostgreSQL", "key", timelineKey)
+
+	tweets, err := s.getTimelineFallback(ctx, userID, limit, cursor, true)
+	return domain.TimelinePage{Tweets: tweets}, err
+}
+
+// getDegradedTimeline serves the page from PostgreSQL after cacheErr, a Redis failure, and
+// flags it as degraded. The cache isn't rebuilt, Redis is likely failing still.
+func (s Service) getDegradedTimeline(ctx context.Context, span trace.Span, userID string, limit int, cursor string, cacheErr error) (domain.TimelinePage, error) {
+	metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheUnavailable).Inc()
+	span.SetAttributes(attribute.Bool("timeline.degraded", true))
+	s.fanOutLogger.WarnContext(ctx, "timeline cache unavailable, getting tweets from PostgreSQL",
+		"user_id", userID, "error", cacheErr)
 
-	return s.getTimelineFallback(ctx, userID)
+	tweets, err := s.getTimelineFallback(ctx, userID, limit, cursor, false)
+	if err != nil {
+		return domain.TimelinePage{}, err
+	}
+	return domain.TimelinePage{Tweets: tweets, Degraded: true}, nil
 }
@@ -2,26 +2,77 @@ package timeline
 
 import (
 	"context"
-	"log"
+	"fmt"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
 )
 
-// getTimelineFallback return []tweets from PostgresSQL
-func (s Service) getTimelineFallback(ctx context.Context, userID string) ([]domain.Tweet, error) {
-	followers, err := s.Storage.SelectFollowersByUserID(ctx, userID)
+// fallbackPage is a timeline page read from PostgreSQL, with the followees it was read from.
+type fallbackPage struct {
+	followers []string
+	tweets    []domain.Tweet
+}
+
+// getTimelineFallback return []tweets from PostgresSQL, with the same order and paging as the cached list.
+// When warmUp is set, the first page also rebuilds the cached list. The tweets of hidden authors
+// aren't read, but the rebuilt list keeps them, like the fan-out does.
+// Concurrent reads of the same page, e.g. a burst of requests while the cache is cold, share a
+// single set of queries.
+func (s Service) getTimelineFallback(ctx context.Context, userID string, limit int, cursor string, warmUp bool) ([]domain.Tweet, error) {
+	key := fmt.Sprintf("%s:%s:%d", userID, cursor, limit)
+	result, err, _ := s.fallbackReads.Do(key, func() (interface{}, error) {
+		// The read is shared, so it mustn't fail for every caller when the first one goes away.
+		return s.readTimelineFallback(context.WithoutCancel(ctx), userID, limit, cursor)
+	})
 	if err != nil {
 		return nil, err
 	}
+	page := result.(fallbackPage)
+	tweets := page.tweets
 
-	tweets, err := s.Storage.SelectLastTweetsByUsersID(ctx, followers)
+	// A first page served from PostgreSQL means the cache is cold. Deeper pages only
+	// fall back because they are past the cached window, so there's nothing to rebuild.
+	if warmUp && cursor == "" && s.cacheAvailable() {
+		// Rebuild the cache in a go-routine for decoupling principal flow.
+		go s.warmUpTimeline(userID, page.followers)
+	}
+
+	metrics.TimelineFallbacks.Inc()
+	s.Logger.DebugContext(ctx, "returning tweets from PostgreSQL", "user_id", userID, "tweets", len(tweets))
+	return tweets, nil
+}
+
+func (s Service) readTimelineFallback(ctx context.Context, userID string, limit int, cursor string) (fallbackPage, error) {
+	followers, err := s.Storage.SelectFollowersByUserID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return fallbackPage{}, err
 	}
 
-	// TODO set found tweets_ids in cache using a go-routine for decoupling principal flow
+	hidden, err := s.hiddenAuthors(ctx, userID)
+	if err != nil {
+		return fallbackPage{}, err
+	}
 
-	// TODO add metric response using fallback pattern.
-	log.Printf("INFO: return [%d] tweets from fallback PostgreSQL for user: %s", len(tweets), userID)
-	return tweets, nil
+	tweets, err := s.Storage.SelectLastTweetsByUsersID(ctx, visibleAuthors(followers, hidden), cursor, limit)
+	if err != nil {
+		return fallbackPage{}, err
+	}
+
+	return fallbackPage{followers: followers, tweets: tweets}, nil
+}
+
+// visibleAuthors returns authorIDs without the hidden ones.
+func visibleAuthors(authorIDs []string, hidden map[string]struct{}) []string {
+	if len(hidden) == 0 {
+		return authorIDs
+	}
+
+	visible := make([]string, 0, len(authorIDs))
+	for _, authorID := range authorIDs {
+		if _, ok := hidden[authorID]; !ok {
+			visible = append(visible, authorID)
+		}
+	}
+	return visible
 }
@@ -3,11 +3,15 @@ package timeline_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +28,7 @@ func TestGetTimeline(t *testing.T) {
 	tweet2 := uuid.NewString()
 	tweet3 := uuid.NewString()
 	limit := 10
-	now := time.Now().Format(time.RFC3339)
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
 	tweetIDs := []string{tweet1, tweet2}
 	mockTweets := []domain.Tweet{
 		{ID: tweet1, UserID: user1, Text: "Hello from cache!", CreatedAt: now},
@@ -36,20 +40,23 @@ func TestGetTimeline(t *testing.T) {
 	}
 
 	fallbackFollowers := []string{user2, user3}
+	warmUpTweetIDs := []string{tweet3, tweet2}
 
 	// Define reusable errors
 	cacheError := errors.New("redis connection refused")
 	dbError := errors.New("postgres connection failed")
 
 	testCases := []struct {
-		name           string
-		setupMocks     func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
-		expectedTweets []domain.Tweet
-		expectedErr    error
+		name             string
+		cursor           string
+		setupMocks       func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup)
+		expectedTweets   []domain.Tweet
+		expectedDegraded bool
+		expectedErr      error
 	}{
 		{
 			name: "Success - Cache Hit",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Expect a call to the cache, which returns tweet IDs.
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), int64(0), int64(limit-1)).
@@ -61,13 +68,39 @@ func TestGetTimeline(t *testing.T) {
 					SelectTweetsByTweetsIDs(gomock.Any(), tweetIDs).
 					Return(mockTweets, nil).
 					Times(1)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+
+				// 3. Expect the TTL of the cached timeline to be refreshed.
+				cache.EXPECT().
+					Expire(gomock.Any(), "timeline:"+user1, 72*time.Hour).
+					Return(nil).
+					Times(1)
+			},
+			expectedTweets: mockTweets,
+			expectedErr:    nil,
+		},
+		{
+			name: "Success - Cache Hit, TTL refresh error is ignored",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), int64(0), int64(limit-1)).
+					Return(tweetIDs, nil)
+
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), tweetIDs).
+					Return(mockTweets, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+
+				cache.EXPECT().
+					Expire(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("redis command failed"))
 			},
 			expectedTweets: mockTweets,
 			expectedErr:    nil,
 		},
 		{
 			name: "Success - Cache Miss, Fallback Succeeds",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Expect a call to the cache, which returns an empty slice (cache miss).
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -76,19 +109,57 @@ func TestGetTimeline(t *testing.T) {
 				//2. Expect a call to the storage to SelectFollowersByUserID
 				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
 					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
 
 				// 3. Expect a call to the fallback method in storage.
 				storage.EXPECT().
-					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers).
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, "", limit).
 					Return(fallbackTweets, nil).
 					Times(1)
+
+				// 4. Expect the async cache warm-up. We use a WaitGroup to test this.
+				wg.Add(1)
+				storage.EXPECT().
+					SelectTweetIDsByUsersID(gomock.Any(), fallbackFollowers, 800).
+					Return(warmUpTweetIDs, nil)
+				cache.EXPECT().
+					RebuildList(gomock.Any(), "timeline:"+user1, warmUpTweetIDs, int64(800), 72*time.Hour).
+					DoAndReturn(func(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+						wg.Done()
+						return nil
+					})
 			},
 			expectedTweets: fallbackTweets,
 			expectedErr:    nil,
 		},
+		{
+			name: "Success - Cache Miss, Fallback leaves out hidden authors but warms up with every followee",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
+					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return([]string{user2}, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), []string{user3}, "", limit).
+					Return(fallbackTweets[1:], nil)
+
+				// The cached list keeps the hidden author, they are filtered when it's read.
+				wg.Add(1)
+				storage.EXPECT().
+					SelectTweetIDsByUsersID(gomock.Any(), fallbackFollowers, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+						wg.Done()
+						return []string{}, nil
+					})
+			},
+			expectedTweets: fallbackTweets[1:],
+			expectedErr:    nil,
+		},
 		{
 			name: "Success - Cache Miss, Fallback is Empty",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Cache miss.
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -97,30 +168,165 @@ func TestGetTimeline(t *testing.T) {
 				//2. Expect a call to the storage to SelectFollowersByUserID
 				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
 					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
 
 				// 3. Fallback returns no tweets.
 				storage.EXPECT().
-					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers).
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, "", limit).
 					Return([]domain.Tweet{}, nil)
+
+				// 4. The warm-up finds nothing either, so the cache is left untouched.
+				wg.Add(1)
+				storage.EXPECT().
+					SelectTweetIDsByUsersID(gomock.Any(), fallbackFollowers, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+						wg.Done()
+						return []string{}, nil
+					})
+				cache.EXPECT().RebuildList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			expectedTweets: []domain.Tweet{},
 			expectedErr:    nil,
 		},
 		{
-			name: "Failure - Cache Error",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
-				// Expect a call to the cache, which returns an error.
+			name: "Success - Cache Miss, warm-up failure does not fail the request",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
+					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, "", limit).
+					Return(fallbackTweets, nil)
+
+				wg.Add(1)
+				storage.EXPECT().
+					SelectTweetIDsByUsersID(gomock.Any(), fallbackFollowers, gomock.Any()).
+					Return(warmUpTweetIDs, nil)
+				cache.EXPECT().
+					RebuildList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+						wg.Done()
+						return cacheError
+					})
+			},
+			expectedTweets: fallbackTweets,
+			expectedErr:    nil,
+		},
+		{
+			name:   "Success - Cursor in cache, page starts after it",
+			cursor: tweet1,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				// 1. The cursor is the second element of the cached list.
+				cache.EXPECT().
+					LPos(gomock.Any(), "timeline:"+user1, tweet1).
+					Return(int64(1), nil)
+
+				// 2. The page starts right after the cursor.
+				cache.EXPECT().
+					LRange(gomock.Any(), "timeline:"+user1, int64(2), int64(2+limit-1)).
+					Return([]string{tweet2}, nil)
+
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet2}).
+					Return(mockTweets[1:], nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+
+				cache.EXPECT().
+					Expire(gomock.Any(), "timeline:"+user1, gomock.Any()).
+					Return(nil)
+			},
+			expectedTweets: mockTweets[1:],
+			expectedErr:    nil,
+		},
+		{
+			name:   "Success - Cursor older than the cached window, served from fallback",
+			cursor: tweet1,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LPos(gomock.Any(), gomock.Any(), tweet1).
+					Return(int64(-1), nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
+					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, tweet1, limit).
+					Return(fallbackTweets, nil)
+
+				// Paging past the cached window must not rebuild the cache.
+				storage.EXPECT().SelectTweetIDsByUsersID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedTweets: fallbackTweets,
+			expectedErr:    nil,
+		},
+		{
+			name:   "Success - Cursor is the last cached tweet, served from fallback",
+			cursor: tweet2,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LPos(gomock.Any(), gomock.Any(), tweet2).
+					Return(int64(1), nil)
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), int64(2), gomock.Any()).
+					Return([]string{}, nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
+					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, tweet2, limit).
+					Return(fallbackTweets, nil)
+			},
+			expectedTweets: fallbackTweets,
+			expectedErr:    nil,
+		},
+		{
+			name:   "Success - Cache Error looking up the cursor, degraded read from fallback",
+			cursor: tweet1,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LPos(gomock.Any(), gomock.Any(), tweet1).
+					Return(int64(0), cacheError)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, tweet1, limit).
+					Return(fallbackTweets, nil)
+			},
+			expectedTweets:   fallbackTweets,
+			expectedDegraded: true,
+		},
+		{
+			name: "Success - Cache Error, degraded read from fallback without warm-up",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, cacheError)
-				// No calls to storage should be made if the cache fails.
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
+				storage.EXPECT().
+					SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, "", limit).
+					Return(fallbackTweets, nil)
+				// No SelectTweetIDsByUsersID or RebuildList: the cache isn't rebuilt while Redis fails.
+			},
+			expectedTweets:   fallbackTweets,
+			expectedDegraded: true,
+		},
+		{
+			name: "Failure - Cache Error and Fallback Fails",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, cacheError)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).Return(nil, dbError)
 			},
 			expectedTweets: nil,
-			expectedErr:    cacheError,
+			expectedErr:    dbError,
 		},
 		{
 			name: "Failure - Cache Hit, Hydration Fails",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Cache returns IDs successfully.
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -134,9 +340,23 @@ func TestGetTimeline(t *testing.T) {
 			expectedTweets: nil,
 			expectedErr:    dbError,
 		},
+		{
+			name: "Failure - Cache Hit, Hidden Authors Fail",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				cache.EXPECT().
+					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tweetIDs, nil)
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), tweetIDs).
+					Return(mockTweets, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, dbError)
+			},
+			expectedTweets: nil,
+			expectedErr:    dbError,
+		},
 		{
 			name: "Failure - Cache Miss, Fallback Fails when call to SelectFollowersByUserID()",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Cache miss.
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -151,7 +371,7 @@ func TestGetTimeline(t *testing.T) {
 		},
 		{
 			name: "Failure - Cache Miss, Fallback Fails when call to SelectLastTweetsByUsersID()",
-			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
 				// 1. Cache miss.
 				cache.EXPECT().
 					LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -160,9 +380,10 @@ func TestGetTimeline(t *testing.T) {
 				//2. Expect a call to the storage to SelectFollowersByUserID
 				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), user1).
 					Return(fallbackFollowers, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), user1).Return(nil, nil)
 
 				//2. Expect a call to the storage to SelectFollowersByUserID
-				storage.EXPECT().SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers).
+				storage.EXPECT().SelectLastTweetsByUsersID(gomock.Any(), fallbackFollowers, "", limit).
 					Return(nil, dbError)
 			},
 			expectedTweets: nil,
@@ -176,15 +397,19 @@ func TestGetTimeline(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockStorage := mocks.NewMockStorageRepo(ctrl)
 			mockCache := mocks.NewMockCacheRepository(ctrl)
+			wg := &sync.WaitGroup{}
 
 			if tc.setupMocks != nil {
-				tc.setupMocks(mockStorage, mockCache)
+				tc.setupMocks(mockStorage, mockCache, wg)
 			}
 
-			service := timeline.NewService(mockStorage, mockCache)
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
 
 			// Act
-			resultTweets, err := service.GetTimeline(context.Background(), user1, limit)
+			resultPage, err := service.GetTimeline(context.Background(), user1, limit, tc.cursor)
+
+			// Wait for the warm-up goroutine to finish (if one was expected)
+			wg.Wait()
 
 			// Assert
 			if tc.expectedErr != nil {
@@ -194,7 +419,140 @@ func TestGetTimeline(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			assert.Equal(t, tc.expectedTweets, resultTweets)
+			assert.Equal(t, tc.expectedTweets, resultPage.Tweets)
+			assert.Equal(t, tc.expectedDegraded, resultPage.Degraded)
+		})
+	}
+}
+
+func TestGetTimeline_Metrics(t *testing.T) {
+	userID := uuid.NewString()
+	cursor := uuid.NewString()
+	tweetIDs := []string{uuid.NewString()}
+	limit := 10
+
+	testCases := []struct {
+		name              string
+		cursor            string
+		setupMocks        func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+		expectedHits      float64
+		expectedMisses    float64
+		expectedFallbacks float64
+	}{
+		{
+			name: "Cache hit",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tweetIDs, nil)
+				storage.EXPECT().SelectTweetsByTweetsIDs(gomock.Any(), tweetIDs).Return([]domain.Tweet{{ID: tweetIDs[0]}}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+				cache.EXPECT().Expire(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedHits: 1,
+		},
+		{
+			name:   "Cache miss, cursor not cached",
+			cursor: cursor,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().LPos(gomock.Any(), gomock.Any(), cursor).Return(int64(-1), nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return([]string{}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+				storage.EXPECT().SelectLastTweetsByUsersID(gomock.Any(), gomock.Any(), cursor, limit).Return([]domain.Tweet{}, nil)
+			},
+			expectedMisses:    1,
+			expectedFallbacks: 1,
+		},
+		{
+			name:   "Cache miss, fallback fails",
+			cursor: cursor,
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				cache.EXPECT().LPos(gomock.Any(), gomock.Any(), cursor).Return(int64(-1), nil)
+				storage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(nil, errors.New("db down"))
+			},
+			expectedMisses: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMocks(mockStorage, mockCache)
+
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+			hits := testutil.ToFloat64(metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheHit))
+			misses := testutil.ToFloat64(metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheMiss))
+			fallbacks := testutil.ToFloat64(metrics.TimelineFallbacks)
+
+			// Act
+			_, _ = service.GetTimeline(context.Background(), userID, limit, tc.cursor)
+
+			// Assert
+			assert.Equal(t, tc.expectedHits, testutil.ToFloat64(metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheHit))-hits)
+			assert.Equal(t, tc.expectedMisses, testutil.ToFloat64(metrics.TimelineCacheRequests.WithLabelValues(metrics.CacheMiss))-misses)
+			assert.Equal(t, tc.expectedFallbacks, testutil.ToFloat64(metrics.TimelineFallbacks)-fallbacks)
 		})
 	}
 }
+
+// TestGetTimeline_FallbackSingleFlight verifies that concurrent fallback reads of the same page
+// share a single set of queries against storage.
+func TestGetTimeline_FallbackSingleFlight(t *testing.T) {
+	const concurrentReads = 5
+
+	// Arrange
+	userID := uuid.NewString()
+	cursor := uuid.NewString()
+	followees := []string{uuid.NewString()}
+	tweets := []domain.Tweet{{ID: uuid.NewString(), UserID: followees[0], Text: "older tweet"}}
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockCache := mocks.NewMockCacheRepository(ctrl)
+
+	// The cursor is past the cached window, so every request falls back to PostgreSQL.
+	mockCache.EXPECT().
+		LPos(gomock.Any(), "timeline:"+userID, cursor).
+		Return(int64(-1), nil).
+		Times(concurrentReads)
+
+	// The read blocks until every request had the chance to join it.
+	release := make(chan struct{})
+	mockStorage.EXPECT().SelectFollowersByUserID(gomock.Any(), userID).Return(followees, nil).Times(1)
+	mockStorage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil).Times(1)
+	mockStorage.EXPECT().
+		SelectLastTweetsByUsersID(gomock.Any(), followees, cursor, 10).
+		DoAndReturn(func(ctx context.Context, userIDs []string, cursor string, limit int) ([]domain.Tweet, error) {
+			<-release
+			return tweets, nil
+		}).
+		Times(1)
+
+	service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+	// Act
+	pages := make(chan domain.TimelinePage, concurrentReads)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentReads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			page, err := service.GetTimeline(context.Background(), userID, 10, cursor)
+			require.NoError(t, err)
+			pages <- page
+		}()
+	}
+
+	// Give the requests time to reach the in-flight read, then let it finish.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(pages)
+
+	// Assert
+	for page := range pages {
+		assert.Equal(t, tweets, page.Tweets)
+	}
+}
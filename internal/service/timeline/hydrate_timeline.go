@@ -0,0 +1,112 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// pruneTimeout bounds the background cleanup of a cached timeline list.
+const pruneTimeout = 2 * time.Second
+
+// hydrateTimeline loads the tweets for tweetIDs from storage and returns them in the exact
+// cache order. Repeated IDs are returned once. IDs without a row (e.g. deleted tweets) are
+// skipped and, together with the repeated ones, pruned from the cached list in the background.
+// Tweets of the authors hidden from userID are skipped too, but stay in the list: they show
+// again once the author is unmuted or unblocked.
+func (s Service) hydrateTimeline(ctx context.Context, userID, timelineKey string, tweetIDs []string) ([]domain.Tweet, error) {
+	uniqueIDs, duplicates := dedupeTweetIDs(tweetIDs)
+
+	stored, err := s.Storage.SelectTweetsByTweetsIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error hydrating tweets from storage: %w", err)
+	}
+
+	hidden, err := s.hiddenAuthors(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tweetsByID := make(map[string]domain.Tweet, len(stored))
+	for _, tweet := range stored {
+		tweetsByID[tweet.ID] = tweet
+	}
+
+	tweets := make([]domain.Tweet, 0, len(uniqueIDs))
+	var missing []string
+	for _, tweetID := range uniqueIDs {
+		tweet, ok := tweetsByID[tweetID]
+		if !ok {
+			missing = append(missing, tweetID)
+			continue
+		}
+		if _, ok := hidden[tweet.UserID]; ok {
+			continue
+		}
+		tweets = append(tweets, tweet)
+	}
+
+	if len(missing) > 0 || len(duplicates) > 0 {
+		s.Logger.WarnContext(ctx, "pruning missing and repeated tweet ids from cached timeline",
+			"key", timelineKey, "missing", len(missing), "repeated", len(duplicates))
+		go s.pruneTimeline(timelineKey, missing, duplicates)
+	}
+
+	return tweets, nil
+}
+
+// hiddenAuthors returns the set of authors whose tweets are hidden from the timeline of userID.
+func (s Service) hiddenAuthors(ctx context.Context, userID string) (map[string]struct{}, error) {
+	authorIDs, err := s.Storage.SelectHiddenAuthorIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hidden authors: %w", err)
+	}
+
+	hidden := make(map[string]struct{}, len(authorIDs))
+	for _, authorID := range authorIDs {
+		hidden[authorID] = struct{}{}
+	}
+	return hidden, nil
+}
+
+// pruneTimeline removes missing tweet IDs and the extra copies of repeated ones from the list.
+// Repeated IDs are removed from the tail so the newest copy keeps its position.
+func (s Service) pruneTimeline(timelineKey string, missing []string, duplicates map[string]int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), pruneTimeout)
+	defer cancel()
+
+	for _, tweetID := range missing {
+		if err := s.Cache.LRem(ctx, timelineKey, 0, tweetID); err != nil {
+			s.fanOutLogger.ErrorContext(ctx, "failed to prune missing tweet", "key", timelineKey, "tweet_id", tweetID, "error", err)
+		}
+	}
+
+	for tweetID, extra := range duplicates {
+		if err := s.Cache.LRem(ctx, timelineKey, -extra, tweetID); err != nil {
+			s.fanOutLogger.ErrorContext(ctx, "failed to prune repeated tweet", "key", timelineKey, "tweet_id", tweetID, "error", err)
+		}
+	}
+}
+
+// dedupeTweetIDs keeps the first occurrence of every ID and counts the extra occurrences.
+func dedupeTweetIDs(tweetIDs []string) ([]string, map[string]int64) {
+	seen := make(map[string]struct{}, len(tweetIDs))
+	uniqueIDs := make([]string, 0, len(tweetIDs))
+	var duplicates map[string]int64
+
+	for _, tweetID := range tweetIDs {
+		if _, ok := seen[tweetID]; ok {
+			if duplicates == nil {
+				duplicates = make(map[string]int64)
+			}
+			duplicates[tweetID]++
+			continue
+		}
+		seen[tweetID] = struct{}{}
+		uniqueIDs = append(uniqueIDs, tweetID)
+	}
+
+	return uniqueIDs, duplicates
+}
@@ -0,0 +1,170 @@
+package timeline_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestGetTimeline_Hydration covers how cached IDs are turned into tweets.
+func TestGetTimeline_Hydration(t *testing.T) {
+	userID := uuid.NewString()
+	timelineKey := "timeline:" + userID
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tweet1 := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "newest", CreatedAt: now}
+	tweet2 := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "middle", CreatedAt: now}
+	tweet3 := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "oldest", CreatedAt: now}
+	deletedID := uuid.NewString()
+
+	cacheError := errors.New("redis command failed")
+
+	// lremDone releases the WaitGroup once the background prune touched the cache.
+	lremDone := func(wg *sync.WaitGroup, err error) func(ctx context.Context, key string, count int64, value interface{}) error {
+		return func(ctx context.Context, key string, count int64, value interface{}) error {
+			wg.Done()
+			return err
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		cachedIDs      []string
+		setupMocks     func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup)
+		expectedTweets []domain.Tweet
+	}{
+		{
+			name:      "Success - keeps the cache order regardless of storage order",
+			cachedIDs: []string{tweet1.ID, tweet2.ID, tweet3.ID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1.ID, tweet2.ID, tweet3.ID}).
+					Return([]domain.Tweet{tweet3, tweet1, tweet2}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+
+				// Nothing to prune.
+				cache.EXPECT().LRem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedTweets: []domain.Tweet{tweet1, tweet2, tweet3},
+		},
+		{
+			name:      "Success - partial hydration skips and prunes missing tweets",
+			cachedIDs: []string{tweet1.ID, deletedID, tweet2.ID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1.ID, deletedID, tweet2.ID}).
+					Return([]domain.Tweet{tweet2, tweet1}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+
+				wg.Add(1)
+				cache.EXPECT().
+					LRem(gomock.Any(), timelineKey, int64(0), deletedID).
+					DoAndReturn(lremDone(wg, nil))
+			},
+			expectedTweets: []domain.Tweet{tweet1, tweet2},
+		},
+		{
+			name:      "Success - repeated ids are returned once and extra copies pruned",
+			cachedIDs: []string{tweet1.ID, tweet2.ID, tweet1.ID, tweet1.ID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1.ID, tweet2.ID}).
+					Return([]domain.Tweet{tweet1, tweet2}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+
+				// Two extra copies are removed from the tail, keeping the newest one.
+				wg.Add(1)
+				cache.EXPECT().
+					LRem(gomock.Any(), timelineKey, int64(-2), tweet1.ID).
+					DoAndReturn(lremDone(wg, nil))
+			},
+			expectedTweets: []domain.Tweet{tweet1, tweet2},
+		},
+		{
+			name:      "Success - prune failure does not fail the request",
+			cachedIDs: []string{deletedID, tweet3.ID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), gomock.Any()).
+					Return([]domain.Tweet{tweet3}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+
+				wg.Add(1)
+				cache.EXPECT().
+					LRem(gomock.Any(), timelineKey, int64(0), deletedID).
+					DoAndReturn(lremDone(wg, cacheError))
+			},
+			expectedTweets: []domain.Tweet{tweet3},
+		},
+		{
+			name:      "Success - skips the tweets of hidden authors without pruning them",
+			cachedIDs: []string{tweet1.ID, tweet2.ID, tweet3.ID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{tweet1.ID, tweet2.ID, tweet3.ID}).
+					Return([]domain.Tweet{tweet1, tweet2, tweet3}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return([]string{tweet2.UserID}, nil)
+
+				// The tweet shows again once its author is unmuted.
+				cache.EXPECT().LRem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedTweets: []domain.Tweet{tweet1, tweet3},
+		},
+		{
+			name:      "Success - every cached tweet is missing",
+			cachedIDs: []string{deletedID},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository, wg *sync.WaitGroup) {
+				storage.EXPECT().
+					SelectTweetsByTweetsIDs(gomock.Any(), []string{deletedID}).
+					Return([]domain.Tweet{}, nil)
+				storage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil)
+
+				wg.Add(1)
+				cache.EXPECT().
+					LRem(gomock.Any(), timelineKey, int64(0), deletedID).
+					DoAndReturn(lremDone(wg, nil))
+			},
+			expectedTweets: []domain.Tweet{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			wg := &sync.WaitGroup{}
+
+			mockCache.EXPECT().
+				LRange(gomock.Any(), timelineKey, int64(0), int64(9)).
+				Return(tc.cachedIDs, nil)
+			mockCache.EXPECT().
+				Expire(gomock.Any(), timelineKey, gomock.Any()).
+				Return(nil)
+			tc.setupMocks(mockStorage, mockCache, wg)
+
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+			// Act
+			page, err := service.GetTimeline(context.Background(), userID, 10, "")
+
+			// Wait for the prune goroutine to finish (if one was expected)
+			wg.Wait()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTweets, page.Tweets)
+		})
+	}
+}
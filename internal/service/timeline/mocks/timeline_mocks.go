@@ -42,6 +42,94 @@ func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
 	return m.recorder
 }
 
+// ClaimStaleFanOutJobs mocks base method.
+func (m *MockStorageRepo) ClaimStaleFanOutJobs(ctx context.Context, staleAfter time.Duration, maxAttempts, limit int) ([]domain.FanOutJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimStaleFanOutJobs", ctx, staleAfter, maxAttempts, limit)
+	ret0, _ := ret[0].([]domain.FanOutJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimStaleFanOutJobs indicates an expected call of ClaimStaleFanOutJobs.
+func (mr *MockStorageRepoMockRecorder) ClaimStaleFanOutJobs(ctx, staleAfter, maxAttempts, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimStaleFanOutJobs", reflect.TypeOf((*MockStorageRepo)(nil).ClaimStaleFanOutJobs), ctx, staleAfter, maxAttempts, limit)
+}
+
+// CountTweetsSince mocks base method.
+func (m *MockStorageRepo) CountTweetsSince(ctx context.Context, userIDs []string, sinceTweetID string, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTweetsSince", ctx, userIDs, sinceTweetID, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTweetsSince indicates an expected call of CountTweetsSince.
+func (mr *MockStorageRepoMockRecorder) CountTweetsSince(ctx, userIDs, sinceTweetID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTweetsSince", reflect.TypeOf((*MockStorageRepo)(nil).CountTweetsSince), ctx, userIDs, sinceTweetID, limit)
+}
+
+// CreateFanOutJob mocks base method.
+func (m *MockStorageRepo) CreateFanOutJob(ctx context.Context, tweetID, authorID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFanOutJob", ctx, tweetID, authorID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFanOutJob indicates an expected call of CreateFanOutJob.
+func (mr *MockStorageRepoMockRecorder) CreateFanOutJob(ctx, tweetID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFanOutJob", reflect.TypeOf((*MockStorageRepo)(nil).CreateFanOutJob), ctx, tweetID, authorID)
+}
+
+// FinishFanOutJob mocks base method.
+func (m *MockStorageRepo) FinishFanOutJob(ctx context.Context, tweetID, status, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishFanOutJob", ctx, tweetID, status, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishFanOutJob indicates an expected call of FinishFanOutJob.
+func (mr *MockStorageRepoMockRecorder) FinishFanOutJob(ctx, tweetID, status, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishFanOutJob", reflect.TypeOf((*MockStorageRepo)(nil).FinishFanOutJob), ctx, tweetID, status, lastError)
+}
+
+// ForEachFollowerBatch mocks base method.
+func (m *MockStorageRepo) ForEachFollowerBatch(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func([]string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachFollowerBatch", ctx, userID, afterFollowerID, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachFollowerBatch indicates an expected call of ForEachFollowerBatch.
+func (mr *MockStorageRepoMockRecorder) ForEachFollowerBatch(ctx, userID, afterFollowerID, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachFollowerBatch", reflect.TypeOf((*MockStorageRepo)(nil).ForEachFollowerBatch), ctx, userID, afterFollowerID, batchSize, fn)
+}
+
+// SelectFanOutJob mocks base method.
+func (m *MockStorageRepo) SelectFanOutJob(ctx context.Context, tweetID string) (*domain.FanOutJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFanOutJob", ctx, tweetID)
+	ret0, _ := ret[0].(*domain.FanOutJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFanOutJob indicates an expected call of SelectFanOutJob.
+func (mr *MockStorageRepoMockRecorder) SelectFanOutJob(ctx, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFanOutJob", reflect.TypeOf((*MockStorageRepo)(nil).SelectFanOutJob), ctx, tweetID)
+}
+
 // SelectFollowersByUserID mocks base method.
 func (m *MockStorageRepo) SelectFollowersByUserID(ctx context.Context, userID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -57,19 +145,49 @@ func (mr *MockStorageRepoMockRecorder) SelectFollowersByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFollowersByUserID", reflect.TypeOf((*MockStorageRepo)(nil).SelectFollowersByUserID), ctx, userID)
 }
 
+// SelectHiddenAuthorIDs mocks base method.
+func (m *MockStorageRepo) SelectHiddenAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectHiddenAuthorIDs", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectHiddenAuthorIDs indicates an expected call of SelectHiddenAuthorIDs.
+func (mr *MockStorageRepoMockRecorder) SelectHiddenAuthorIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectHiddenAuthorIDs", reflect.TypeOf((*MockStorageRepo)(nil).SelectHiddenAuthorIDs), ctx, userID)
+}
+
 // SelectLastTweetsByUsersID mocks base method.
-func (m *MockStorageRepo) SelectLastTweetsByUsersID(ctx context.Context, userIDs []string) ([]domain.Tweet, error) {
+func (m *MockStorageRepo) SelectLastTweetsByUsersID(ctx context.Context, userIDs []string, cursor string, limit int) ([]domain.Tweet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectLastTweetsByUsersID", ctx, userIDs)
+	ret := m.ctrl.Call(m, "SelectLastTweetsByUsersID", ctx, userIDs, cursor, limit)
 	ret0, _ := ret[0].([]domain.Tweet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectLastTweetsByUsersID indicates an expected call of SelectLastTweetsByUsersID.
-func (mr *MockStorageRepoMockRecorder) SelectLastTweetsByUsersID(ctx, userIDs any) *gomock.Call {
+func (mr *MockStorageRepoMockRecorder) SelectLastTweetsByUsersID(ctx, userIDs, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLastTweetsByUsersID", reflect.TypeOf((*MockStorageRepo)(nil).SelectLastTweetsByUsersID), ctx, userIDs, cursor, limit)
+}
+
+// SelectTweetIDsByUsersID mocks base method.
+func (m *MockStorageRepo) SelectTweetIDsByUsersID(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTweetIDsByUsersID", ctx, userIDs, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTweetIDsByUsersID indicates an expected call of SelectTweetIDsByUsersID.
+func (mr *MockStorageRepoMockRecorder) SelectTweetIDsByUsersID(ctx, userIDs, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLastTweetsByUsersID", reflect.TypeOf((*MockStorageRepo)(nil).SelectLastTweetsByUsersID), ctx, userIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTweetIDsByUsersID", reflect.TypeOf((*MockStorageRepo)(nil).SelectTweetIDsByUsersID), ctx, userIDs, limit)
 }
 
 // SelectTweetsByTweetsIDs mocks base method.
@@ -87,6 +205,20 @@ func (mr *MockStorageRepoMockRecorder) SelectTweetsByTweetsIDs(ctx, tweetIDs any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTweetsByTweetsIDs", reflect.TypeOf((*MockStorageRepo)(nil).SelectTweetsByTweetsIDs), ctx, tweetIDs)
 }
 
+// UpdateFanOutJobCheckpoint mocks base method.
+func (m *MockStorageRepo) UpdateFanOutJobCheckpoint(ctx context.Context, tweetID, lastFollowerID string, processedCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFanOutJobCheckpoint", ctx, tweetID, lastFollowerID, processedCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFanOutJobCheckpoint indicates an expected call of UpdateFanOutJobCheckpoint.
+func (mr *MockStorageRepoMockRecorder) UpdateFanOutJobCheckpoint(ctx, tweetID, lastFollowerID, processedCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFanOutJobCheckpoint", reflect.TypeOf((*MockStorageRepo)(nil).UpdateFanOutJobCheckpoint), ctx, tweetID, lastFollowerID, processedCount)
+}
+
 // MockCacheRepository is a mock of CacheRepository interface.
 type MockCacheRepository struct {
 	ctrl     *gomock.Controller
@@ -111,23 +243,65 @@ func (m *MockCacheRepository) EXPECT() *MockCacheRepositoryMockRecorder {
 	return m.recorder
 }
 
-// LPush mocks base method.
-func (m *MockCacheRepository) LPush(ctx context.Context, key string, values ...any) error {
+// Expire mocks base method.
+func (m *MockCacheRepository) Expire(ctx context.Context, key string, expiration time.Duration) error {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, key}
-	for _, a := range values {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "LPush", varargs...)
+	ret := m.ctrl.Call(m, "Expire", ctx, key, expiration)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// LPush indicates an expected call of LPush.
-func (mr *MockCacheRepositoryMockRecorder) LPush(ctx, key any, values ...any) *gomock.Call {
+// Expire indicates an expected call of Expire.
+func (mr *MockCacheRepositoryMockRecorder) Expire(ctx, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockCacheRepository)(nil).Expire), ctx, key, expiration)
+}
+
+// LPos mocks base method.
+func (m *MockCacheRepository) LPos(ctx context.Context, key, value string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPos", ctx, key, value)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LPos indicates an expected call of LPos.
+func (mr *MockCacheRepositoryMockRecorder) LPos(ctx, key, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, key}, values...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPush", reflect.TypeOf((*MockCacheRepository)(nil).LPush), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPos", reflect.TypeOf((*MockCacheRepository)(nil).LPos), ctx, key, value)
+}
+
+// LPushTrimPipeline mocks base method.
+func (m *MockCacheRepository) LPushTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value any) (int, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPushTrimPipeline", ctx, keys, maxLen, expiration, value)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LPushTrimPipeline indicates an expected call of LPushTrimPipeline.
+func (mr *MockCacheRepositoryMockRecorder) LPushTrimPipeline(ctx, keys, maxLen, expiration, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPushTrimPipeline", reflect.TypeOf((*MockCacheRepository)(nil).LPushTrimPipeline), ctx, keys, maxLen, expiration, value)
+}
+
+// LPushUniqueTrimPipeline mocks base method.
+func (m *MockCacheRepository) LPushUniqueTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value any) (int, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPushUniqueTrimPipeline", ctx, keys, maxLen, expiration, value)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LPushUniqueTrimPipeline indicates an expected call of LPushUniqueTrimPipeline.
+func (mr *MockCacheRepositoryMockRecorder) LPushUniqueTrimPipeline(ctx, keys, maxLen, expiration, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPushUniqueTrimPipeline", reflect.TypeOf((*MockCacheRepository)(nil).LPushUniqueTrimPipeline), ctx, keys, maxLen, expiration, value)
 }
 
 // LRange mocks base method.
@@ -145,16 +319,82 @@ func (mr *MockCacheRepositoryMockRecorder) LRange(ctx, key, start, stop any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRange", reflect.TypeOf((*MockCacheRepository)(nil).LRange), ctx, key, start, stop)
 }
 
-// Set mocks base method.
-func (m *MockCacheRepository) Set(ctx context.Context, key string, value any, expiration time.Duration) error {
+// LRem mocks base method.
+func (m *MockCacheRepository) LRem(ctx context.Context, key string, count int64, value any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", ctx, key, value, expiration)
+	ret := m.ctrl.Call(m, "LRem", ctx, key, count, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Set indicates an expected call of Set.
-func (mr *MockCacheRepositoryMockRecorder) Set(ctx, key, value, expiration any) *gomock.Call {
+// LRem indicates an expected call of LRem.
+func (mr *MockCacheRepositoryMockRecorder) LRem(ctx, key, count, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRem", reflect.TypeOf((*MockCacheRepository)(nil).LRem), ctx, key, count, value)
+}
+
+// PublishPipeline mocks base method.
+func (m *MockCacheRepository) PublishPipeline(ctx context.Context, channels []string, message any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPipeline", ctx, channels, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPipeline indicates an expected call of PublishPipeline.
+func (mr *MockCacheRepositoryMockRecorder) PublishPipeline(ctx, channels, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPipeline", reflect.TypeOf((*MockCacheRepository)(nil).PublishPipeline), ctx, channels, message)
+}
+
+// RebuildList mocks base method.
+func (m *MockCacheRepository) RebuildList(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildList", ctx, key, values, maxLen, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildList indicates an expected call of RebuildList.
+func (mr *MockCacheRepositoryMockRecorder) RebuildList(ctx, key, values, maxLen, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildList", reflect.TypeOf((*MockCacheRepository)(nil).RebuildList), ctx, key, values, maxLen, expiration)
+}
+
+// MockCacheAvailability is a mock of CacheAvailability interface.
+type MockCacheAvailability struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheAvailabilityMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheAvailabilityMockRecorder is the mock recorder for MockCacheAvailability.
+type MockCacheAvailabilityMockRecorder struct {
+	mock *MockCacheAvailability
+}
+
+// NewMockCacheAvailability creates a new mock instance.
+func NewMockCacheAvailability(ctrl *gomock.Controller) *MockCacheAvailability {
+	mock := &MockCacheAvailability{ctrl: ctrl}
+	mock.recorder = &MockCacheAvailabilityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheAvailability) EXPECT() *MockCacheAvailabilityMockRecorder {
+	return m.recorder
+}
+
+// Available mocks base method.
+func (m *MockCacheAvailability) Available() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Available")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Available indicates an expected call of Available.
+func (mr *MockCacheAvailabilityMockRecorder) Available() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheRepository)(nil).Set), ctx, key, value, expiration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Available", reflect.TypeOf((*MockCacheAvailability)(nil).Available))
 }
@@ -0,0 +1,63 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// fanOutRecoveryBatch caps the jobs claimed by a single recovery pass.
+const fanOutRecoveryBatch = 10
+
+// ResumeFanOutJobs claims the fan-out jobs that were left unfinished, by a crash or by a failed
+// run, and weren't updated for FanOutRecoveryInterval, then runs them again from their
+// checkpoint. Jobs are given up after FanOutMaxAttempts runs. It returns how many jobs ran.
+// Nothing is claimed while the cache circuit breaker is open: the jobs wait for Redis instead
+// of spending their attempts.
+func (s Service) ResumeFanOutJobs(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "timeline.ResumeFanOutJobs")
+	defer span.End()
+
+	if !s.cacheAvailable() {
+		span.SetAttributes(attribute.Bool("fan_out.cache_unavailable", true))
+		s.Logger.WarnContext(ctx, "timeline cache unavailable, fan-out jobs will be resumed later")
+		return 0, nil
+	}
+
+	jobs, err := s.Storage.ClaimStaleFanOutJobs(ctx, s.Config.FanOutRecoveryInterval, s.Config.FanOutMaxAttempts, fanOutRecoveryBatch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error claiming fan-out jobs")
+		return 0, fmt.Errorf("error claiming fan-out jobs: %w", err)
+	}
+	span.SetAttributes(attribute.Int("fan_out.jobs", len(jobs)))
+
+	for _, job := range jobs {
+		s.Logger.InfoContext(ctx, "resuming fan-out",
+			"tweet_id", job.TweetID, "last_follower_id", job.LastFollowerID, "attempt", job.Attempts)
+		s.runFanOut(ctx, job, true)
+	}
+
+	return len(jobs), nil
+}
+
+// RunFanOutRecovery calls ResumeFanOutJobs every FanOutRecoveryInterval until ctx is cancelled.
+// It's meant to be started in its own goroutine.
+func (s Service) RunFanOutRecovery(ctx context.Context) {
+	ticker := time.NewTicker(s.Config.FanOutRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ResumeFanOutJobs(ctx); err != nil {
+				s.Logger.ErrorContext(ctx, "fan-out recovery failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package timeline_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResumeFanOutJobs(t *testing.T) {
+	authorID := uuid.NewString()
+	tweetID := uuid.NewString()
+	checkpoint := uuid.NewString()
+	follower := uuid.NewString()
+	job := domain.FanOutJob{
+		TweetID:        tweetID,
+		AuthorID:       authorID,
+		Status:         domain.FanOutJobRunning,
+		LastFollowerID: checkpoint,
+		ProcessedCount: 500,
+		Attempts:       2,
+	}
+	dbError := errors.New("database connection failed")
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "Success - Resumes a job from its checkpoint",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ClaimStaleFanOutJobs(gomock.Any(), time.Minute, 5, 10).
+					Return([]domain.FanOutJob{job}, nil)
+
+				// Followers are read after the checkpoint.
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, checkpoint, 500, gomock.Any()).
+					DoAndReturn(followerBatches([]string{follower}))
+
+				// The page may have been delivered before the crash, so pushes are unique.
+				cache.EXPECT().
+					LPushUniqueTrimPipeline(gomock.Any(), []string{fmt.Sprintf("timeline:%s", follower)}, int64(800), 72*time.Hour, tweetID).
+					Return(1, nil, nil)
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				cache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				// The processed count keeps growing from the saved one.
+				storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, follower, 501).Return(nil)
+				storage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobCompleted, "").Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Success - Nothing to resume",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ClaimStaleFanOutJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				storage.EXPECT().ForEachFollowerBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Failure - Claim error",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ClaimStaleFanOutJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMocks(mockStorage, mockCache)
+
+			service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+			// Act
+			count, err := service.ResumeFanOutJobs(context.Background())
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestRunFanOutRecovery(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockCache := mocks.NewMockCacheRepository(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The loop stops once the context is cancelled during the first pass.
+	mockStorage.EXPECT().
+		ClaimStaleFanOutJobs(gomock.Any(), 10*time.Millisecond, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, staleAfter time.Duration, maxAttempts, limit int) ([]domain.FanOutJob, error) {
+			cancel()
+			return nil, nil
+		})
+
+	service := timeline.NewService(mockStorage, mockCache, timeline.Config{FanOutRecoveryInterval: 10 * time.Millisecond}, logging.Discard())
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		service.RunFanOutRecovery(ctx)
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunFanOutRecovery did not stop after the context was cancelled")
+	}
+}
@@ -2,34 +2,147 @@ package timeline
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/singleflight"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/timeline_mocks.go -package=mocks
 
 type StorageRepo interface {
 	SelectFollowersByUserID(ctx context.Context, userID string) ([]string, error)
+	ForEachFollowerBatch(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error
+	CreateFanOutJob(ctx context.Context, tweetID, authorID string) (bool, error)
+	UpdateFanOutJobCheckpoint(ctx context.Context, tweetID, lastFollowerID string, processedCount int) error
+	FinishFanOutJob(ctx context.Context, tweetID, status, lastError string) error
+	ClaimStaleFanOutJobs(ctx context.Context, staleAfter time.Duration, maxAttempts, limit int) ([]domain.FanOutJob, error)
+	SelectFanOutJob(ctx context.Context, tweetID string) (*domain.FanOutJob, error)
 	SelectTweetsByTweetsIDs(ctx context.Context, tweetIDs []string) ([]domain.Tweet, error)
-	SelectLastTweetsByUsersID(ctx context.Context, userIDs []string) ([]domain.Tweet, error)
+	SelectLastTweetsByUsersID(ctx context.Context, userIDs []string, cursor string, limit int) ([]domain.Tweet, error)
+	CountTweetsSince(ctx context.Context, userIDs []string, sinceTweetID string, limit int) (int, error)
+	SelectTweetIDsByUsersID(ctx context.Context, userIDs []string, limit int) ([]string, error)
+	// SelectHiddenAuthorIDs returns the users whose tweets are hidden from the timeline of
+	// userID: the ones it muted and the ones blocking or blocked by it.
+	SelectHiddenAuthorIDs(ctx context.Context, userID string) ([]string, error)
 }
 
 type CacheRepository interface {
-	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
-	LPush(ctx context.Context, key string, values ...interface{}) error
+	LPushTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error)
+	LPushUniqueTrimPipeline(ctx context.Context, keys []string, maxLen int64, expiration time.Duration, value interface{}) (int, []string, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
 	LRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+	LPos(ctx context.Context, key string, value string) (int64, error)
+	LRem(ctx context.Context, key string, count int64, value interface{}) error
+	PublishPipeline(ctx context.Context, channels []string, message interface{}) error
+	RebuildList(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error
+}
+
+// CacheAvailability is implemented by caches that stop calling Redis while it's failing, e.g.
+// behind a circuit breaker. While the cache isn't available timelines aren't rebuilt and
+// fan-out jobs aren't resumed.
+type CacheAvailability interface {
+	Available() bool
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultMaxNewTweetsCount = 99
+	defaultMaxTweetsCached   = 800
+	defaultTimelineTTL       = 72 * time.Hour
+	defaultFanOutBatchSize   = 500
+	defaultFanOutWorkers     = 8
+	defaultFanOutMaxRetries  = 2
+
+	defaultFanOutRecoveryInterval = time.Minute
+	defaultFanOutMaxAttempts      = 5
+)
+
+// ErrFanOutJobNotFound is returned when a tweet has no fan-out job.
+var ErrFanOutJobNotFound = errors.New("fan-out job not found")
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/service/timeline")
+
+// fanOutLogSampling bounds the lines logged per chunk of a fan-out, so a failing Redis
+// doesn't produce one line per page of followers.
+var fanOutLogSampling = logging.SamplerConfig{First: 10, Thereafter: 100, Tick: time.Second}
+
+// Config holds the tunable limits of the timeline service.
+type Config struct {
+	// MaxNewTweetsCount caps the "new tweets since" counter shown on timeline badges.
+	MaxNewTweetsCount int
+	// MaxTweetsCached is the number of tweet IDs kept in a cached timeline list.
+	MaxTweetsCached int
+	// TimelineTTL is the expiration set on a cached timeline list. It's refreshed when the
+	// owner reads the timeline, so lists of dormant users expire and are rebuilt on return.
+	TimelineTTL time.Duration
+	// FanOutBatchSize is the number of followers read per page and updated per Redis pipeline.
+	FanOutBatchSize int
+	// FanOutWorkers bounds how many pipelines a single fan-out runs concurrently.
+	FanOutWorkers int
+	// FanOutMaxRetries is how many times the failed pushes of a pipeline are retried.
+	FanOutMaxRetries int
+	// FanOutRecoveryInterval is how often unfinished fan-out jobs are looked for. A job that
+	// wasn't updated for this long is considered abandoned and is resumed from its checkpoint.
+	FanOutRecoveryInterval time.Duration
+	// FanOutMaxAttempts is how many times a fan-out job is run before giving up on it.
+	FanOutMaxAttempts int
 }
 
 // Service depends on the interfaces, not concrete types.
 type Service struct {
 	Storage StorageRepo
 	Cache   CacheRepository
+	Config  Config
+	Logger  *slog.Logger
+
+	// fanOutLogger samples the repeated lines of a fan-out, of the cache cleanups and of the
+	// reads served while Redis is down.
+	fanOutLogger *slog.Logger
+	// warmUps collapses concurrent cache rebuilds for the same user into one.
+	warmUps *singleflight.Group
+	// fallbackReads collapses concurrent PostgreSQL reads of the same timeline page into one.
+	fallbackReads *singleflight.Group
 }
 
-func NewService(storage StorageRepo, cache CacheRepository) *Service {
+func NewService(storage StorageRepo, cache CacheRepository, cfg Config, logger *slog.Logger) *Service {
+	if cfg.MaxNewTweetsCount <= 0 {
+		cfg.MaxNewTweetsCount = defaultMaxNewTweetsCount
+	}
+	if cfg.MaxTweetsCached <= 0 {
+		cfg.MaxTweetsCached = defaultMaxTweetsCached
+	}
+	if cfg.TimelineTTL <= 0 {
+		cfg.TimelineTTL = defaultTimelineTTL
+	}
+	if cfg.FanOutBatchSize <= 0 {
+		cfg.FanOutBatchSize = defaultFanOutBatchSize
+	}
+	if cfg.FanOutWorkers <= 0 {
+		cfg.FanOutWorkers = defaultFanOutWorkers
+	}
+	if cfg.FanOutMaxRetries <= 0 {
+		cfg.FanOutMaxRetries = defaultFanOutMaxRetries
+	}
+	if cfg.FanOutRecoveryInterval <= 0 {
+		cfg.FanOutRecoveryInterval = defaultFanOutRecoveryInterval
+	}
+	if cfg.FanOutMaxAttempts <= 0 {
+		cfg.FanOutMaxAttempts = defaultFanOutMaxAttempts
+	}
+
 	return &Service{
 		Storage: storage,
 		Cache:   cache,
+		Config:  cfg,
+		Logger:  logger,
+
+		fanOutLogger:  logging.Sampled(logger, fanOutLogSampling),
+		warmUps:       &singleflight.Group{},
+		fallbackReads: &singleflight.Group{},
 	}
 }
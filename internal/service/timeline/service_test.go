@@ -3,6 +3,7 @@ package timeline_test
 import (
 	"testing"
 
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
 	"github.com/stretchr/testify/assert"
@@ -20,7 +21,7 @@ func TestNewService(t *testing.T) {
 	mockCache := mocks.NewMockCacheRepository(ctrl)
 
 	// Act: Call the constructor function that we are testing.
-	service := timeline.NewService(mockStorage, mockCache)
+	service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
 
 	// Assert: Verify the outcome.
 	// 1. Ensure the service object was actually created and is not nil.
@@ -30,4 +31,7 @@ func TestNewService(t *testing.T) {
 	// This confirms that the service holds the dependencies it needs to operate.
 	assert.Equal(t, mockStorage, service.Storage, "Storage should be the provided mock instance")
 	assert.Equal(t, mockCache, service.Cache, "Cache should be the provided mock instance")
+
+	// 3. Ensure unset limits fall back to their defaults.
+	assert.Positive(t, service.Config.MaxNewTweetsCount, "MaxNewTweetsCount should have a default")
 }
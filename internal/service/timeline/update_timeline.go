@@ -2,62 +2,287 @@ package timeline
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"sync"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/circuitbreaker"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	// Defines a consistent key structure for user timelines in Redis.
 	timelineKeyFormat = "timeline:%s"
+
+	// fanOutRetryBackoff is the base wait before retrying the failed pushes of a chunk.
+	// It grows linearly with each attempt.
+	fanOutRetryBackoff = 50 * time.Millisecond
 )
 
-// UpdateTimeline performs the "fan-out" operation. It finds all followers of the tweet's author
+// fanOutResult counts the outcome of pushing a tweet to a set of follower timelines.
+type fanOutResult struct {
+	updated  int
+	uncached int
+	failed   int
+}
+
+func (r *fanOutResult) add(other fanOutResult) {
+	r.updated += other.updated
+	r.uncached += other.uncached
+	r.failed += other.failed
+}
+
+// UpdateTimeline performs the "fan-out" operation. It walks the followers of the tweet's author
 // and pushes the new tweet's ID onto each of their timeline lists in Redis.
-// It's designed to be called asynchronously (e.g., in a goroutine).
+// The progress is tracked by a fan-out job, so a delivery interrupted by a crash or by Redis
+// errors is resumed later from its checkpoint (see ResumeFanOutJobs).
+// It's designed to be called asynchronously (e.g., in a goroutine): the fan-out gets its own
+// trace, linked to the span found in ctx (usually the request that published the tweet).
 func (s Service) UpdateTimeline(ctx context.Context, tweetAuthorID, tweetID string) {
-	// 1. Get all followers from the database.
-	followers, err := s.Storage.SelectFollowersByUserID(ctx, tweetAuthorID)
+	ctx, span := tracer.Start(ctx, "timeline.UpdateTimeline",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(
+			attribute.String("tweet.id", tweetID),
+			attribute.String("tweet.author_id", tweetAuthorID),
+		),
+	)
+	defer span.End()
+
+	created, err := s.Storage.CreateFanOutJob(ctx, tweetID, tweetAuthorID)
 	if err != nil {
-		log.Printf("ERROR: UpdateTimeline could not get followers for user %s: %v", tweetAuthorID, err)
+		// Deliver anyway: without a job the fan-out can't be resumed, but most of the time it won't need to.
+		s.Logger.ErrorContext(ctx, "could not create fan-out job", "tweet_id", tweetID, "error", err)
+	} else if !created {
+		s.Logger.InfoContext(ctx, "tweet already has a fan-out job, skipping", "tweet_id", tweetID)
+		span.SetAttributes(attribute.Bool("fan_out.skipped", true))
 		return
 	}
 
-	if len(followers) == 0 {
-		log.Printf("INFO: User %s has no followers to update.", tweetAuthorID)
-		return
-	}
+	s.runFanOut(ctx, domain.FanOutJob{TweetID: tweetID, AuthorID: tweetAuthorID}, false)
+}
+
+// runFanOut delivers job.TweetID to the followers after the job checkpoint.
+// Followers are read in pages of FanOutBatchSize, each page is written with a single pipeline,
+// and up to FanOutWorkers pages are processed concurrently, so memory stays bounded no matter
+// how many followers the author has. The checkpoint moves forward as pages complete; once a
+// page can't be delivered no more pages are read and the job is marked as failed.
+// When resumed is set the pages right after the checkpoint may already have been delivered,
+// so pushes skip the timelines that already hold the tweet.
+func (s Service) runFanOut(ctx context.Context, job domain.FanOutJob, resumed bool) {
+	tweetID := job.TweetID
+	start := time.Now()
 
-	// TODO add span with followers_count trace, for check performance
+	ctx, span := tracer.Start(ctx, "timeline.runFanOut", trace.WithAttributes(
+		attribute.String("tweet.id", tweetID),
+		attribute.Bool("fan_out.resumed", resumed),
+	))
+	defer span.End()
 
 	// TODO [spike] add log if user has more than 10.000 followers.
 	// avoid next logic if the user has too many followers. In this case, another approach is needed.
 	// [spike] learn to celebrity user problem / pattern and how apply it.
 
-	// 2. For each follower, push the new tweet ID to their timeline list in Redis.
-	var updatedCount int
-	for _, followerID := range followers {
-		// TODO [spike] parallelize each follower-UpdateTimeline with go-routines. Use waitGroup to await finish results.
+	// The live event is the same for every follower, so it's encoded once.
+	event, err := json.Marshal(domain.Event{
+		Type:     domain.EventTweetCreated,
+		TweetID:  tweetID,
+		AuthorID: job.AuthorID,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to encode timeline event", "tweet_id", tweetID, "error", err)
+		event = nil
+	}
 
-		// Construct the unique Redis key for this follower's timeline.
-		timelineKey := fmt.Sprintf(timelineKeyFormat, followerID)
+	// 1. Start the workers that push the new tweet ID to the followers' timeline lists.
+	pages := make(chan fanOutPage)
+	checkpoint := newFanOutCheckpoint(job.LastFollowerID, job.ProcessedCount)
 
-		// TODO [technical debt] firs check if timelineKey exist into Redis. If not exist, create with a TTL.
-		// If exist, continue with LPush
-		// NOTE: // With this implementation, each register on cache is alive for ever.
+	var mu sync.Mutex
+	var result fanOutResult
+	var wg sync.WaitGroup
+	for range s.Config.FanOutWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				if checkpoint.blocked() {
+					// The run will stop before this page anyway; the resumed job delivers it.
+					continue
+				}
+				pageResult := s.fanOutChunk(ctx, page.followerIDs, tweetID, event, resumed)
 
-		// LPUSH adds the new tweet ID to the beginning of the list.
-		if err := s.Cache.LPush(ctx, timelineKey, tweetID); err != nil {
-			// Log the error but continue, so one failure doesn't stop the whole process.
-			log.Printf("ERROR: Failed to push tweet %s to timeline for follower %s: %v", tweetID, followerID, err)
-			continue
+				mu.Lock()
+				result.add(pageResult)
+				mu.Unlock()
+
+				lastFollowerID, processed, advanced := checkpoint.complete(page, pageResult.failed == 0)
+				if !advanced {
+					continue
+				}
+				if err := s.Storage.UpdateFanOutJobCheckpoint(ctx, tweetID, lastFollowerID, processed); err != nil {
+					s.fanOutLogger.ErrorContext(ctx, "failed to save fan-out checkpoint", "tweet_id", tweetID, "error", err)
+				}
+			}
+		}()
+	}
+
+	// 2. Stream the followers from the database, one page per chunk. Sending blocks while every
+	// worker is busy, which keeps the database reads at the pace of the Redis writes.
+	var followersCount, seq int
+	err = s.Storage.ForEachFollowerBatch(ctx, job.AuthorID, job.LastFollowerID, s.Config.FanOutBatchSize, func(followerIDs []string) error {
+		if checkpoint.blocked() {
+			return errFanOutPageFailed
+		}
+
+		followersCount += len(followerIDs)
+		select {
+		case pages <- fanOutPage{seq: seq, followerIDs: followerIDs}:
+			seq++
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(pages)
+	wg.Wait()
+
+	// 3. Record how the run ended.
+	if err == nil && checkpoint.blocked() {
+		err = errFanOutPageFailed
+	}
+	span.SetAttributes(
+		attribute.Int("fan_out.followers_count", followersCount),
+		attribute.Int("fan_out.updated", result.updated),
+		attribute.Int("fan_out.uncached", result.uncached),
+		attribute.Int("fan_out.failed", result.failed),
+	)
+	status, lastError := domain.FanOutJobCompleted, ""
+	if err != nil {
+		status, lastError = domain.FanOutJobFailed, err.Error()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fan-out stopped")
+		s.Logger.ErrorContext(ctx, "fan-out stopped, it will be resumed from its checkpoint", "tweet_id", tweetID, "error", err)
+	}
+	if err := s.Storage.FinishFanOutJob(ctx, tweetID, status, lastError); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save fan-out status", "tweet_id", tweetID, "error", err)
+	}
+
+	metrics.FanOutDuration.Observe(time.Since(start).Seconds())
+	metrics.FanOutFollowers.Observe(float64(followersCount))
+	metrics.FanOutPushes.WithLabelValues(metrics.PushUpdated).Add(float64(result.updated))
+	metrics.FanOutPushes.WithLabelValues(metrics.PushUncached).Add(float64(result.uncached))
+	metrics.FanOutPushes.WithLabelValues(metrics.PushFailed).Add(float64(result.failed))
+
+	if followersCount == 0 && err == nil {
+		s.Logger.InfoContext(ctx, "user has no followers to update", "user_id", job.AuthorID)
+		return
+	}
+
+	s.Logger.InfoContext(ctx, "finished timeline fan-out",
+		"tweet_id", tweetID,
+		"followers", followersCount,
+		"updated", result.updated,
+		"uncached", result.uncached,
+		"failed", result.failed,
+		"duration", time.Since(start),
+	)
+}
+
+// fanOutChunk pushes tweetID to the timelines of followerIDs with one pipeline, retrying the
+// pushes that failed up to FanOutMaxRetries times. When unique is set, timelines already
+// holding the tweet are left as is. Lists that aren't cached are skipped; they're
+// rebuilt from the database on the next read. Every follower whose push didn't fail is notified.
+// While the cache circuit breaker is open nothing is pushed: the whole chunk is reported as
+// failed, so the job stops at its checkpoint and is resumed once Redis is back.
+func (s Service) fanOutChunk(ctx context.Context, followerIDs []string, tweetID string, event []byte, unique bool) fanOutResult {
+	keys := make([]string, len(followerIDs))
+	for i, followerID := range followerIDs {
+		keys[i] = fmt.Sprintf(timelineKeyFormat, followerID)
+	}
+
+	push := s.Cache.LPushTrimPipeline
+	if unique {
+		push = s.Cache.LPushUniqueTrimPipeline
+	}
+
+	var result fanOutResult
+	pending := keys
+	for attempt := 0; len(pending) > 0; attempt++ {
+		cached, failed, err := push(ctx, pending, int64(s.Config.MaxTweetsCached), s.Config.TimelineTTL, tweetID)
+		if errors.Is(err, circuitbreaker.ErrOpen) {
+			s.fanOutLogger.WarnContext(ctx, "timeline cache unavailable, leaving timelines for the resumed fan-out",
+				"tweet_id", tweetID, "timelines", len(pending))
+			break
+		}
+		result.updated += cached
+		result.uncached += len(pending) - len(failed) - cached
+		if err == nil {
+			pending = nil
+			break
+		}
+		metrics.FanOutLPushFailures.Add(float64(len(failed)))
+		if len(failed) == 0 {
+			// Every push landed; only the trim or the TTL of some list failed.
+			s.fanOutLogger.WarnContext(ctx, "pushed tweet but could not trim or expire some timelines", "tweet_id", tweetID, "error", err)
+			pending = nil
+			break
 		}
-		log.Printf("INFO: add timeline fan-out on cache for tweetID: %s followerID: %s tweetAuthorID: %s", tweetID, followerID, tweetAuthorID)
-		updatedCount++
 
-		// TODO [technical debt] evaluate the value of elements for the followerID. If is more than maxTweetsCached, trim for avoid
-		// bigger cache. This method should be execute with async process.
+		pending = failed
+		if attempt == s.Config.FanOutMaxRetries {
+			// The job checkpoint stays before this chunk, so it's retried when the job is resumed.
+			s.fanOutLogger.ErrorContext(ctx, "failed to push tweet to timelines",
+				"tweet_id", tweetID, "timelines", len(pending), "attempts", attempt+1, "error", err)
+			break
+		}
+		s.fanOutLogger.WarnContext(ctx, "retrying push of tweet to timelines", "tweet_id", tweetID, "timelines", len(pending), "error", err)
+
+		select {
+		case <-ctx.Done():
+			s.fanOutLogger.ErrorContext(ctx, "fan-out cancelled with timelines pending",
+				"tweet_id", tweetID, "timelines", len(pending), "error", ctx.Err())
+			result.failed = len(pending)
+			return result
+		case <-time.After(fanOutRetryBackoff * time.Duration(attempt+1)):
+		}
 	}
+	result.failed = len(pending)
 
-	log.Printf("INFO: Finished timeline fan-out. Successfully updated %d of %d follower timelines.", updatedCount, len(followers))
-	// TODO add metric if updated is distinct to len(followers). Then see logs for troubleshooting
+	// Notify live subscribers. The timelines are already updated, so a failure here only delays the clients.
+	if event != nil {
+		s.publishTweetCreated(ctx, followerIDs, pending, tweetID, event)
+	}
+
+	return result
+}
+
+// publishTweetCreated announces a new tweet on the timeline events channel of each follower,
+// except those whose timeline key is in failedKeys.
+func (s Service) publishTweetCreated(ctx context.Context, followerIDs, failedKeys []string, tweetID string, event []byte) {
+	failed := make(map[string]struct{}, len(failedKeys))
+	for _, key := range failedKeys {
+		failed[key] = struct{}{}
+	}
+
+	channels := make([]string, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		if _, ok := failed[fmt.Sprintf(timelineKeyFormat, followerID)]; ok {
+			continue
+		}
+		channels = append(channels, domain.TimelineEventsChannel(followerID))
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	if err := s.Cache.PublishPipeline(ctx, channels, event); err != nil {
+		s.fanOutLogger.ErrorContext(ctx, "failed to publish timeline event", "tweet_id", tweetID, "followers", len(channels), "error", err)
+	}
 }
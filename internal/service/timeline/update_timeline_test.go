@@ -4,11 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/cmd/http/config"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/infraestructure/redis"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline"
 	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/renzonaitor/tweet-api/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 )
 
@@ -19,98 +32,224 @@ func TestUpdateTimeline(t *testing.T) {
 	follower1 := uuid.NewString()
 	follower2 := uuid.NewString()
 	followers := []string{follower1, follower2}
+	timelineKey1 := fmt.Sprintf("timeline:%s", follower1)
+	timelineKey2 := fmt.Sprintf("timeline:%s", follower2)
+	timelineKeys := []string{timelineKey1, timelineKey2}
+	channel1 := fmt.Sprintf("events:timeline:%s", follower1)
+	channel2 := fmt.Sprintf("events:timeline:%s", follower2)
 
 	// Define reusable errors
 	dbError := errors.New("database connection failed")
 	cacheError := errors.New("redis command failed")
 
 	testCases := []struct {
-		name       string
-		authorID   string
-		tweetID    string
-		setupMocks func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+		name           string
+		config         timeline.Config
+		setupMocks     func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+		expectedStatus string
 	}{
 		{
-			name:     "Success - Fan-out to multiple followers",
-			authorID: authorID,
-			tweetID:  tweetID,
+			name: "Success - Fan-out to multiple followers in one pipeline",
 			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
 				// Expect storage to be called to get followers, and it succeeds.
 				storage.EXPECT().
-					SelectFollowersByUserID(gomock.Any(), authorID).
-					Return(followers, nil).
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers)).
 					Times(1)
 
-				// Expect cache to be called for each follower.
-				timelineKey1 := fmt.Sprintf("timeline:%s", follower1)
+				// Expect a single pipeline with every follower timeline.
 				cache.EXPECT().
-					LPush(gomock.Any(), timelineKey1, tweetID).
-					Return(nil).
+					LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+					Return(2, nil, nil).
 					Times(1)
 
-				timelineKey2 := fmt.Sprintf("timeline:%s", follower2)
+				// Expect a live event to be published for each updated timeline.
 				cache.EXPECT().
-					LPush(gomock.Any(), timelineKey2, tweetID).
+					PublishPipeline(gomock.Any(), []string{channel1, channel2}, gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
+			expectedStatus: domain.FanOutJobCompleted,
+		},
+		{
+			name:   "Success - Followers are split in chunks",
+			config: timeline.Config{FanOutBatchSize: 1, FanOutWorkers: 2},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 1, gomock.Any()).
+					DoAndReturn(followerBatches([]string{follower1}, []string{follower2}))
+
+				// Chunks run concurrently, so each one is matched by its own key.
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), []string{timelineKey1}, int64(800), 72*time.Hour, tweetID).
+					Return(1, nil, nil)
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), []string{timelineKey2}, int64(800), 72*time.Hour, tweetID).
+					Return(1, nil, nil)
+
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), []string{channel1}, gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), []string{channel2}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: domain.FanOutJobCompleted,
+		},
+		{
+			name: "Success - Publish error does not stop the fan-out",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers)).
+					Times(1)
+
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+					Return(2, nil, nil)
+
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(cacheError)
+			},
+			expectedStatus: domain.FanOutJobCompleted,
+		},
+		{
+			name: "Success - Followers without a cached timeline are skipped",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers)).
+					Times(1)
+
+				// Only one of the followers has a cached timeline.
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+					Return(1, nil, nil)
+
+				// Live clients are notified either way; the timeline is rebuilt on the next read.
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), []string{channel1, channel2}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: domain.FanOutJobCompleted,
 		},
 		{
-			name:     "Success - User has no followers",
-			authorID: authorID,
-			tweetID:  tweetID,
+			name: "Success - User has no followers",
 			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
-				// Expect storage to be called, returning an empty slice.
+				// Expect storage to be called, yielding no batch.
 				storage.EXPECT().
-					SelectFollowersByUserID(gomock.Any(), authorID).
-					Return([]string{}, nil).
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches()).
 					Times(1)
 
 				// Cache should NOT be called if there are no followers.
-				cache.EXPECT().LPush(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
+			expectedStatus: domain.FanOutJobCompleted,
 		},
 		{
-			name:     "Failure - Storage error when fetching followers",
-			authorID: authorID,
-			tweetID:  tweetID,
+			name: "Failure - Storage error when fetching followers",
 			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
 				// Expect storage to be called, and it returns an error.
 				storage.EXPECT().
-					SelectFollowersByUserID(gomock.Any(), authorID).
-					Return(nil, dbError).
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					Return(dbError).
 					Times(1)
 
 				// Cache should NOT be called if storage fails.
-				cache.EXPECT().LPush(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
+			expectedStatus: domain.FanOutJobFailed,
 		},
 		{
-			name:     "Partial Failure - Cache error for one follower",
-			authorID: authorID,
-			tweetID:  tweetID,
+			name:   "Partial Failure - Storage error after the first page",
+			config: timeline.Config{FanOutBatchSize: 1},
 			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
-				// Expect storage to succeed.
+				// The first page is read, then the database fails.
 				storage.EXPECT().
-					SelectFollowersByUserID(gomock.Any(), authorID).
-					Return(followers, nil).
-					Times(1)
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+						if err := fn([]string{follower1}); err != nil {
+							return err
+						}
+						return dbError
+					})
 
-				// Expect LPush for the first follower to FAIL.
-				timelineKey1 := fmt.Sprintf("timeline:%s", follower1)
+				// The followers already read are still updated.
 				cache.EXPECT().
-					LPush(gomock.Any(), timelineKey1, tweetID).
-					Return(cacheError).
-					Times(1)
+					LPushTrimPipeline(gomock.Any(), []string{timelineKey1}, int64(800), 72*time.Hour, tweetID).
+					Return(1, nil, nil)
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), []string{channel1}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: domain.FanOutJobFailed,
+		},
+		{
+			name: "Partial Failure - Failed pushes are retried",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers))
+
+				// The push for the first follower fails, and only that one is retried.
+				gomock.InOrder(
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+						Return(1, []string{timelineKey1}, cacheError),
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), []string{timelineKey1}, int64(800), 72*time.Hour, tweetID).
+						Return(1, nil, nil),
+				)
 
-				// IMPORTANT: Expect LPush for the second follower to still be called.
-				// This verifies the loop continues on error.
-				timelineKey2 := fmt.Sprintf("timeline:%s", follower2)
 				cache.EXPECT().
-					LPush(gomock.Any(), timelineKey2, tweetID).
-					Return(nil).
-					Times(1)
+					PublishPipeline(gomock.Any(), []string{channel1, channel2}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: domain.FanOutJobCompleted,
+		},
+		{
+			name:   "Partial Failure - Retries exhausted",
+			config: timeline.Config{FanOutMaxRetries: 1},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers))
+
+				gomock.InOrder(
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+						Return(1, []string{timelineKey1}, cacheError),
+					cache.EXPECT().
+						LPushTrimPipeline(gomock.Any(), []string{timelineKey1}, int64(800), 72*time.Hour, tweetID).
+						Return(0, []string{timelineKey1}, cacheError),
+				)
+
+				// Only the follower whose timeline was updated gets a live event.
+				cache.EXPECT().
+					PublishPipeline(gomock.Any(), []string{channel2}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: domain.FanOutJobFailed,
+		},
+		{
+			name:   "Failure - Every push fails",
+			config: timeline.Config{FanOutMaxRetries: 1},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches(followers))
+
+				cache.EXPECT().
+					LPushTrimPipeline(gomock.Any(), timelineKeys, int64(800), 72*time.Hour, tweetID).
+					Return(0, timelineKeys, cacheError).
+					Times(2)
+
+				// Nobody is notified.
+				cache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
+			expectedStatus: domain.FanOutJobFailed,
 		},
 	}
 
@@ -125,12 +264,226 @@ func TestUpdateTimeline(t *testing.T) {
 				tc.setupMocks(mockStorage, mockCache)
 			}
 
-			service := timeline.NewService(mockStorage, mockCache)
+			// Every run is tracked by a fan-out job.
+			mockStorage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(true, nil)
+			mockStorage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockStorage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, tc.expectedStatus, gomock.Any()).Return(nil)
+
+			service := timeline.NewService(mockStorage, mockCache, tc.config, logging.Discard())
 
 			// Act
 			// Since the method is designed to be async and logs errors instead of returning them,
 			// we call it directly. The test's assertions are handled by gomock's expectations.
-			service.UpdateTimeline(context.Background(), tc.authorID, tc.tweetID)
+			service.UpdateTimeline(context.Background(), authorID, tweetID)
+		})
+	}
+}
+
+func TestUpdateTimeline_FanOutJob(t *testing.T) {
+	authorID := uuid.NewString()
+	tweetID := uuid.NewString()
+	follower1 := uuid.NewString()
+	follower2 := uuid.NewString()
+	follower3 := uuid.NewString()
+	cacheError := errors.New("redis command failed")
+
+	testCases := []struct {
+		name       string
+		config     timeline.Config
+		setupMocks func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository)
+	}{
+		{
+			name: "Success - Tweet with a job is not fanned out again",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(false, nil)
+
+				storage.EXPECT().ForEachFollowerBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "Success - Job creation error does not stop the delivery",
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(false, errors.New("database connection failed"))
+
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).
+					DoAndReturn(followerBatches([]string{follower1}))
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), tweetID).Return(1, nil, nil)
+				cache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, follower1, 1).Return(nil)
+				storage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobCompleted, "").Return(nil)
+			},
+		},
+		{
+			name:   "Success - Checkpoint follows the delivered pages",
+			config: timeline.Config{FanOutBatchSize: 2, FanOutWorkers: 1},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(true, nil)
+
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 2, gomock.Any()).
+					DoAndReturn(followerBatches([]string{follower1, follower2}, []string{follower3}))
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), tweetID).Return(1, nil, nil).Times(2)
+				cache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+				gomock.InOrder(
+					storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, follower2, 2).Return(nil),
+					storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, follower3, 3).Return(nil),
+					storage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobCompleted, "").Return(nil),
+				)
+			},
+		},
+		{
+			name:   "Partial Failure - A failed page blocks the checkpoint and stops the run",
+			config: timeline.Config{FanOutBatchSize: 1, FanOutWorkers: 1, FanOutMaxRetries: 1},
+			setupMocks: func(storage *mocks.MockStorageRepo, cache *mocks.MockCacheRepository) {
+				storage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(true, nil)
+
+				storage.EXPECT().
+					ForEachFollowerBatch(gomock.Any(), authorID, "", 1, gomock.Any()).
+					DoAndReturn(followerBatches([]string{follower1}, []string{follower2}, []string{follower3}))
+
+				// The first page is delivered, the second one fails on every attempt.
+				key2 := fmt.Sprintf("timeline:%s", follower2)
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), []string{fmt.Sprintf("timeline:%s", follower1)}, gomock.Any(), gomock.Any(), tweetID).Return(1, nil, nil)
+				cache.EXPECT().LPushTrimPipeline(gomock.Any(), []string{key2}, gomock.Any(), gomock.Any(), tweetID).Return(0, []string{key2}, cacheError).Times(2)
+				cache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				// The third page is never delivered; the job resumes after the first follower.
+				storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, follower1, 1).Return(nil)
+				storage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobFailed, "some follower timelines could not be updated").Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockCache := mocks.NewMockCacheRepository(ctrl)
+			tc.setupMocks(mockStorage, mockCache)
+
+			service := timeline.NewService(mockStorage, mockCache, tc.config, logging.Discard())
+
+			// Act
+			service.UpdateTimeline(context.Background(), authorID, tweetID)
+		})
+	}
+}
+
+// followerBatches makes a ForEachFollowerBatch mock yield each batch in order.
+func followerBatches(batches ...[]string) func(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+	return func(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// BenchmarkUpdateTimeline measures the fan-out throughput against an in-memory Redis.
+// Every follower has a cached timeline, so each push runs the full LPUSH + LTRIM script.
+func TestUpdateTimeline_Tracing(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(exporter, tracing.Config{SampleRatio: 1})
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	authorID := uuid.NewString()
+	tweetID := uuid.NewString()
+	followers := []string{uuid.NewString(), uuid.NewString()}
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockCache := mocks.NewMockCacheRepository(ctrl)
+	mockStorage.EXPECT().CreateFanOutJob(gomock.Any(), tweetID, authorID).Return(true, nil)
+	mockStorage.EXPECT().ForEachFollowerBatch(gomock.Any(), authorID, "", 500, gomock.Any()).DoAndReturn(followerBatches(followers))
+	mockCache.EXPECT().LPushTrimPipeline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), tweetID).Return(2, nil, nil)
+	mockCache.EXPECT().PublishPipeline(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockStorage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), tweetID, gomock.Any(), 2).Return(nil)
+	mockStorage.EXPECT().FinishFanOutJob(gomock.Any(), tweetID, domain.FanOutJobCompleted, "").Return(nil)
+
+	service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+	// The request that published the tweet.
+	requestCtx, requestSpan := provider.Tracer("test").Start(context.Background(), "POST /api/v1/tweet")
+	requestSpan.End()
+
+	// Act
+	service.UpdateTimeline(requestCtx, authorID, tweetID)
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	// Assert
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	require.Contains(t, spans, "timeline.UpdateTimeline")
+	require.Contains(t, spans, "timeline.runFanOut")
+
+	// The fan-out runs in its own trace, linked to the request.
+	update := spans["timeline.UpdateTimeline"]
+	assert.NotEqual(t, requestSpan.SpanContext().TraceID(), update.SpanContext.TraceID())
+	require.Len(t, update.Links, 1)
+	assert.Equal(t, requestSpan.SpanContext().SpanID(), update.Links[0].SpanContext.SpanID())
+
+	run := spans["timeline.runFanOut"]
+	assert.Equal(t, update.SpanContext.SpanID(), run.Parent.SpanID())
+	assert.Contains(t, run.Attributes, attribute.Int("fan_out.followers_count", 2))
+	assert.Contains(t, run.Attributes, attribute.Int("fan_out.updated", 2))
+}
+
+func BenchmarkUpdateTimeline(b *testing.B) {
+	for _, followersCount := range []int{10_000, 100_000} {
+		b.Run(fmt.Sprintf("followers=%d", followersCount), func(b *testing.B) {
+			mockRedis := miniredis.RunT(b)
+			port, err := strconv.Atoi(mockRedis.Port())
+			require.NoError(b, err)
+			cache, err := redis.NewRepository(config.Config{Redis: config.Redis{Host: mockRedis.Host(), Port: port}}, logging.Discard())
+			require.NoError(b, err)
+			b.Cleanup(cache.Close)
+
+			followers := make([]string, followersCount)
+			for i := range followers {
+				followers[i] = uuid.NewString()
+				_, err := mockRedis.Lpush(fmt.Sprintf("timeline:%s", followers[i]), uuid.NewString())
+				require.NoError(b, err)
+			}
+
+			// Serve the followers in pages, like the keyset pagination in PostgreSQL does.
+			ctrl := gomock.NewController(b)
+			storage := mocks.NewMockStorageRepo(ctrl)
+			storage.EXPECT().
+				ForEachFollowerBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, userID, afterFollowerID string, batchSize int, fn func(followerIDs []string) error) error {
+					for start := 0; start < len(followers); start += batchSize {
+						if err := fn(followers[start:min(start+batchSize, len(followers))]); err != nil {
+							return err
+						}
+					}
+					return nil
+				}).
+				AnyTimes()
+
+			storage.EXPECT().CreateFanOutJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+			storage.EXPECT().UpdateFanOutJobCheckpoint(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			storage.EXPECT().FinishFanOutJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			service := timeline.NewService(storage, cache, timeline.Config{}, logging.Discard())
+			authorID := uuid.NewString()
+
+			b.ResetTimer()
+			for range b.N {
+				service.UpdateTimeline(context.Background(), authorID, uuid.NewString())
+			}
+			b.StopTimer()
+
+			b.ReportMetric(float64(followersCount*b.N)/b.Elapsed().Seconds(), "pushes/s")
 		})
 	}
 }
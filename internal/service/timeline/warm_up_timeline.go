@@ -0,0 +1,48 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// warmUpTimeout bounds a background cache rebuild, which no longer has a request to follow.
+const warmUpTimeout = 5 * time.Second
+
+// warmUpTimeline rebuilds the cached timeline list of userID from PostgreSQL, newest first,
+// so the next request is served from cache. Concurrent misses for the same user share a
+// single rebuild instead of each querying PostgreSQL.
+func (s Service) warmUpTimeline(userID string, followees []string) {
+	timelineKey := fmt.Sprintf(timelineKeyFormat, userID)
+
+	_, err, shared := s.warmUps.Do(userID, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+		defer cancel()
+
+		ctx, span := tracer.Start(ctx, "timeline.warmUpTimeline", trace.WithAttributes(attribute.String("user.id", userID)))
+		defer span.End()
+
+		tweetIDs, err := s.Storage.SelectTweetIDsByUsersID(ctx, followees, s.Config.MaxTweetsCached)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting tweet ids: %w", err)
+		}
+
+		// Redis can't hold an empty list; the user stays on the fallback until someone they follow tweets.
+		if len(tweetIDs) == 0 {
+			return nil, nil
+		}
+
+		if err := s.Cache.RebuildList(ctx, timelineKey, tweetIDs, int64(s.Config.MaxTweetsCached), s.Config.TimelineTTL); err != nil {
+			return nil, err
+		}
+
+		s.Logger.InfoContext(ctx, "warmed up timeline cache", "key", timelineKey, "tweets", len(tweetIDs))
+		return nil, nil
+	})
+	if err != nil && !shared {
+		s.Logger.Error("could not warm up timeline cache", "key", timelineKey, "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package timeline_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline"
+	"github.com/renzonaitor/tweet-api/internal/service/timeline/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestWarmUpTimeline_SingleFlight verifies that concurrent cache misses for the same user
+// trigger a single rebuild query against storage.
+func TestWarmUpTimeline_SingleFlight(t *testing.T) {
+	const concurrentMisses = 5
+
+	// Arrange
+	userID := uuid.NewString()
+	followees := []string{uuid.NewString()}
+	tweetIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockCache := mocks.NewMockCacheRepository(ctrl)
+
+	mockCache.EXPECT().
+		LRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]string{}, nil).
+		Times(concurrentMisses)
+	// Concurrent fallback reads of the page may be shared too.
+	mockStorage.EXPECT().
+		SelectFollowersByUserID(gomock.Any(), userID).
+		Return(followees, nil).
+		MinTimes(1).MaxTimes(concurrentMisses)
+	mockStorage.EXPECT().SelectHiddenAuthorIDs(gomock.Any(), userID).Return(nil, nil).MinTimes(1).MaxTimes(concurrentMisses)
+	mockStorage.EXPECT().
+		SelectLastTweetsByUsersID(gomock.Any(), followees, "", 10).
+		Return([]domain.Tweet{}, nil).
+		MinTimes(1).MaxTimes(concurrentMisses)
+
+	// The rebuild blocks until every miss had the chance to join it.
+	release := make(chan struct{})
+	rebuilt := make(chan struct{})
+	mockStorage.EXPECT().
+		SelectTweetIDsByUsersID(gomock.Any(), followees, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userIDs []string, limit int) ([]string, error) {
+			<-release
+			return tweetIDs, nil
+		}).
+		Times(1)
+	mockCache.EXPECT().
+		RebuildList(gomock.Any(), "timeline:"+userID, tweetIDs, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, values []string, maxLen int64, expiration time.Duration) error {
+			close(rebuilt)
+			return nil
+		}).
+		Times(1)
+
+	service := timeline.NewService(mockStorage, mockCache, timeline.Config{}, logging.Discard())
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentMisses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.GetTimeline(context.Background(), userID, 10, "")
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Give the warm-up goroutines time to reach the in-flight rebuild, then let it finish.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	// Assert
+	select {
+	case <-rebuilt:
+	case <-time.After(time.Second):
+		t.Fatal("expected the timeline to be rebuilt")
+	}
+}
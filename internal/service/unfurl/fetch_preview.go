@@ -0,0 +1,177 @@
+package unfurl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Lengths the preview texts are cut to, in runes.
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 500
+)
+
+// FetchPreview fetches rawURL and reads its preview from the Open Graph tags of the page,
+// falling back to its Twitter card tags and then to its <title> and meta description. A
+// link to an image is previewed by the image itself. Other content types, or pages without
+// metadata, give an empty preview.
+func (s Service) FetchPreview(ctx context.Context, rawURL string) (domain.LinkPreview, error) {
+	select {
+	case s.fetches <- struct{}{}:
+		defer func() { <-s.fetches }()
+	case <-ctx.Done():
+		return domain.LinkPreview{}, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.Config.FetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return domain.LinkPreview{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,image/*;q=0.8")
+
+	resp, err := s.Fetcher.Do(req)
+	if err != nil {
+		return domain.LinkPreview{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return domain.LinkPreview{}, fmt.Errorf("fetching %s returned status %d", rawURL, resp.StatusCode)
+	}
+
+	preview := domain.LinkPreview{
+		URL:       rawURL,
+		FetchedAt: s.Clock.Now().UTC().Truncate(time.Millisecond),
+	}
+	// The URL of the page after the redirects, to resolve the relative image URLs.
+	pageURL := req.URL
+	if resp.Request != nil && resp.Request.URL != nil {
+		pageURL = resp.Request.URL
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		preview.ImageURL = pageURL.String()
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		meta, err := readPageMeta(io.LimitReader(resp.Body, s.Config.MaxBodySize))
+		if err != nil {
+			return domain.LinkPreview{}, fmt.Errorf("error reading %s: %w", rawURL, err)
+		}
+		preview.Title = truncate(meta.first("og:title", "twitter:title", "title"), maxTitleLength)
+		preview.Description = truncate(meta.first("og:description", "twitter:description", "description"), maxDescriptionLength)
+		preview.ImageURL = resolveImageURL(pageURL, meta.first("og:image", "og:image:url", "og:image:secure_url", "twitter:image", "twitter:image:src"))
+	}
+
+	return preview, nil
+}
+
+// pageMeta holds the first value of each meta tag of a page, by property or name, and its
+// title under "title".
+type pageMeta map[string]string
+
+// first returns the first non-empty value of keys.
+func (m pageMeta) first(keys ...string) string {
+	for _, key := range keys {
+		if value := m[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// readPageMeta reads the title and meta tags of the HTML page in r. It stops at the body, as
+// the metadata is in the head.
+func readPageMeta(r io.Reader) (pageMeta, error) {
+	meta := make(pageMeta)
+	tokenizer := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return meta, nil
+			}
+			return nil, tokenizer.Err()
+		case html.TextToken:
+			if inTitle && meta["title"] == "" {
+				meta["title"] = string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return meta, nil
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = true
+			case atom.Body:
+				return meta, nil
+			case atom.Meta:
+				if !hasAttr {
+					continue
+				}
+				var key, content string
+				for more := true; more; {
+					var attr, value []byte
+					attr, value, more = tokenizer.TagAttr()
+					switch string(attr) {
+					case "property", "name":
+						if key == "" {
+							key = strings.ToLower(strings.TrimSpace(string(value)))
+						}
+					case "content":
+						content = string(value)
+					}
+				}
+				if _, seen := meta[key]; key != "" && key != "title" && !seen {
+					meta[key] = content
+				}
+			}
+		}
+	}
+}
+
+// truncate collapses the whitespace of s and cuts it to maxRunes, dropping invalid UTF-8.
+func truncate(s string, maxRunes int) string {
+	s = strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:maxRunes-1])) + "…"
+}
+
+// resolveImageURL resolves the image reference of a page against its URL. Only http and
+// https images are kept.
+func resolveImageURL(pageURL *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	image, err := pageURL.Parse(ref)
+	if err != nil || (image.Scheme != "http" && image.Scheme != "https") {
+		return ""
+	}
+	return image.String()
+}
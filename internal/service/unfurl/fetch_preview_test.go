@@ -0,0 +1,187 @@
+package unfurl_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPreview(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 123456789, time.UTC)
+	fetchedAt := now.Truncate(time.Millisecond)
+
+	mux := http.NewServeMux()
+	page := func(path, contentType, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	page("/open-graph", "text/html; charset=utf-8", `<!doctype html><html><head>
+		<title>Page title</title>
+		<meta name="description" content="Meta description">
+		<meta property="og:title" content="OG &amp; title">
+		<meta property="og:description" content="  OG
+			description ">
+		<meta property="og:image" content="/images/card.png">
+		</head><body><meta property="og:title" content="Not in the head"></body></html>`)
+	page("/twitter-card", "text/html", `<html><head>
+		<meta name="twitter:title" content="Card title">
+		<meta name="twitter:description" content="Card description">
+		<meta name="twitter:image" content="https://cdn.example.com/card.jpg">
+		</head></html>`)
+	page("/plain", "text/html", `<html><head><title> Plain
+		page </title><meta name="description" content="Plain description"></head><body>Hi</body></html>`)
+	page("/bad-image", "text/html", `<html><head><title>Bad image</title><meta property="og:image" content="javascript:alert(1)"></head></html>`)
+	page("/long", "text/html", `<html><head><title>`+strings.Repeat("a", 250)+`</title></head></html>`)
+	page("/no-metadata", "text/html", `<html><body><p>Nothing to see</p></body></html>`)
+	page("/photo.png", "image/png", "\x89PNG\r\n\x1a\n")
+	page("/data.json", "application/json", `{"title": "not a page"}`)
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/open-graph", http.StatusFound)
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := []struct {
+		name            string
+		path            string
+		expectedPreview domain.LinkPreview
+		errorContains   string
+	}{
+		{
+			name: "Success - Open Graph tags win over the other metadata",
+			path: "/open-graph",
+			expectedPreview: domain.LinkPreview{
+				Title:       "OG & title",
+				Description: "OG description",
+				ImageURL:    server.URL + "/images/card.png",
+			},
+		},
+		{
+			name: "Success - Twitter card tags",
+			path: "/twitter-card",
+			expectedPreview: domain.LinkPreview{
+				Title:       "Card title",
+				Description: "Card description",
+				ImageURL:    "https://cdn.example.com/card.jpg",
+			},
+		},
+		{
+			name:            "Success - title and meta description",
+			path:            "/plain",
+			expectedPreview: domain.LinkPreview{Title: "Plain page", Description: "Plain description"},
+		},
+		{
+			name:            "Success - images that aren't http are dropped",
+			path:            "/bad-image",
+			expectedPreview: domain.LinkPreview{Title: "Bad image"},
+		},
+		{
+			name:            "Success - long titles are cut",
+			path:            "/long",
+			expectedPreview: domain.LinkPreview{Title: strings.Repeat("a", 199) + "…"},
+		},
+		{
+			name: "Success - redirects resolve the image against the final page",
+			path: "/moved",
+			expectedPreview: domain.LinkPreview{
+				Title:       "OG & title",
+				Description: "OG description",
+				ImageURL:    server.URL + "/images/card.png",
+			},
+		},
+		{
+			name:            "Success - an image previews itself",
+			path:            "/photo.png",
+			expectedPreview: domain.LinkPreview{ImageURL: server.URL + "/photo.png"},
+		},
+		{
+			name:            "Success - a page without metadata has an empty preview",
+			path:            "/no-metadata",
+			expectedPreview: domain.LinkPreview{},
+		},
+		{
+			name:            "Success - other content types have an empty preview",
+			path:            "/data.json",
+			expectedPreview: domain.LinkPreview{},
+		},
+		{
+			name:          "Failure - the page isn't found",
+			path:          "/missing",
+			errorContains: "returned status 404",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service := unfurl.NewService(nil, server.Client(), unfurl.Config{}, logging.Discard())
+			service.Clock = clock.Fixed(now)
+			url := server.URL + tc.path
+
+			// Act
+			preview, err := service.FetchPreview(context.Background(), url)
+
+			// Assert
+			if tc.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			tc.expectedPreview.URL = url
+			tc.expectedPreview.FetchedAt = fetchedAt
+			assert.Equal(t, tc.expectedPreview, preview)
+		})
+	}
+}
+
+func TestFetchPreview_Limits(t *testing.T) {
+	t.Run("Failure - the page takes longer than FetchTimeout", func(t *testing.T) {
+		// Arrange
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+		service := unfurl.NewService(nil, server.Client(), unfurl.Config{FetchTimeout: 50 * time.Millisecond}, logging.Discard())
+
+		// Act
+		_, err := service.FetchPreview(context.Background(), server.URL)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Success - only MaxBodySize bytes are read", func(t *testing.T) {
+		// Arrange
+		head := `<html><head><title>Big page</title>`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(head + strings.Repeat("<!-- padding -->", 1000) + `<meta property="og:title" content="Too far">`))
+		}))
+		defer server.Close()
+		service := unfurl.NewService(nil, server.Client(), unfurl.Config{MaxBodySize: int64(len(head) + 100)}, logging.Discard())
+
+		// Act
+		preview, err := service.FetchPreview(context.Background(), server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Big page", preview.Title)
+	})
+}
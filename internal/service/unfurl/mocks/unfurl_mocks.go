@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/unfurl_mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	domain "github.com/renzonaitor/tweet-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorageRepo is a mock of StorageRepo interface.
+type MockStorageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageRepoMockRecorder is the mock recorder for MockStorageRepo.
+type MockStorageRepoMockRecorder struct {
+	mock *MockStorageRepo
+}
+
+// NewMockStorageRepo creates a new mock instance.
+func NewMockStorageRepo(ctrl *gomock.Controller) *MockStorageRepo {
+	mock := &MockStorageRepo{ctrl: ctrl}
+	mock.recorder = &MockStorageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
+	return m.recorder
+}
+
+// SelectLinkPreviews mocks base method.
+func (m *MockStorageRepo) SelectLinkPreviews(ctx context.Context, urls []string) ([]domain.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLinkPreviews", ctx, urls)
+	ret0, _ := ret[0].([]domain.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLinkPreviews indicates an expected call of SelectLinkPreviews.
+func (mr *MockStorageRepoMockRecorder) SelectLinkPreviews(ctx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLinkPreviews", reflect.TypeOf((*MockStorageRepo)(nil).SelectLinkPreviews), ctx, urls)
+}
+
+// UpsertLinkPreview mocks base method.
+func (m *MockStorageRepo) UpsertLinkPreview(ctx context.Context, preview domain.LinkPreview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLinkPreview", ctx, preview)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertLinkPreview indicates an expected call of UpsertLinkPreview.
+func (mr *MockStorageRepoMockRecorder) UpsertLinkPreview(ctx, preview any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLinkPreview", reflect.TypeOf((*MockStorageRepo)(nil).UpsertLinkPreview), ctx, preview)
+}
+
+// MockHTTPFetcher is a mock of HTTPFetcher interface.
+type MockHTTPFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPFetcherMockRecorder is the mock recorder for MockHTTPFetcher.
+type MockHTTPFetcherMockRecorder struct {
+	mock *MockHTTPFetcher
+}
+
+// NewMockHTTPFetcher creates a new mock instance.
+func NewMockHTTPFetcher(ctrl *gomock.Controller) *MockHTTPFetcher {
+	mock := &MockHTTPFetcher{ctrl: ctrl}
+	mock.recorder = &MockHTTPFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPFetcher) EXPECT() *MockHTTPFetcherMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockHTTPFetcher) Do(req *http.Request) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", req)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockHTTPFetcherMockRecorder) Do(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockHTTPFetcher)(nil).Do), req)
+}
@@ -0,0 +1,88 @@
+package unfurl
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/unfurl_mocks.go -package=mocks
+type StorageRepo interface {
+	// SelectLinkPreviews returns the stored previews of urls, including the empty ones.
+	SelectLinkPreviews(ctx context.Context, urls []string) ([]domain.LinkPreview, error)
+	UpsertLinkPreview(ctx context.Context, preview domain.LinkPreview) error
+}
+
+// HTTPFetcher sends the requests for the linked pages. *http.Client implements it; in
+// production it must refuse to reach the private network, as the URLs come from the users.
+type HTTPFetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultFetchTimeout         = 5 * time.Second
+	defaultMaxBodySize          = 512 << 10
+	defaultRefreshAfter         = 24 * time.Hour
+	defaultMaxConcurrentFetches = 8
+)
+
+// userAgent identifies the unfurler to the linked sites.
+const userAgent = "tweet-api-unfurler/1.0"
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/service/unfurl")
+
+// Config holds the tunable limits of the unfurl service.
+type Config struct {
+	// FetchTimeout bounds the fetch of a single page, redirects included.
+	FetchTimeout time.Duration
+	// MaxBodySize is how many bytes of a page are read looking for its metadata.
+	MaxBodySize int64
+	// RefreshAfter is how long a stored preview is used before the page is fetched again.
+	RefreshAfter time.Duration
+	// MaxConcurrentFetches bounds the pages fetched at the same time across all tweets.
+	MaxConcurrentFetches int
+}
+
+// Service depends on the interfaces, not concrete types.
+type Service struct {
+	Storage StorageRepo
+	Fetcher HTTPFetcher
+	Config  Config
+	Logger  *slog.Logger
+	// Clock stamps the fetched previews. Defaults to the system clock.
+	Clock clock.Clock
+
+	// fetches holds a slot per page being fetched.
+	fetches chan struct{}
+}
+
+func NewService(storage StorageRepo, fetcher HTTPFetcher, cfg Config, logger *slog.Logger) *Service {
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = defaultFetchTimeout
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaultMaxBodySize
+	}
+	if cfg.RefreshAfter <= 0 {
+		cfg.RefreshAfter = defaultRefreshAfter
+	}
+	if cfg.MaxConcurrentFetches <= 0 {
+		cfg.MaxConcurrentFetches = defaultMaxConcurrentFetches
+	}
+
+	return &Service{
+		Storage: storage,
+		Fetcher: fetcher,
+		Config:  cfg,
+		Logger:  logger,
+		Clock:   clock.System,
+
+		fetches: make(chan struct{}, cfg.MaxConcurrentFetches),
+	}
+}
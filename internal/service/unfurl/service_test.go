@@ -0,0 +1,31 @@
+package unfurl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockFetcher := mocks.NewMockHTTPFetcher(ctrl)
+
+	// Act
+	service := unfurl.NewService(mockStorage, mockFetcher, unfurl.Config{}, logging.Discard())
+
+	// Assert
+	assert.NotNil(t, service)
+	assert.Equal(t, mockStorage, service.Storage)
+	assert.Equal(t, mockFetcher, service.Fetcher)
+	assert.Equal(t, 5*time.Second, service.Config.FetchTimeout, "FetchTimeout should default to 5 seconds")
+	assert.Equal(t, int64(512<<10), service.Config.MaxBodySize, "MaxBodySize should default to 512 KiB")
+	assert.Equal(t, 24*time.Hour, service.Config.RefreshAfter, "RefreshAfter should default to a day")
+	assert.Equal(t, 8, service.Config.MaxConcurrentFetches, "MaxConcurrentFetches should default to 8")
+}
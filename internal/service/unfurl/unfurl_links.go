@@ -0,0 +1,75 @@
+package unfurl
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UnfurlLinks fetches and stores the previews of urls that were never fetched or whose
+// preview is older than Config.RefreshAfter. It runs in the background of the requests that
+// publish tweets, so failures are logged: the tweets are just shown without a preview.
+//
+// Pages without metadata are stored with an empty preview, so they aren't fetched again
+// until it is refreshed; pages that couldn't be fetched are retried by the next tweet.
+func (s Service) UnfurlLinks(ctx context.Context, urls []string) {
+	ctx, span := tracer.Start(ctx, "unfurl.UnfurlLinks", trace.WithAttributes(
+		attribute.Int("urls.count", len(urls)),
+	))
+	defer span.End()
+
+	stale, err := s.staleURLs(ctx, urls)
+	if err != nil {
+		span.RecordError(err)
+		s.Logger.ErrorContext(ctx, "failed to read the stored link previews", "error", err)
+		return
+	}
+	span.SetAttributes(attribute.Int("urls.fetched", len(stale)))
+
+	for _, url := range stale {
+		preview, err := s.FetchPreview(ctx, url)
+		if err != nil {
+			s.Logger.WarnContext(ctx, "failed to unfurl link", "url", url, "error", err)
+			continue
+		}
+		if err = s.Storage.UpsertLinkPreview(ctx, preview); err != nil {
+			span.RecordError(err)
+			s.Logger.ErrorContext(ctx, "failed to store link preview", "url", url, "error", err)
+		}
+	}
+}
+
+// staleURLs returns urls without duplicates, skipping those with a fresh preview.
+func (s Service) staleURLs(ctx context.Context, urls []string) ([]string, error) {
+	unique := make([]string, 0, len(urls))
+	seen := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		if !seen[url] {
+			seen[url] = true
+			unique = append(unique, url)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	stored, err := s.Storage.SelectLinkPreviews(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	freshSince := s.Clock.Now().Add(-s.Config.RefreshAfter)
+	fetchedAt := make(map[string]time.Time, len(stored))
+	for _, preview := range stored {
+		fetchedAt[preview.URL] = preview.FetchedAt
+	}
+
+	stale := make([]string, 0, len(unique))
+	for _, url := range unique {
+		if at, ok := fetchedAt[url]; !ok || at.Before(freshSince) {
+			stale = append(stale, url)
+		}
+	}
+	return stale, nil
+}
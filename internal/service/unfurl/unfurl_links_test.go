@@ -0,0 +1,118 @@
+package unfurl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl"
+	"github.com/renzonaitor/tweet-api/internal/service/unfurl/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnfurlLinks(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	refreshAfter := 24 * time.Hour
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Page ` + r.URL.Path + `</title></head></html>`))
+	}))
+	defer server.Close()
+
+	newURL := server.URL + "/new"
+	freshURL := server.URL + "/fresh"
+	staleURL := server.URL + "/stale"
+	downURL := server.URL + "/down"
+	previewOf := func(url, title string) domain.LinkPreview {
+		return domain.LinkPreview{URL: url, Title: title, FetchedAt: now}
+	}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name            string
+		urls            []string
+		setupMocks      func(storage *mocks.MockStorageRepo)
+		expectedFetches int32
+	}{
+		{
+			name: "Success - fetches and stores the new and stale previews, once per URL",
+			urls: []string{newURL, freshURL, newURL, staleURL},
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectLinkPreviews(gomock.Any(), []string{newURL, freshURL, staleURL}).Return([]domain.LinkPreview{
+					{URL: freshURL, FetchedAt: now.Add(-refreshAfter + time.Minute)},
+					{URL: staleURL, Title: "Old title", FetchedAt: now.Add(-refreshAfter - time.Minute)},
+				}, nil)
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), previewOf(newURL, "Page /new")).Return(nil)
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), previewOf(staleURL, "Page /stale")).Return(nil)
+			},
+			expectedFetches: 2,
+		},
+		{
+			name: "Success - pages that can't be fetched aren't stored",
+			urls: []string{downURL, newURL},
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectLinkPreviews(gomock.Any(), []string{downURL, newURL}).Return([]domain.LinkPreview{}, nil)
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), previewOf(newURL, "Page /new")).Return(nil)
+			},
+			expectedFetches: 2,
+		},
+		{
+			name: "Failure - a preview that can't be stored doesn't stop the others",
+			urls: []string{newURL, staleURL},
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectLinkPreviews(gomock.Any(), []string{newURL, staleURL}).Return([]domain.LinkPreview{}, nil)
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), previewOf(newURL, "Page /new")).Return(dbError)
+				storage.EXPECT().UpsertLinkPreview(gomock.Any(), previewOf(staleURL, "Page /stale")).Return(nil)
+			},
+			expectedFetches: 2,
+		},
+		{
+			name: "Failure - nothing is fetched when the stored previews can't be read",
+			urls: []string{newURL},
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectLinkPreviews(gomock.Any(), []string{newURL}).Return(nil, dbError)
+			},
+			expectedFetches: 0,
+		},
+		{
+			name:            "Success - no URLs reads nothing",
+			urls:            nil,
+			setupMocks:      func(storage *mocks.MockStorageRepo) {},
+			expectedFetches: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			fetches.Store(0)
+
+			service := unfurl.NewService(mockStorage, server.Client(), unfurl.Config{RefreshAfter: refreshAfter}, logging.Discard())
+			service.Clock = clock.Fixed(now)
+
+			// Act
+			service.UnfurlLinks(context.Background(), tc.urls)
+
+			// Assert
+			assert.Equal(t, tc.expectedFetches, fetches.Load())
+		})
+	}
+}
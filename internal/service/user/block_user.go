@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BlockUser makes BlockerID block BlockedID. The follows between both users are removed, in
+// either direction, and neither can follow the other until the block is lifted. Tweets of one
+// already delivered to the timeline of the other are hidden when it's read.
+func (s Service) BlockUser(ctx context.Context, block domain.BlockUser) error {
+	ctx, span := tracer.Start(ctx, "user.BlockUser", trace.WithAttributes(
+		attribute.String("block.blocker_id", block.BlockerID),
+		attribute.String("block.blocked_id", block.BlockedID),
+	))
+	defer span.End()
+
+	return s.Storage.CreateBlock(ctx, block)
+}
+
+// UnblockUser lifts the block of BlockerID on BlockedID. The follows removed by the block
+// aren't restored.
+func (s Service) UnblockUser(ctx context.Context, block domain.BlockUser) error {
+	ctx, span := tracer.Start(ctx, "user.UnblockUser", trace.WithAttributes(
+		attribute.String("block.blocker_id", block.BlockerID),
+		attribute.String("block.blocked_id", block.BlockedID),
+	))
+	defer span.End()
+
+	return s.Storage.DeleteBlock(ctx, block)
+}
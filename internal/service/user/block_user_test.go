@@ -0,0 +1,87 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockUser(t *testing.T) {
+	block := domain.BlockUser{
+		BlockerID: uuid.NewString(),
+		BlockedID: uuid.NewString(),
+	}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name        string
+		unblock     bool
+		setupMock   func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - blocks the user",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateBlock(gomock.Any(), block).Return(nil)
+			},
+		},
+		{
+			name: "Failure - error blocking the user",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateBlock(gomock.Any(), block).Return(dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name:    "Success - unblocks the user",
+			unblock: true,
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().DeleteBlock(gomock.Any(), block).Return(nil)
+			},
+		},
+		{
+			name:    "Failure - error unblocking the user",
+			unblock: true,
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().DeleteBlock(gomock.Any(), block).Return(dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMock(mockStorage)
+
+			service := user.NewService(mockStorage, nil, nil, nil, nil, user.Config{}, logging.Discard())
+
+			// Act
+			var err error
+			if tc.unblock {
+				err = service.UnblockUser(context.Background(), block)
+			} else {
+				err = service.BlockUser(context.Background(), block)
+			}
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
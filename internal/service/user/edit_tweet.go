@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EditTweet replaces the text of tweetID, written by userID, within Config.EditWindow of its
+// creation. The previous text is kept in the tweet history. Timelines only cache tweet IDs
+// and hydrate them from PostgreSQL, so every timeline shows the new text on its next read,
+// and the clients following the tweet thread are told of the edit right away.
+//
+// Only published tweets can be edited: held and removed tweets aren't found, so an edit can't
+// bring back text a moderator hasn't approved. The new text is moderated too. As the tweet may
+// already be in the timelines, an edit the moderation would hold or reject is refused with
+// ErrTweetRejected; shadow limits only apply to new tweets.
+func (s Service) EditTweet(ctx context.Context, tweetID, userID, text string) (domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "user.EditTweet", trace.WithAttributes(
+		attribute.String("tweet.id", tweetID),
+		attribute.String("user.id", userID),
+	))
+	defer span.End()
+
+	tweet, err := s.Storage.SelectTweetByID(ctx, tweetID)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+	if tweet == nil || tweet.Moderation == domain.TweetHeld || tweet.Moderation == domain.TweetRemoved {
+		return domain.Tweet{}, ErrTweetNotFound
+	}
+	if tweet.UserID != userID {
+		return domain.Tweet{}, ErrNotTweetAuthor
+	}
+
+	now := s.Clock.Now().UTC().Truncate(time.Millisecond)
+	editableSince := now.Add(-s.Config.EditWindow)
+	if tweet.CreatedAt.Before(editableSince) {
+		return domain.Tweet{}, ErrEditWindowExpired
+	}
+	if tweet.Text == text {
+		// Nothing changes, so no revision is stored.
+		return *tweet, nil
+	}
+
+	urls := tweettext.ExtractURLs(text)
+	verdict := s.Moderator.Moderate(ctx, domain.Tweet{ID: tweetID, UserID: userID, Text: text, URLs: urls})
+	if verdict.Action.Severity() >= domain.ModerationHold.Severity() {
+		return domain.Tweet{}, ErrTweetRejected
+	}
+
+	// The author and the window are checked again by the update, as the tweet may have
+	// changed since it was read.
+	edited, err := s.Storage.UpdateTweetText(ctx, tweetID, userID, text, urls, now, editableSince)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+	if edited == nil {
+		return domain.Tweet{}, ErrEditWindowExpired
+	}
+	span.SetAttributes(attribute.Bool("tweet.edited", true))
+	// Edits only change the text, the attachments stay.
+	edited.Media = tweet.Media
+	s.unfurlLinks(ctx, edited.URLs)
+	s.publishEvent(ctx, domain.ThreadEventsChannel(tweetID), domain.Event{
+		Type:     domain.EventTweetEdited,
+		TweetID:  tweetID,
+		AuthorID: userID,
+	})
+
+	return *edited, nil
+}
+
+// GetTweetHistory returns tweetID and its previous texts, newest first. Held and removed
+// tweets aren't found.
+func (s Service) GetTweetHistory(ctx context.Context, tweetID string) (domain.TweetHistory, error) {
+	ctx, span := tracer.Start(ctx, "user.GetTweetHistory", trace.WithAttributes(
+		attribute.String("tweet.id", tweetID),
+	))
+	defer span.End()
+
+	tweet, err := s.Storage.SelectTweetByID(ctx, tweetID)
+	if err != nil {
+		return domain.TweetHistory{}, err
+	}
+	if tweet == nil || tweet.Moderation == domain.TweetHeld || tweet.Moderation == domain.TweetRemoved {
+		return domain.TweetHistory{}, ErrTweetNotFound
+	}
+
+	revisions, err := s.Storage.SelectTweetRevisions(ctx, tweetID)
+	if err != nil {
+		return domain.TweetHistory{}, err
+	}
+
+	return domain.TweetHistory{Tweet: *tweet, Revisions: revisions}, nil
+}
@@ -0,0 +1,339 @@
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/clock"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEditTweet(t *testing.T) {
+	authorID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := createdAt.Add(10 * time.Minute)
+	editWindow := 30 * time.Minute
+
+	tweet := domain.Tweet{ID: uuid.NewString(), UserID: authorID, Text: "helo world", CreatedAt: createdAt}
+	edited := tweet
+	edited.Text = "hello world"
+	edited.EditedAt = &now
+	oldTweet := tweet
+	oldTweet.CreatedAt = now.Add(-editWindow - time.Millisecond)
+	heldTweet := tweet
+	heldTweet.Moderation = domain.TweetHeld
+	removedTweet := tweet
+	removedTweet.Moderation = domain.TweetRemoved
+	urls := []domain.URLEntity{{URL: "https://go.dev", Start: 12, End: 26}}
+	editedWithURL := edited
+	editedWithURL.Text = "hello world https://go.dev"
+	editedWithURL.URLs = urls
+
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name       string
+		userID     string
+		text       string
+		setupMocks func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup)
+		// verdict is the moderation of the new text, allowed when nil.
+		verdict *domain.ModerationVerdict
+		// publishesEdit tells whether the edit is announced on the tweet thread, which fails
+		// with publishErr.
+		publishesEdit bool
+		publishErr    error
+		expectedTweet domain.Tweet
+		expectedErr   error
+	}{
+		{
+			name:   "Success - edits the tweet",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world", nil, now, now.Add(-editWindow)).
+					Return(&edited, nil)
+			},
+			publishesEdit: true,
+			expectedTweet: edited,
+		},
+		{
+			name:   "Success - failing to publish the edit doesn't fail it",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world", nil, now, now.Add(-editWindow)).
+					Return(&edited, nil)
+			},
+			publishesEdit: true,
+			publishErr:    errors.New("redis connection lost"),
+			expectedTweet: edited,
+		},
+		{
+			name:   "Success - edits the tweet and unfurls its links",
+			userID: authorID,
+			text:   "hello world https://go.dev",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world https://go.dev", urls, now, now.Add(-editWindow)).
+					Return(&editedWithURL, nil)
+				wg.Add(1)
+				links.EXPECT().
+					UnfurlLinks(gomock.Any(), []string{"https://go.dev"}).
+					Do(func(ctx context.Context, urls []string) { wg.Done() })
+			},
+			publishesEdit: true,
+			expectedTweet: editedWithURL,
+		},
+		{
+			name:   "Success - same text stores no revision",
+			userID: authorID,
+			text:   "helo world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+			},
+			expectedTweet: tweet,
+		},
+		{
+			name:    "Success - shadow limits don't apply to edits",
+			userID:  authorID,
+			text:    "hello world",
+			verdict: &domain.ModerationVerdict{Action: domain.ModerationShadowLimit, Rule: "duplicate_burst", Reason: "repeats the text"},
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world", nil, now, now.Add(-editWindow)).
+					Return(&edited, nil)
+			},
+			publishesEdit: true,
+			expectedTweet: edited,
+		},
+		{
+			name:    "Failure - text the moderation would hold",
+			userID:  authorID,
+			text:    "hello world",
+			verdict: &domain.ModerationVerdict{Action: domain.ModerationHold, Rule: "banned_words", Reason: `contains the banned word "world"`},
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+			},
+			expectedErr: user.ErrTweetRejected,
+		},
+		{
+			name:    "Failure - text the moderation rejects",
+			userID:  authorID,
+			text:    "hello world https://go.dev",
+			verdict: &domain.ModerationVerdict{Action: domain.ModerationReject, Rule: "link_blocklist", Reason: `links to the blocked domain "go.dev"`},
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+			},
+			expectedErr: user.ErrTweetRejected,
+		},
+		{
+			name:   "Failure - tweet not found",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(nil, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name:   "Failure - held tweet isn't found",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&heldTweet, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name:   "Failure - removed tweet isn't found",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&removedTweet, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name:   "Failure - not the author",
+			userID: uuid.NewString(),
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+			},
+			expectedErr: user.ErrNotTweetAuthor,
+		},
+		{
+			name:   "Failure - edit window expired",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&oldTweet, nil)
+			},
+			expectedErr: user.ErrEditWindowExpired,
+		},
+		{
+			name:   "Failure - edit window expired while editing",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world", nil, now, now.Add(-editWindow)).
+					Return(nil, nil)
+			},
+			expectedErr: user.ErrEditWindowExpired,
+		},
+		{
+			name:   "Failure - error updating the tweet",
+			userID: authorID,
+			text:   "hello world",
+			setupMocks: func(storage *mocks.MockStorageRepo, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().
+					UpdateTweetText(gomock.Any(), tweet.ID, authorID, "hello world", nil, now, now.Add(-editWindow)).
+					Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockLinks := mocks.NewMockLinkUnfurler(ctrl)
+			mockModerator := mocks.NewMockModerator(ctrl)
+			mockEvents := mocks.NewMockEventPublisher(ctrl)
+			wg := &sync.WaitGroup{}
+			tc.setupMocks(mockStorage, mockLinks, wg)
+			if tc.publishesEdit {
+				event, err := json.Marshal(domain.Event{Type: domain.EventTweetEdited, TweetID: tweet.ID, AuthorID: authorID})
+				require.NoError(t, err)
+				mockEvents.EXPECT().
+					PublishPipeline(gomock.Any(), []string{domain.ThreadEventsChannel(tweet.ID)}, event).
+					Return(tc.publishErr)
+			}
+
+			verdict := domain.ModerationVerdict{Action: domain.ModerationAllow}
+			if tc.verdict != nil {
+				verdict = *tc.verdict
+			}
+			mockModerator.EXPECT().
+				Moderate(gomock.Any(), domain.Tweet{ID: tweet.ID, UserID: tc.userID, Text: tc.text, URLs: tweettext.ExtractURLs(tc.text)}).
+				Return(verdict).
+				MaxTimes(1)
+
+			service := user.NewService(mockStorage, nil, mockLinks, mockModerator, mockEvents, user.Config{EditWindow: editWindow}, logging.Discard())
+			service.Clock = clock.Fixed(now)
+
+			// Act
+			result, err := service.EditTweet(context.Background(), tweet.ID, tc.userID, tc.text)
+
+			// Assert
+			wg.Wait()
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTweet, result)
+		})
+	}
+}
+
+func TestGetTweetHistory(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(time.Minute)
+	tweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "hello world", CreatedAt: createdAt, EditedAt: &editedAt}
+	revisions := []domain.TweetRevision{{Text: "helo world", CreatedAt: createdAt, ReplacedAt: editedAt}}
+	heldTweet := tweet
+	heldTweet.Moderation = domain.TweetHeld
+	removedTweet := tweet
+	removedTweet.Moderation = domain.TweetRemoved
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(storage *mocks.MockStorageRepo)
+		expectedHistory domain.TweetHistory
+		expectedErr     error
+	}{
+		{
+			name: "Success - tweet with its revisions",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().SelectTweetRevisions(gomock.Any(), tweet.ID).Return(revisions, nil)
+			},
+			expectedHistory: domain.TweetHistory{Tweet: tweet, Revisions: revisions},
+		},
+		{
+			name: "Failure - tweet not found",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(nil, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name: "Failure - held tweet isn't found",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&heldTweet, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name: "Failure - removed tweet isn't found",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&removedTweet, nil)
+			},
+			expectedErr: user.ErrTweetNotFound,
+		},
+		{
+			name: "Failure - error reading the revisions",
+			setupMocks: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().SelectTweetByID(gomock.Any(), tweet.ID).Return(&tweet, nil)
+				storage.EXPECT().SelectTweetRevisions(gomock.Any(), tweet.ID).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMocks(mockStorage)
+			service := user.NewService(mockStorage, nil, nil, nil, nil, user.Config{}, logging.Discard())
+
+			// Act
+			history, err := service.GetTweetHistory(context.Background(), tweet.ID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHistory, history)
+		})
+	}
+}
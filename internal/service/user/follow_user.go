@@ -2,16 +2,43 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// FollowUser makes FollowID follow FollowedID, unless either user blocks the other. The
+// followed user is notified on their notifications topic.
 func (s Service) FollowUser(ctx context.Context, followUser domain.FollowUser) error {
+	ctx, span := tracer.Start(ctx, "user.FollowUser", trace.WithAttributes(
+		attribute.String("follow.follow_id", followUser.FollowID),
+		attribute.String("follow.followed_id", followUser.FollowedID),
+	))
+	defer span.End()
+
 	// TODO [technical debt] validate if already exist
 	// relation := s.Storage.GetRelation(ctx, followUser) TODO implements this method on repository
 	// validate if relation is equal to followUser entity TODO implements this logic on receiver function
 
 	// TODO another approach if capture duplicate_key error and return elegant message to user
 
-	return s.Storage.CreateRelation(ctx, followUser)
+	blocked, err := s.Storage.ExistsBlockBetween(ctx, followUser.FollowID, followUser.FollowedID)
+	if err != nil {
+		return fmt.Errorf("error checking blocks: %w", err)
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+
+	if err := s.Storage.CreateRelation(ctx, followUser); err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, domain.NotificationEventsChannel(followUser.FollowedID), domain.Event{
+		Type:       domain.EventNewFollower,
+		FollowerID: followUser.FollowID,
+	})
+	return nil
 }
@@ -2,11 +2,13 @@ package user_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/renzonaitor/tweet-api/internal/service/user"
 	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
 	"github.com/stretchr/testify/assert"
@@ -20,36 +22,84 @@ func TestFollowUser(t *testing.T) {
 		FollowedID: uuid.NewString(),
 	}
 
+	newFollowerEvent, err := json.Marshal(domain.Event{Type: domain.EventNewFollower, FollowerID: followInput.FollowID})
+	require.NoError(t, err)
+
 	// Define a reusable database error
 	dbError := errors.New("database constraint violation: user already follows this user")
 
 	testCases := []struct {
 		name        string
 		input       domain.FollowUser
-		setupMock   func(storage *mocks.MockStorageRepo)
+		setupMock   func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher)
 		expectedErr error
 	}{
 		{
 			name:  "Success - Create Follow Relation",
 			input: followInput,
-			setupMock: func(storage *mocks.MockStorageRepo) {
+			setupMock: func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher) {
+				storage.EXPECT().
+					ExistsBlockBetween(gomock.Any(), followInput.FollowID, followInput.FollowedID).
+					Return(false, nil)
 				storage.EXPECT().
 					CreateRelation(gomock.Any(), followInput).
 					Return(nil).
 					Times(1)
+				events.EXPECT().
+					PublishPipeline(gomock.Any(), []string{domain.NotificationEventsChannel(followInput.FollowedID)}, newFollowerEvent).
+					Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "Success - failing to notify the followed user doesn't fail the follow",
+			input: followInput,
+			setupMock: func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher) {
+				storage.EXPECT().
+					ExistsBlockBetween(gomock.Any(), followInput.FollowID, followInput.FollowedID).
+					Return(false, nil)
+				storage.EXPECT().
+					CreateRelation(gomock.Any(), followInput).
+					Return(nil)
+				events.EXPECT().
+					PublishPipeline(gomock.Any(), []string{domain.NotificationEventsChannel(followInput.FollowedID)}, newFollowerEvent).
+					Return(errors.New("redis connection lost"))
 			},
 			expectedErr: nil,
 		},
 		{
 			name:  "Failure - Error from storage layer",
 			input: followInput,
-			setupMock: func(storage *mocks.MockStorageRepo) {
+			setupMock: func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher) {
+				storage.EXPECT().
+					ExistsBlockBetween(gomock.Any(), followInput.FollowID, followInput.FollowedID).
+					Return(false, nil)
 				storage.EXPECT().
 					CreateRelation(gomock.Any(), followInput).
 					Return(dbError)
 			},
 			expectedErr: dbError,
 		},
+		{
+			name:  "Failure - a block exists between the users",
+			input: followInput,
+			setupMock: func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher) {
+				storage.EXPECT().
+					ExistsBlockBetween(gomock.Any(), followInput.FollowID, followInput.FollowedID).
+					Return(true, nil)
+			},
+			expectedErr: user.ErrUserBlocked,
+		},
+		{
+			name:  "Failure - Error checking blocks",
+			input: followInput,
+			setupMock: func(storage *mocks.MockStorageRepo, events *mocks.MockEventPublisher) {
+				storage.EXPECT().
+					ExistsBlockBetween(gomock.Any(), followInput.FollowID, followInput.FollowedID).
+					Return(false, dbError)
+			},
+			expectedErr: dbError,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -57,12 +107,13 @@ func TestFollowUser(t *testing.T) {
 			// Arrange
 			ctrl := gomock.NewController(t)
 			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			mockEvents := mocks.NewMockEventPublisher(ctrl)
 
 			if tc.setupMock != nil {
-				tc.setupMock(mockStorage)
+				tc.setupMock(mockStorage, mockEvents)
 			}
 
-			service := user.NewService(mockStorage, nil)
+			service := user.NewService(mockStorage, nil, nil, nil, mockEvents, user.Config{}, logging.Discard())
 
 			// Act
 			err := service.FollowUser(context.Background(), tc.input)
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/renzonaitor/tweet-api/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,34 @@ func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
 	return m.recorder
 }
 
+// CreateBlock mocks base method.
+func (m *MockStorageRepo) CreateBlock(ctx context.Context, block domain.BlockUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlock", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBlock indicates an expected call of CreateBlock.
+func (mr *MockStorageRepoMockRecorder) CreateBlock(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlock", reflect.TypeOf((*MockStorageRepo)(nil).CreateBlock), ctx, block)
+}
+
+// CreateMute mocks base method.
+func (m *MockStorageRepo) CreateMute(ctx context.Context, mute domain.MuteUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMute", ctx, mute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMute indicates an expected call of CreateMute.
+func (mr *MockStorageRepoMockRecorder) CreateMute(ctx, mute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMute", reflect.TypeOf((*MockStorageRepo)(nil).CreateMute), ctx, mute)
+}
+
 // CreateRelation mocks base method.
 func (m *MockStorageRepo) CreateRelation(ctx context.Context, follow domain.FollowUser) error {
 	m.ctrl.T.Helper()
@@ -56,12 +85,13 @@ func (mr *MockStorageRepoMockRecorder) CreateRelation(ctx, follow any) *gomock.C
 }
 
 // CreateTweet mocks base method.
-func (m *MockStorageRepo) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+func (m *MockStorageRepo) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTweet", ctx, tweet)
 	ret0, _ := ret[0].(domain.Tweet)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // CreateTweet indicates an expected call of CreateTweet.
@@ -70,6 +100,64 @@ func (mr *MockStorageRepoMockRecorder) CreateTweet(ctx, tweet any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockStorageRepo)(nil).CreateTweet), ctx, tweet)
 }
 
+// DeleteBlock mocks base method.
+func (m *MockStorageRepo) DeleteBlock(ctx context.Context, block domain.BlockUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockStorageRepoMockRecorder) DeleteBlock(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStorageRepo)(nil).DeleteBlock), ctx, block)
+}
+
+// DeleteMute mocks base method.
+func (m *MockStorageRepo) DeleteMute(ctx context.Context, mute domain.MuteUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMute", ctx, mute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMute indicates an expected call of DeleteMute.
+func (mr *MockStorageRepoMockRecorder) DeleteMute(ctx, mute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMute", reflect.TypeOf((*MockStorageRepo)(nil).DeleteMute), ctx, mute)
+}
+
+// ExistsBlockBetween mocks base method.
+func (m *MockStorageRepo) ExistsBlockBetween(ctx context.Context, userID, otherUserID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsBlockBetween", ctx, userID, otherUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsBlockBetween indicates an expected call of ExistsBlockBetween.
+func (mr *MockStorageRepoMockRecorder) ExistsBlockBetween(ctx, userID, otherUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsBlockBetween", reflect.TypeOf((*MockStorageRepo)(nil).ExistsBlockBetween), ctx, userID, otherUserID)
+}
+
+// SelectMediaByIDs mocks base method.
+func (m *MockStorageRepo) SelectMediaByIDs(ctx context.Context, mediaIDs []string) ([]domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectMediaByIDs", ctx, mediaIDs)
+	ret0, _ := ret[0].([]domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectMediaByIDs indicates an expected call of SelectMediaByIDs.
+func (mr *MockStorageRepoMockRecorder) SelectMediaByIDs(ctx, mediaIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectMediaByIDs", reflect.TypeOf((*MockStorageRepo)(nil).SelectMediaByIDs), ctx, mediaIDs)
+}
+
 // SelectTweetByID mocks base method.
 func (m *MockStorageRepo) SelectTweetByID(ctx context.Context, tweetID string) (*domain.Tweet, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +173,36 @@ func (mr *MockStorageRepoMockRecorder) SelectTweetByID(ctx, tweetID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTweetByID", reflect.TypeOf((*MockStorageRepo)(nil).SelectTweetByID), ctx, tweetID)
 }
 
+// SelectTweetRevisions mocks base method.
+func (m *MockStorageRepo) SelectTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTweetRevisions", ctx, tweetID)
+	ret0, _ := ret[0].([]domain.TweetRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTweetRevisions indicates an expected call of SelectTweetRevisions.
+func (mr *MockStorageRepoMockRecorder) SelectTweetRevisions(ctx, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTweetRevisions", reflect.TypeOf((*MockStorageRepo)(nil).SelectTweetRevisions), ctx, tweetID)
+}
+
+// UpdateTweetText mocks base method.
+func (m *MockStorageRepo) UpdateTweetText(ctx context.Context, tweetID, authorID, text string, urls []domain.URLEntity, editedAt, editableSince time.Time) (*domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTweetText", ctx, tweetID, authorID, text, urls, editedAt, editableSince)
+	ret0, _ := ret[0].(*domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTweetText indicates an expected call of UpdateTweetText.
+func (mr *MockStorageRepoMockRecorder) UpdateTweetText(ctx, tweetID, authorID, text, urls, editedAt, editableSince any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTweetText", reflect.TypeOf((*MockStorageRepo)(nil).UpdateTweetText), ctx, tweetID, authorID, text, urls, editedAt, editableSince)
+}
+
 // MockTimelineUpdater is a mock of TimelineUpdater interface.
 type MockTimelineUpdater struct {
 	ctrl     *gomock.Controller
@@ -120,3 +238,115 @@ func (mr *MockTimelineUpdaterMockRecorder) UpdateTimeline(ctx, tweetAuthorID, tw
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeline", reflect.TypeOf((*MockTimelineUpdater)(nil).UpdateTimeline), ctx, tweetAuthorID, tweetID)
 }
+
+// MockLinkUnfurler is a mock of LinkUnfurler interface.
+type MockLinkUnfurler struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkUnfurlerMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkUnfurlerMockRecorder is the mock recorder for MockLinkUnfurler.
+type MockLinkUnfurlerMockRecorder struct {
+	mock *MockLinkUnfurler
+}
+
+// NewMockLinkUnfurler creates a new mock instance.
+func NewMockLinkUnfurler(ctrl *gomock.Controller) *MockLinkUnfurler {
+	mock := &MockLinkUnfurler{ctrl: ctrl}
+	mock.recorder = &MockLinkUnfurlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkUnfurler) EXPECT() *MockLinkUnfurlerMockRecorder {
+	return m.recorder
+}
+
+// UnfurlLinks mocks base method.
+func (m *MockLinkUnfurler) UnfurlLinks(ctx context.Context, urls []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnfurlLinks", ctx, urls)
+}
+
+// UnfurlLinks indicates an expected call of UnfurlLinks.
+func (mr *MockLinkUnfurlerMockRecorder) UnfurlLinks(ctx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfurlLinks", reflect.TypeOf((*MockLinkUnfurler)(nil).UnfurlLinks), ctx, urls)
+}
+
+// MockModerator is a mock of Moderator interface.
+type MockModerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockModeratorMockRecorder
+	isgomock struct{}
+}
+
+// MockModeratorMockRecorder is the mock recorder for MockModerator.
+type MockModeratorMockRecorder struct {
+	mock *MockModerator
+}
+
+// NewMockModerator creates a new mock instance.
+func NewMockModerator(ctrl *gomock.Controller) *MockModerator {
+	mock := &MockModerator{ctrl: ctrl}
+	mock.recorder = &MockModeratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerator) EXPECT() *MockModeratorMockRecorder {
+	return m.recorder
+}
+
+// Moderate mocks base method.
+func (m *MockModerator) Moderate(ctx context.Context, tweet domain.Tweet) domain.ModerationVerdict {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, tweet)
+	ret0, _ := ret[0].(domain.ModerationVerdict)
+	return ret0
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockModeratorMockRecorder) Moderate(ctx, tweet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockModerator)(nil).Moderate), ctx, tweet)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishPipeline mocks base method.
+func (m *MockEventPublisher) PublishPipeline(ctx context.Context, channels []string, message any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPipeline", ctx, channels, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPipeline indicates an expected call of PublishPipeline.
+func (mr *MockEventPublisherMockRecorder) PublishPipeline(ctx, channels, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPipeline", reflect.TypeOf((*MockEventPublisher)(nil).PublishPipeline), ctx, channels, message)
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MuteUser makes MuterID mute MutedID. Unlike a block, the follow is kept and the tweets are
+// still delivered; they're only hidden when the muter reads their timeline, so unmuting shows
+// them again.
+func (s Service) MuteUser(ctx context.Context, mute domain.MuteUser) error {
+	ctx, span := tracer.Start(ctx, "user.MuteUser", trace.WithAttributes(
+		attribute.String("mute.muter_id", mute.MuterID),
+		attribute.String("mute.muted_id", mute.MutedID),
+	))
+	defer span.End()
+
+	return s.Storage.CreateMute(ctx, mute)
+}
+
+// UnmuteUser lifts the mute of MuterID on MutedID.
+func (s Service) UnmuteUser(ctx context.Context, mute domain.MuteUser) error {
+	ctx, span := tracer.Start(ctx, "user.UnmuteUser", trace.WithAttributes(
+		attribute.String("mute.muter_id", mute.MuterID),
+		attribute.String("mute.muted_id", mute.MutedID),
+	))
+	defer span.End()
+
+	return s.Storage.DeleteMute(ctx, mute)
+}
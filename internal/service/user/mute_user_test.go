@@ -0,0 +1,87 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/service/user"
+	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMuteUser(t *testing.T) {
+	mute := domain.MuteUser{
+		MuterID: uuid.NewString(),
+		MutedID: uuid.NewString(),
+	}
+	dbError := errors.New("database connection lost")
+
+	testCases := []struct {
+		name        string
+		unmute      bool
+		setupMock   func(storage *mocks.MockStorageRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success - mutes the user",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateMute(gomock.Any(), mute).Return(nil)
+			},
+		},
+		{
+			name: "Failure - error muting the user",
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().CreateMute(gomock.Any(), mute).Return(dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name:   "Success - unmutes the user",
+			unmute: true,
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().DeleteMute(gomock.Any(), mute).Return(nil)
+			},
+		},
+		{
+			name:   "Failure - error unmuting the user",
+			unmute: true,
+			setupMock: func(storage *mocks.MockStorageRepo) {
+				storage.EXPECT().DeleteMute(gomock.Any(), mute).Return(dbError)
+			},
+			expectedErr: dbError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorageRepo(ctrl)
+			tc.setupMock(mockStorage)
+
+			service := user.NewService(mockStorage, nil, nil, nil, nil, user.Config{}, logging.Discard())
+
+			// Act
+			var err error
+			if tc.unmute {
+				err = service.UnmuteUser(context.Background(), mute)
+			} else {
+				err = service.MuteUser(context.Background(), mute)
+			}
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
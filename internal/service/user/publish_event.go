@@ -0,0 +1,21 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// publishEvent notifies the live subscribers of channel. The change is already stored, so a
+// failure is only logged: the clients see it on their next read.
+func (s Service) publishEvent(ctx context.Context, channel string, event domain.Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to encode event", "type", event.Type, "error", err)
+		return
+	}
+	if err := s.Events.PublishPipeline(ctx, []string{channel}, message); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to publish event", "type", event.Type, "channel", channel, "error", err)
+	}
+}
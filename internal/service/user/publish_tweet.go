@@ -2,28 +2,163 @@ package user
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
 
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (s Service) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
-	existTweet, err := s.Storage.SelectTweetByID(ctx, tweet.ID)
+// PublishTweet creates tweet, using its ID as idempotency key. A replay of an already used key
+// returns the stored tweet, without moderating it again, and created is false; if the key was
+// used for a different request (another author, text or media) ErrIdempotencyKeyMismatch is
+// returned.
+//
+// Only the IDs of tweet.Media are read: each must be an upload of the author not attached to
+// another tweet, otherwise ErrMediaNotAttachable is returned. The URLs are extracted from the
+// text and their previews fetched in the background.
+//
+// The tweet is moderated before it's stored: a rejected tweet returns ErrTweetRejected, a held
+// one is stored hidden until a moderator approves it and a shadow-limited one is stored but
+// not delivered to the timelines of the followers. Only a visible tweet is fanned out.
+func (s Service) PublishTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
+	ctx, span := tracer.Start(ctx, "user.PublishTweet", trace.WithAttributes(
+		attribute.String("tweet.id", tweet.ID),
+		attribute.String("user.id", tweet.UserID),
+	))
+	defer span.End()
+
+	// The server stamps the tweet, truncated to the precision of the API responses, so a
+	// replay returns the same time as the creation.
+	tweet.CreatedAt = s.Clock.Now().UTC().Truncate(time.Millisecond)
+	tweet.Fingerprint = fingerprint(tweet)
+	tweet.URLs = tweettext.ExtractURLs(tweet.Text)
+
+	// A replay gets the stored tweet back before the moderation runs: moderating it again could
+	// reject it, and the duplicate burst rule would count it as one more duplicate.
+	existing, err := s.Storage.SelectTweetByID(ctx, tweet.ID)
 	if err != nil {
-		return domain.Tweet{}, err
+		return domain.Tweet{}, false, err
+	}
+	if existing != nil {
+		span.SetAttributes(attribute.Bool("tweet.replayed", true))
+		return replayed(*existing, tweet)
+	}
+
+	verdict := s.Moderator.Moderate(ctx, tweet)
+	if verdict.Action == domain.ModerationReject {
+		// The author isn't told which rule, so the rules can't be probed.
+		return domain.Tweet{}, false, ErrTweetRejected
+	}
+	tweet.Moderation = verdict.Action.Status()
+	if verdict.Action != domain.ModerationAllow {
+		tweet.Flag = &verdict
 	}
 
-	if existTweet != nil {
-		return *existTweet, nil
+	if len(tweet.Media) > 0 {
+		media, err := s.attachableMedia(ctx, tweet)
+		if err != nil {
+			return domain.Tweet{}, false, err
+		}
+		tweet.Media = media
 	}
 
-	createTweet, err := s.Storage.CreateTweet(ctx, tweet)
+	// The insert is skipped when the key is taken, so concurrent replays can't both create it.
+	storedTweet, created, err := s.Storage.CreateTweet(ctx, tweet)
 	if err != nil {
-		return domain.Tweet{}, err
+		return domain.Tweet{}, false, err
+	}
+
+	if !created {
+		// A concurrent request with the same key stored it first.
+		span.SetAttributes(attribute.Bool("tweet.replayed", true))
+		return replayed(storedTweet, tweet)
 	}
 
 	// This goroutine is a temporary simulation of an async flow.
 	// The final implementation should leverage a message broker like AWS SQS/SNS.
-	go s.Timeline.UpdateTimeline(context.Background(), tweet.UserID, tweet.ID)
+	// The fan-out outlives the request, so it keeps the span (for linking) but not the cancellation.
+	if tweet.Moderation == domain.TweetVisible {
+		go s.Timeline.UpdateTimeline(context.WithoutCancel(ctx), tweet.UserID, tweet.ID)
+	} else {
+		span.SetAttributes(attribute.String("tweet.moderation", string(tweet.Moderation)))
+	}
+	s.unfurlLinks(ctx, storedTweet.URLs)
+
+	return storedTweet, true, nil
+}
+
+// replayed returns stored, the tweet already created with the ID of tweet, unless it was
+// created by a different request.
+func replayed(stored, tweet domain.Tweet) (domain.Tweet, bool, error) {
+	storedFingerprint := stored.Fingerprint
+	if storedFingerprint == "" {
+		// Tweets created before fingerprints were stored.
+		storedFingerprint = fingerprint(stored)
+	}
+	if storedFingerprint != tweet.Fingerprint {
+		return domain.Tweet{}, false, ErrIdempotencyKeyMismatch
+	}
+	return stored, false, nil
+}
 
-	return createTweet, nil
+// unfurlLinks fetches the previews of urls in the background, as they aren't needed to
+// answer the request: hydrated tweets show them once they are stored.
+func (s Service) unfurlLinks(ctx context.Context, urls []domain.URLEntity) {
+	if len(urls) == 0 {
+		return
+	}
+	links := make([]string, len(urls))
+	for i, entity := range urls {
+		links[i] = entity.URL
+	}
+	go s.Links.UnfurlLinks(context.WithoutCancel(ctx), links)
+}
+
+// attachableMedia loads the media of tweet, in the requested order, and checks they can be
+// attached to it. A media already attached to tweet itself is accepted, so replays pass.
+func (s Service) attachableMedia(ctx context.Context, tweet domain.Tweet) ([]domain.Media, error) {
+	mediaIDs := make([]string, len(tweet.Media))
+	for i, media := range tweet.Media {
+		mediaIDs[i] = media.ID
+	}
+
+	stored, err := s.Storage.SelectMediaByIDs(ctx, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	storedByID := make(map[string]domain.Media, len(stored))
+	for _, media := range stored {
+		storedByID[media.ID] = media
+	}
+
+	attachable := make([]domain.Media, len(mediaIDs))
+	for i, mediaID := range mediaIDs {
+		media, ok := storedByID[mediaID]
+		if !ok || media.UserID != tweet.UserID || (media.TweetID != "" && media.TweetID != tweet.ID) {
+			return nil, fmt.Errorf("%w: %s", ErrMediaNotAttachable, mediaID)
+		}
+		media.TweetID = tweet.ID
+		attachable[i] = media
+	}
+	return attachable, nil
+}
+
+// fingerprint hashes the fields of tweet set by the publish request. The creation time is
+// left out: it changes on every retry. The media are only hashed when present, so tweets
+// without media keep the fingerprints stored before media could be attached.
+func fingerprint(tweet domain.Tweet) string {
+	hash := sha256.New()
+	hash.Write([]byte(tweet.UserID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(tweet.Text))
+	for _, media := range tweet.Media {
+		hash.Write([]byte{0})
+		hash.Write([]byte(media.ID))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
@@ -2,16 +2,24 @@ package user_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/renzonaitor/tweet-api/internal/clock"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/moderation"
 	"github.com/renzonaitor/tweet-api/internal/service/user"
 	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
 
@@ -21,27 +29,86 @@ func TestPublishTweet(t *testing.T) {
 		UserID: uuid.NewString(),
 		Text:   "This is a test tweet!",
 	}
+	// The clock is in another zone and more precise than the API: tweets are stamped in UTC, to the millisecond.
+	now := time.Date(2025, 1, 1, 7, 0, 0, 123456789, time.FixedZone("UTC-3", -3*60*60))
+
+	// inputTweet as stored: with the creation time and the request fingerprint.
+	storedTweet := inputTweet
+	storedTweet.CreatedAt = time.Date(2025, 1, 1, 10, 0, 0, 123000000, time.UTC)
+	storedTweet.Fingerprint = fingerprint(inputTweet)
+	storedTweet.Moderation = domain.TweetVisible
+	legacyTweet := inputTweet
+
+	otherText := storedTweet
+	otherText.Text = "This is another tweet!"
+	otherText.Fingerprint = fingerprint(otherText)
+	otherAuthor := storedTweet
+	otherAuthor.UserID = uuid.NewString()
+	otherAuthor.Fingerprint = fingerprint(otherAuthor)
+
+	// A tweet with two media, attached in the requested order.
+	photo := domain.Media{ID: uuid.NewString(), UserID: inputTweet.UserID, ContentType: "image/png", Size: 2048}
+	video := domain.Media{ID: uuid.NewString(), UserID: inputTweet.UserID, ContentType: "video/mp4", Size: 4096}
+	inputWithMedia := inputTweet
+	inputWithMedia.Media = []domain.Media{{ID: video.ID}, {ID: photo.ID}}
+	attachedPhoto, attachedVideo := photo, video
+	attachedPhoto.TweetID, attachedVideo.TweetID = inputTweet.ID, inputTweet.ID
+	storedWithMedia := storedTweet
+	storedWithMedia.Media = []domain.Media{attachedVideo, attachedPhoto}
+	storedWithMedia.Fingerprint = fingerprint(inputWithMedia)
+	otherAuthorPhoto := photo
+	otherAuthorPhoto.UserID = uuid.NewString()
+	photoOfOtherTweet := photo
+	photoOfOtherTweet.TweetID = uuid.NewString()
+	mediaIDs := []string{video.ID, photo.ID}
+
+	// A tweet linking twice to the same page: every URL is stored, and each page is unfurled.
+	inputWithURLs := inputTweet
+	inputWithURLs.Text = "Read https://go.dev/doc and https://go.dev/doc."
+	storedWithURLs := storedTweet
+	storedWithURLs.Text = inputWithURLs.Text
+	storedWithURLs.Fingerprint = fingerprint(inputWithURLs)
+	storedWithURLs.URLs = []domain.URLEntity{
+		{URL: "https://go.dev/doc", Start: 5, End: 23},
+		{URL: "https://go.dev/doc", Start: 28, End: 46},
+	}
+
+	// Tweets breaking a moderation rule are stored with their flag.
+	holdVerdict := domain.ModerationVerdict{Action: domain.ModerationHold, Rule: "banned_words", Reason: `contains the banned word "test"`}
+	heldTweet := storedTweet
+	heldTweet.Moderation = domain.TweetHeld
+	heldTweet.Flag = &holdVerdict
+	limitVerdict := domain.ModerationVerdict{Action: domain.ModerationShadowLimit, Rule: "duplicate_burst", Reason: "repeats the text of 3 tweets published in the last 10m0s"}
+	limitedTweet := storedTweet
+	limitedTweet.Moderation = domain.TweetLimited
+	limitedTweet.Flag = &limitVerdict
+	limitedWithURLs := storedWithURLs
+	limitedWithURLs.Moderation = domain.TweetLimited
+	limitedWithURLs.Flag = &limitVerdict
 
 	dbError := errors.New("database connection lost")
 
 	testCases := []struct {
-		name          string
-		input         domain.Tweet
-		setupMocks    func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup)
-		expectedTweet domain.Tweet
-		expectedErr   error
+		name       string
+		input      domain.Tweet
+		setupMocks func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup)
+		// existing is the tweet already stored with the ID of the input, if any.
+		existing  *domain.Tweet
+		lookupErr error
+		// verdict is the moderation of the tweet, allowed when nil.
+		verdict         *domain.ModerationVerdict
+		expectedTweet   domain.Tweet
+		expectedCreated bool
+		expectedErr     error
 	}{
 		{
 			name:  "Success - New Tweet",
 			input: inputTweet,
-			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
-				// 1. Expect a call to check for the tweet, and it's not found.
-				storage.EXPECT().SelectTweetByID(gomock.Any(), inputTweet.ID).Return(nil, nil)
-
-				// 2. Expect a call to create the tweet, which succeeds.
-				storage.EXPECT().CreateTweet(gomock.Any(), inputTweet).Return(inputTweet, nil)
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				// 1. Expect a call to create the tweet, and the ID is free.
+				storage.EXPECT().CreateTweet(gomock.Any(), storedTweet).Return(storedTweet, true, nil)
 
-				// 3. Expect the async timeline update. We use a WaitGroup to test this.
+				// 2. Expect the async timeline update. We use a WaitGroup to test this.
 				wg.Add(1)
 				timeline.EXPECT().
 					UpdateTimeline(gomock.Any(), inputTweet.UserID, inputTweet.ID).
@@ -49,34 +116,206 @@ func TestPublishTweet(t *testing.T) {
 						wg.Done() // Signal that the mock was called
 					})
 			},
-			expectedTweet: inputTweet,
+			expectedTweet:   storedTweet,
+			expectedCreated: true,
+			expectedErr:     nil,
+		},
+		{
+			name:     "Success - Idempotency Hit",
+			existing: &storedTweet,
+			input:    inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				// No other calls to storage, moderator or timeline are expected.
+			},
+			expectedTweet: storedTweet,
 			expectedErr:   nil,
 		},
 		{
-			name:  "Success - Idempotency Hit",
-			input: inputTweet,
-			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
-				storage.EXPECT().SelectTweetByID(gomock.Any(), inputTweet.ID).Return(&inputTweet, nil)
-				// No other calls to storage or timeline are expected.
+			name:     "Success - Idempotency Hit on a tweet without fingerprint",
+			existing: &legacyTweet,
+			input:    inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
 			},
-			expectedTweet: inputTweet,
+			expectedTweet: legacyTweet,
 			expectedErr:   nil,
 		},
 		{
-			name:  "Failure - Error checking for existing tweet",
-			input: inputTweet,
-			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
-				storage.EXPECT().SelectTweetByID(gomock.Any(), inputTweet.ID).Return(nil, dbError)
+			name:     "Failure - Idempotency key reused with another text",
+			existing: &otherText,
+			input:    inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
 			},
 			expectedTweet: domain.Tweet{},
-			expectedErr:   dbError,
+			expectedErr:   user.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name:     "Failure - Idempotency key reused by another user",
+			existing: &otherAuthor,
+			input:    inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedTweet: domain.Tweet{},
+			expectedErr:   user.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name:     "Success - Idempotency Hit isn't moderated again",
+			existing: &storedTweet,
+			input:    inputTweet,
+			// The rules changed since the tweet was stored: a new request would be rejected.
+			verdict: &domain.ModerationVerdict{Action: domain.ModerationReject, Rule: "banned_words", Reason: `contains the banned word "test"`},
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedTweet: storedTweet,
+		},
+		{
+			name:  "Success - Idempotency Hit stored first by a concurrent request",
+			input: inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), storedTweet).Return(storedTweet, false, nil)
+			},
+			expectedTweet: storedTweet,
+		},
+		{
+			name:      "Failure - Error looking up the idempotency key",
+			lookupErr: dbError,
+			input:     inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedErr: dbError,
+		},
+		{
+			name:  "Success - New Tweet with media",
+			input: inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectMediaByIDs(gomock.Any(), mediaIDs).Return([]domain.Media{photo, video}, nil)
+				storage.EXPECT().CreateTweet(gomock.Any(), storedWithMedia).Return(storedWithMedia, true, nil)
+				wg.Add(1)
+				timeline.EXPECT().
+					UpdateTimeline(gomock.Any(), inputTweet.UserID, inputTweet.ID).
+					Do(func(ctx context.Context, authorID, tweetID string) { wg.Done() })
+			},
+			expectedTweet:   storedWithMedia,
+			expectedCreated: true,
+		},
+		{
+			name:  "Success - New Tweet with URLs unfurls them",
+			input: inputWithURLs,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), storedWithURLs).Return(storedWithURLs, true, nil)
+				wg.Add(2)
+				timeline.EXPECT().
+					UpdateTimeline(gomock.Any(), inputTweet.UserID, inputTweet.ID).
+					Do(func(ctx context.Context, authorID, tweetID string) { wg.Done() })
+				links.EXPECT().
+					UnfurlLinks(gomock.Any(), []string{"https://go.dev/doc", "https://go.dev/doc"}).
+					Do(func(ctx context.Context, urls []string) { wg.Done() })
+			},
+			expectedTweet:   storedWithURLs,
+			expectedCreated: true,
+		},
+		{
+			name:     "Success - Idempotency Hit with URLs doesn't unfurl them again",
+			existing: &storedWithURLs,
+			input:    inputWithURLs,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedTweet: storedWithURLs,
+		},
+		{
+			name:     "Success - Idempotency Hit with media already attached to the tweet",
+			existing: &storedWithMedia,
+			input:    inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedTweet: storedWithMedia,
+		},
+		{
+			name:     "Failure - Idempotency key reused with other media",
+			existing: &storedWithMedia,
+			input:    inputTweet,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedErr: user.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name:  "Failure - Unknown media",
+			input: inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectMediaByIDs(gomock.Any(), mediaIDs).Return([]domain.Media{photo}, nil)
+			},
+			expectedErr: user.ErrMediaNotAttachable,
+		},
+		{
+			name:  "Failure - Media uploaded by another user",
+			input: inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectMediaByIDs(gomock.Any(), mediaIDs).Return([]domain.Media{otherAuthorPhoto, video}, nil)
+			},
+			expectedErr: user.ErrMediaNotAttachable,
+		},
+		{
+			name:  "Failure - Media attached to another tweet",
+			input: inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectMediaByIDs(gomock.Any(), mediaIDs).Return([]domain.Media{photoOfOtherTweet, video}, nil)
+			},
+			expectedErr: user.ErrMediaNotAttachable,
+		},
+		{
+			name:  "Failure - Error selecting media",
+			input: inputWithMedia,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().SelectMediaByIDs(gomock.Any(), mediaIDs).Return(nil, dbError)
+			},
+			expectedErr: dbError,
+		},
+		{
+			name:    "Success - held tweet is stored flagged and not fanned out",
+			input:   inputTweet,
+			verdict: &holdVerdict,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), heldTweet).Return(heldTweet, true, nil)
+			},
+			expectedTweet:   heldTweet,
+			expectedCreated: true,
+		},
+		{
+			name:    "Success - shadow-limited tweet is stored flagged and not fanned out",
+			input:   inputTweet,
+			verdict: &limitVerdict,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), limitedTweet).Return(limitedTweet, true, nil)
+			},
+			expectedTweet:   limitedTweet,
+			expectedCreated: true,
+		},
+		{
+			name:    "Success - shadow-limited tweet still unfurls its URLs",
+			input:   inputWithURLs,
+			verdict: &limitVerdict,
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), limitedWithURLs).Return(limitedWithURLs, true, nil)
+				wg.Add(1)
+				links.EXPECT().
+					UnfurlLinks(gomock.Any(), []string{"https://go.dev/doc", "https://go.dev/doc"}).
+					Do(func(ctx context.Context, urls []string) { wg.Done() })
+			},
+			expectedTweet:   limitedWithURLs,
+			expectedCreated: true,
+		},
+		{
+			name:    "Failure - rejected by moderation stores nothing",
+			input:   inputWithMedia,
+			verdict: &domain.ModerationVerdict{Action: domain.ModerationReject, Rule: "link_blocklist", Reason: "links to a blocked domain"},
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+			},
+			expectedErr: user.ErrTweetRejected,
 		},
 		{
 			name:  "Failure - Error creating tweet",
 			input: inputTweet,
-			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, wg *sync.WaitGroup) {
-				storage.EXPECT().SelectTweetByID(gomock.Any(), inputTweet.ID).Return(nil, nil)
-				storage.EXPECT().CreateTweet(gomock.Any(), inputTweet).Return(domain.Tweet{}, dbError)
+			setupMocks: func(storage *mocks.MockStorageRepo, timeline *mocks.MockTimelineUpdater, links *mocks.MockLinkUnfurler, wg *sync.WaitGroup) {
+				storage.EXPECT().CreateTweet(gomock.Any(), storedTweet).Return(domain.Tweet{}, false, dbError)
 			},
 			expectedTweet: domain.Tweet{},
 			expectedErr:   dbError,
@@ -89,16 +328,37 @@ func TestPublishTweet(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockStorage := mocks.NewMockStorageRepo(ctrl)
 			mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+			mockLinks := mocks.NewMockLinkUnfurler(ctrl)
+			mockModerator := mocks.NewMockModerator(ctrl)
 			wg := &sync.WaitGroup{}
 
+			verdict := domain.ModerationVerdict{Action: domain.ModerationAllow}
+			if tc.verdict != nil {
+				verdict = *tc.verdict
+			}
+			// Replays aren't moderated.
+			moderations := 1
+			if tc.existing != nil || tc.lookupErr != nil {
+				moderations = 0
+			}
+			mockModerator.EXPECT().
+				Moderate(gomock.Any(), gomock.Cond(func(tweet domain.Tweet) bool {
+					// The tweet is moderated with its URLs and before it's stored.
+					return tweet.ID == tc.input.ID && tweet.Text == tc.input.Text && len(tweet.URLs) == len(tweettext.ExtractURLs(tc.input.Text))
+				})).
+				Return(verdict).
+				MaxTimes(moderations)
+			mockStorage.EXPECT().SelectTweetByID(gomock.Any(), tc.input.ID).Return(tc.existing, tc.lookupErr)
+
 			if tc.setupMocks != nil {
-				tc.setupMocks(mockStorage, mockTimeline, wg)
+				tc.setupMocks(mockStorage, mockTimeline, mockLinks, wg)
 			}
 
-			service := user.NewService(mockStorage, mockTimeline)
+			service := user.NewService(mockStorage, mockTimeline, mockLinks, mockModerator, nil, user.Config{}, logging.Discard())
+			service.Clock = clock.Fixed(now)
 
 			// Act
-			resultTweet, err := service.PublishTweet(context.Background(), tc.input)
+			resultTweet, created, err := service.PublishTweet(context.Background(), tc.input)
 
 			// Assert
 			// Wait for the goroutine to finish (if one was expected)
@@ -114,6 +374,133 @@ func TestPublishTweet(t *testing.T) {
 
 			// Check the returned tweet
 			assert.Equal(t, tc.expectedTweet, resultTweet)
+			assert.Equal(t, tc.expectedCreated, created)
 		})
 	}
 }
+
+func TestPublishTweet_FanOutContext(t *testing.T) {
+	// Arrange
+	inputTweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "This is a test tweet!"}
+	requestSpan := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+	mockStorage.EXPECT().SelectTweetByID(gomock.Any(), inputTweet.ID).Return(nil, nil)
+	mockStorage.EXPECT().CreateTweet(gomock.Any(), gomock.Any()).Return(inputTweet, true, nil)
+
+	fanOutCtx := make(chan context.Context, 1)
+	mockTimeline.EXPECT().
+		UpdateTimeline(gomock.Any(), inputTweet.UserID, inputTweet.ID).
+		Do(func(ctx context.Context, authorID, tweetID string) { fanOutCtx <- ctx })
+
+	// The request is already finished by the time the fan-out runs.
+	ctx, cancel := context.WithCancel(trace.ContextWithSpanContext(context.Background(), requestSpan))
+	cancel()
+
+	service := user.NewService(mockStorage, mockTimeline, nil, moderation.NewModerator(logging.Discard()), nil, user.Config{}, logging.Discard())
+
+	// Act
+	_, _, err := service.PublishTweet(ctx, inputTweet)
+
+	// Assert
+	require.NoError(t, err)
+	received := <-fanOutCtx
+	assert.NoError(t, received.Err())
+	assert.Equal(t, requestSpan.TraceID(), trace.SpanContextFromContext(received).TraceID())
+}
+
+// fingerprint mirrors the request fingerprint computed by the service.
+func fingerprint(tweet domain.Tweet) string {
+	request := tweet.UserID + "\x00" + tweet.Text
+	for _, media := range tweet.Media {
+		request += "\x00" + media.ID
+	}
+	hash := sha256.Sum256([]byte(request))
+	return hex.EncodeToString(hash[:])
+}
+
+// memoryStorage is a user.StorageRepo keeping the tweets in a map. CreateTweet has the
+// INSERT ... ON CONFLICT DO NOTHING semantics of the PostgreSQL repository; the other
+// methods but SelectTweetByID aren't implemented.
+type memoryStorage struct {
+	user.StorageRepo
+	mu     sync.Mutex
+	tweets map[string]domain.Tweet
+}
+
+func (s *memoryStorage) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.tweets[tweet.ID]; ok {
+		return existing, false, nil
+	}
+	s.tweets[tweet.ID] = tweet
+	return tweet, true, nil
+}
+
+func (s *memoryStorage) SelectTweetByID(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.tweets[tweetID]; ok {
+		return &existing, nil
+	}
+	return nil, nil
+}
+
+func TestPublishTweet_ConcurrentReplays(t *testing.T) {
+	// Arrange
+	const requests = 50
+	inputTweet := domain.Tweet{ID: uuid.NewString(), UserID: uuid.NewString(), Text: "This is a test tweet!"}
+
+	ctrl := gomock.NewController(t)
+	mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+	fanOuts := make(chan string, requests)
+	mockTimeline.EXPECT().
+		UpdateTimeline(gomock.Any(), inputTweet.UserID, inputTweet.ID).
+		Do(func(ctx context.Context, authorID, tweetID string) { fanOuts <- tweetID }).
+		Times(1)
+
+	service := user.NewService(&memoryStorage{tweets: map[string]domain.Tweet{}}, mockTimeline, nil, moderation.NewModerator(logging.Discard()), nil, user.Config{}, logging.Discard())
+
+	type result struct {
+		tweet   domain.Tweet
+		created bool
+		err     error
+	}
+	results := make(chan result, requests)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// Act
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			tweet, created, err := service.PublishTweet(context.Background(), inputTweet)
+			results <- result{tweet, created, err}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	// Assert
+	var createdCount int
+	for r := range results {
+		require.NoError(t, r.err)
+		assert.Equal(t, inputTweet.ID, r.tweet.ID)
+		assert.Equal(t, inputTweet.Text, r.tweet.Text)
+		if r.created {
+			createdCount++
+		}
+	}
+	assert.Equal(t, 1, createdCount)
+	assert.Equal(t, inputTweet.ID, <-fanOuts)
+}
@@ -2,30 +2,112 @@ package user
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
+	"github.com/renzonaitor/tweet-api/internal/clock"
 	"github.com/renzonaitor/tweet-api/internal/domain"
+	"go.opentelemetry.io/otel"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/user_mocks.go -package=mocks
 type StorageRepo interface {
 	CreateRelation(ctx context.Context, follow domain.FollowUser) error
-	CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error)
+	// ExistsBlockBetween reports whether either user blocks the other.
+	ExistsBlockBetween(ctx context.Context, userID, otherUserID string) (bool, error)
+	// CreateBlock stores the block and removes the follows between both users.
+	CreateBlock(ctx context.Context, block domain.BlockUser) error
+	DeleteBlock(ctx context.Context, block domain.BlockUser) error
+	CreateMute(ctx context.Context, mute domain.MuteUser) error
+	DeleteMute(ctx context.Context, mute domain.MuteUser) error
+	// CreateTweet stores tweet unless its ID is taken, and returns the stored tweet either way.
+	CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, bool, error)
 	SelectTweetByID(ctx context.Context, tweetID string) (*domain.Tweet, error)
+	// UpdateTweetText edits the text of a tweet by authorID created at or after editableSince,
+	// keeping the previous text as a revision. It returns nil when no such tweet exists.
+	UpdateTweetText(ctx context.Context, tweetID, authorID, text string, urls []domain.URLEntity, editedAt, editableSince time.Time) (*domain.Tweet, error)
+	SelectTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error)
+	SelectMediaByIDs(ctx context.Context, mediaIDs []string) ([]domain.Media, error)
 }
 
 type TimelineUpdater interface {
 	UpdateTimeline(ctx context.Context, tweetAuthorID, tweetID string)
 }
 
+// LinkUnfurler fetches and stores the previews of the URLs linked by tweets.
+type LinkUnfurler interface {
+	UnfurlLinks(ctx context.Context, urls []string)
+}
+
+// Moderator decides what happens to a tweet before it is published.
+type Moderator interface {
+	Moderate(ctx context.Context, tweet domain.Tweet) domain.ModerationVerdict
+}
+
+// EventPublisher pushes the real-time events to their Pub/Sub channels.
+type EventPublisher interface {
+	PublishPipeline(ctx context.Context, channels []string, message interface{}) error
+}
+
+// Defaults used when the matching Config field is not set.
+const (
+	defaultEditWindow = 30 * time.Minute
+)
+
+var (
+	// ErrIdempotencyKeyMismatch is returned when an idempotency key is replayed with a request
+	// that differs from the one that first used it.
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key already used with a different request")
+	// ErrTweetNotFound is returned when a tweet doesn't exist.
+	ErrTweetNotFound = errors.New("tweet not found")
+	// ErrNotTweetAuthor is returned when a user edits a tweet written by someone else.
+	ErrNotTweetAuthor = errors.New("only the author can edit the tweet")
+	// ErrEditWindowExpired is returned when a tweet is edited after Config.EditWindow.
+	ErrEditWindowExpired = errors.New("the tweet can no longer be edited")
+	// ErrMediaNotAttachable is returned when a tweet is published with a media that doesn't
+	// exist, was uploaded by another user or is attached to another tweet.
+	ErrMediaNotAttachable = errors.New("media can't be attached to the tweet")
+	// ErrTweetRejected is returned when the moderation refuses a tweet or an edit.
+	ErrTweetRejected = errors.New("tweet rejected by moderation")
+	// ErrUserBlocked is returned when a user follows someone they block or are blocked by.
+	ErrUserBlocked = errors.New("a block exists between the users")
+)
+
+var tracer = otel.Tracer("github.com/renzonaitor/tweet-api/internal/service/user")
+
+// Config holds the tunable limits of the user service.
+type Config struct {
+	// EditWindow is how long after its creation a tweet can be edited.
+	EditWindow time.Duration
+}
+
 // Service depends on the interfaces, not concrete types.
 type Service struct {
-	Storage  StorageRepo
-	Timeline TimelineUpdater
+	Storage   StorageRepo
+	Timeline  TimelineUpdater
+	Links     LinkUnfurler
+	Moderator Moderator
+	Events    EventPublisher
+	Config    Config
+	Logger    *slog.Logger
+	// Clock stamps the new and edited tweets. Defaults to the system clock.
+	Clock clock.Clock
 }
 
-func NewService(storage StorageRepo, timeline TimelineUpdater) *Service {
+func NewService(storage StorageRepo, timeline TimelineUpdater, links LinkUnfurler, moderator Moderator, events EventPublisher, cfg Config, logger *slog.Logger) *Service {
+	if cfg.EditWindow <= 0 {
+		cfg.EditWindow = defaultEditWindow
+	}
+
 	return &Service{
-		Storage:  storage,
-		Timeline: timeline,
+		Storage:   storage,
+		Timeline:  timeline,
+		Links:     links,
+		Moderator: moderator,
+		Events:    events,
+		Config:    cfg,
+		Logger:    logger,
+		Clock:     clock.System,
 	}
 }
@@ -2,7 +2,9 @@ package user_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/renzonaitor/tweet-api/internal/logging"
 	"github.com/renzonaitor/tweet-api/internal/service/user"
 	"github.com/renzonaitor/tweet-api/internal/service/user/mocks"
 	"github.com/stretchr/testify/assert"
@@ -18,9 +20,12 @@ func TestNewService(t *testing.T) {
 	// Create mock instances using the auto-generated constructors.
 	mockStorage := mocks.NewMockStorageRepo(ctrl)
 	mockTimeline := mocks.NewMockTimelineUpdater(ctrl)
+	mockLinks := mocks.NewMockLinkUnfurler(ctrl)
+	mockModerator := mocks.NewMockModerator(ctrl)
+	mockEvents := mocks.NewMockEventPublisher(ctrl)
 
 	// Act: Call the constructor function that we are testing.
-	service := user.NewService(mockStorage, mockTimeline)
+	service := user.NewService(mockStorage, mockTimeline, mockLinks, mockModerator, mockEvents, user.Config{}, logging.Discard())
 
 	// Assert: Verify the outcome.
 	// 1. Ensure the service object was actually created.
@@ -30,4 +35,10 @@ func TestNewService(t *testing.T) {
 	// This confirms that the service holds the dependencies it needs to operate.
 	assert.Equal(t, mockStorage, service.Storage, "Storage should be the provided mock instance")
 	assert.Equal(t, mockTimeline, service.Timeline, "TimelineUpdater should be the provided mock instance")
+	assert.Equal(t, mockLinks, service.Links, "LinkUnfurler should be the provided mock instance")
+	assert.Equal(t, mockModerator, service.Moderator, "Moderator should be the provided mock instance")
+	assert.Equal(t, mockEvents, service.Events, "EventPublisher should be the provided mock instance")
+
+	// 3. Ensure the unset limits get their defaults.
+	assert.Equal(t, 30*time.Minute, service.Config.EditWindow, "EditWindow should default to 30 minutes")
 }
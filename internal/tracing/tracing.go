@@ -0,0 +1,72 @@
+// Package tracing configures the OpenTelemetry tracer provider of the service.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const defaultServiceName = "tweet-api"
+
+// Config holds the settings of the span exporter.
+type Config struct {
+	// Endpoint is the host:port of the OTLP/HTTP collector. Tracing is disabled when empty.
+	Endpoint string
+	// Insecure sends the spans over plain HTTP instead of HTTPS.
+	Insecure bool
+	// ServiceName identifies this service in the traces.
+	ServiceName string
+	// SampleRatio is the fraction of new traces that are recorded, from 0 to 1.
+	// Traces started by a caller follow the caller's decision.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// When no endpoint is configured the default no-op provider is kept, so spans cost nothing.
+// The returned function flushes the pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider := NewProvider(exporter, cfg)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// NewProvider returns a tracer provider that batches the spans to exporter. Tests pass an
+// in-memory exporter (see go.opentelemetry.io/otel/sdk/trace/tracetest) to inspect the spans.
+func NewProvider(exporter sdktrace.SpanExporter, cfg Config) *sdktrace.TracerProvider {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
@@ -0,0 +1,83 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestSetup(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  tracing.Config
+	}{
+		{
+			name: "Success - disabled without an endpoint",
+			cfg:  tracing.Config{},
+		},
+		{
+			name: "Success - OTLP exporter",
+			cfg:  tracing.Config{Endpoint: "localhost:4318", Insecure: true, SampleRatio: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			shutdown, err := tracing.Setup(context.Background(), tc.cfg)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotNil(t, shutdown)
+			// Nothing was recorded, so shutting down doesn't reach the collector.
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	testCases := []struct {
+		name                string
+		cfg                 tracing.Config
+		expectedSpans       int
+		expectedServiceName string
+	}{
+		{
+			name:                "Records every trace with ratio 1",
+			cfg:                 tracing.Config{ServiceName: "tweet-api-test", SampleRatio: 1},
+			expectedSpans:       1,
+			expectedServiceName: "tweet-api-test",
+		},
+		{
+			name:          "Drops every trace with ratio 0",
+			cfg:           tracing.Config{SampleRatio: 0},
+			expectedSpans: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			exporter := tracetest.NewInMemoryExporter()
+			provider := tracing.NewProvider(exporter, tc.cfg)
+
+			// Act
+			_, span := provider.Tracer("test").Start(context.Background(), "operation")
+			span.End()
+			require.NoError(t, provider.ForceFlush(context.Background()))
+			t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+			// Assert
+			spans := exporter.GetSpans()
+			require.Len(t, spans, tc.expectedSpans)
+			if tc.expectedSpans > 0 {
+				assert.Contains(t, spans[0].Resource.Attributes(), semconv.ServiceName(tc.expectedServiceName))
+			}
+		})
+	}
+}
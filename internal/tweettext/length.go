@@ -0,0 +1,99 @@
+package tweettext
+
+import (
+	"strings"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// lightRanges are the code points weighing one: the Latin, Greek, Cyrillic, Hebrew, Arabic
+// and Indic scripts among others, and the common punctuation. Everything else, e.g. CJK,
+// weighs two.
+var lightRanges = [][2]rune{
+	{0x0000, 0x10FF},
+	{0x2000, 0x200D},
+	{0x2010, 0x201F},
+	{0x2032, 0x2037},
+}
+
+// Code points only found in emoji sequences: the emoji presentation selector and the keycap.
+const (
+	emojiPresentation = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+)
+
+// Result is the parse of the text of a tweet.
+type Result struct {
+	// Text is the text as it's published: trimmed and NFC normalized.
+	Text string
+	// WeightedLength is the length the text counts towards MaxLength.
+	WeightedLength int
+	// Valid reports whether the text can be published: it isn't blank and it fits MaxLength.
+	Valid bool
+	// URLs are the URLs of the text, with their offsets in Text.
+	URLs []domain.URLEntity
+}
+
+// Parse counts text as it is published: trimmed of the surrounding whitespace and NFC
+// normalized, so a character counts the same whether it was typed precomposed or with
+// combining marks. That text is returned in Result.Text and is the one to store.
+//
+// The text is counted by grapheme clusters, what users see as a single character, each
+// weighing as its first code point: one or two, see lightRanges. An emoji weighs two
+// whatever the code points in its sequence, e.g. skin tones, flags or ZWJ families. Every
+// URL counts URLLength, whatever its length.
+func Parse(text string) Result {
+	text = norm.NFC.String(strings.TrimSpace(text))
+	length := weightedLength(text)
+
+	return Result{
+		Text:           text,
+		WeightedLength: length,
+		Valid:          text != "" && length <= MaxLength,
+		URLs:           ExtractURLs(text),
+	}
+}
+
+// Length returns the weighted length of text, see Parse.
+func Length(text string) int {
+	return Parse(text).WeightedLength
+}
+
+// weightedLength counts the URLs of text at URLLength and the rest by grapheme clusters.
+func weightedLength(text string) int {
+	length := 0
+	last := 0
+	for _, match := range findURLs(text) {
+		length += clustersWeight(text[last:match[0]]) + URLLength
+		last = match[1]
+	}
+	return length + clustersWeight(text[last:])
+}
+
+func clustersWeight(text string) int {
+	weight := 0
+	state := -1
+	var cluster string
+	for text != "" {
+		cluster, text, _, state = uniseg.FirstGraphemeClusterInString(text, state)
+		weight += clusterWeight(cluster)
+	}
+	return weight
+}
+
+// clusterWeight weighs a grapheme cluster as its first code point, unless it's an emoji
+// sequence starting with a light one, e.g. the keycap 1️⃣ or ©️.
+func clusterWeight(cluster string) int {
+	if strings.ContainsRune(cluster, emojiPresentation) || strings.ContainsRune(cluster, combiningKeycap) {
+		return 2
+	}
+	first := []rune(cluster)[0]
+	for _, r := range lightRanges {
+		if first >= r[0] && first <= r[1] {
+			return 1
+		}
+	}
+	return 2
+}
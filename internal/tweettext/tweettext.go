@@ -0,0 +1,69 @@
+// Package tweettext holds the rules of the text of a tweet: which parts are links and how
+// long the text counts, following the weighted counting of Twitter.
+package tweettext
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+)
+
+// MaxLength is the maximum weighted length of a tweet, see Parse.
+const MaxLength = 280
+
+// URLLength is what every URL counts towards MaxLength, whatever its length, as if it was
+// shortened.
+const URLLength = 23
+
+// urlPattern matches the candidate URLs: an http or https scheme up to the next whitespace or
+// character that can't be part of a URL written in a text.
+var urlPattern = regexp.MustCompile(`(?i)https?://[^\s<>"]+`)
+
+// trailingPunctuation is trimmed from the end of a URL: it ends the sentence, not the URL.
+const trailingPunctuation = ".,:;!?'"
+
+// ExtractURLs returns the URLs of text, in order, with their offsets in code points.
+func ExtractURLs(text string) []domain.URLEntity {
+	var entities []domain.URLEntity
+	for _, match := range findURLs(text) {
+		start := utf8.RuneCountInString(text[:match[0]])
+		entities = append(entities, domain.URLEntity{
+			URL:   text[match[0]:match[1]],
+			Start: start,
+			End:   start + utf8.RuneCountInString(text[match[0]:match[1]]),
+		})
+	}
+	return entities
+}
+
+// findURLs returns the byte offsets of the URLs of text, end excluded.
+func findURLs(text string) [][2]int {
+	var matches [][2]int
+	for _, match := range urlPattern.FindAllStringIndex(text, -1) {
+		candidate := trimURL(text[match[0]:match[1]])
+		parsed, err := url.Parse(candidate)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		matches = append(matches, [2]int{match[0], match[0] + len(candidate)})
+	}
+	return matches
+}
+
+// trimURL drops the punctuation ending a sentence and the closing parentheses without an
+// opening one in the URL, e.g. the one of "(see https://example.com)".
+func trimURL(candidate string) string {
+	for {
+		trimmed := strings.TrimRight(candidate, trailingPunctuation)
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = strings.TrimSuffix(trimmed, ")")
+		}
+		if trimmed == candidate {
+			return candidate
+		}
+		candidate = trimmed
+	}
+}
@@ -0,0 +1,198 @@
+package tweettext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/renzonaitor/tweet-api/internal/domain"
+	"github.com/renzonaitor/tweet-api/internal/tweettext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractURLs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []domain.URLEntity
+	}{
+		{
+			name: "No URL",
+			text: "just text, example.com isn't a link without a scheme",
+		},
+		{
+			name: "URLs with offsets in code points",
+			text: "¡hola! https://example.com/a?b=c y http://go.dev",
+			expected: []domain.URLEntity{
+				{URL: "https://example.com/a?b=c", Start: 7, End: 32},
+				{URL: "http://go.dev", Start: 35, End: 48},
+			},
+		},
+		{
+			name:     "Trailing punctuation is not part of the URL",
+			text:     "read https://example.com/post.",
+			expected: []domain.URLEntity{{URL: "https://example.com/post", Start: 5, End: 29}},
+		},
+		{
+			name:     "Unbalanced closing parenthesis is not part of the URL",
+			text:     "(see https://example.com/x)!",
+			expected: []domain.URLEntity{{URL: "https://example.com/x", Start: 5, End: 26}},
+		},
+		{
+			name:     "Balanced parentheses are part of the URL",
+			text:     "https://en.wikipedia.org/wiki/Go_(programming_language)",
+			expected: []domain.URLEntity{{URL: "https://en.wikipedia.org/wiki/Go_(programming_language)", Start: 0, End: 55}},
+		},
+		{
+			name:     "Scheme in upper case",
+			text:     "HTTPS://EXAMPLE.COM",
+			expected: []domain.URLEntity{{URL: "HTTPS://EXAMPLE.COM", Start: 0, End: 19}},
+		},
+		{
+			name: "Scheme without host is skipped",
+			text: "https://",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			entities := tweettext.ExtractURLs(tc.text)
+
+			// Assert
+			assert.Equal(t, tc.expected, entities)
+		})
+	}
+}
+
+func TestLength(t *testing.T) {
+	longURL := "https://example.com/" + strings.Repeat("a", 100)
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		// Light characters weigh one.
+		{name: "ASCII", text: "hello world", expected: 11},
+		{name: "Latin with precomposed accents", text: "¡Olé! ñandú", expected: 11},
+		{name: "Greek", text: "γειά σου", expected: 8},
+		{name: "Cyrillic", text: "привет", expected: 6},
+		{name: "Hebrew", text: "שלום", expected: 4},
+		{name: "Arabic", text: "مرحبا", expected: 5},
+		{name: "Common punctuation", text: "“quoted” — ‘a’ ′", expected: 16},
+		{name: "Whitespace inside the text counts", text: "a \t\nb", expected: 5},
+
+		// Heavy characters weigh two.
+		{name: "Chinese", text: "你好世界", expected: 8},
+		{name: "Japanese", text: "こんにちは", expected: 10},
+		{name: "Korean", text: "안녕하세요", expected: 10},
+		{name: "Fullwidth Latin", text: "ＡＢＣ", expected: 6},
+		{name: "Mixed scripts", text: "Go 言語", expected: 7},
+		{name: "Symbol outside the light ranges", text: "→ ✓", expected: 5},
+		{name: "Ellipsis is outside the light ranges", text: "wait…", expected: 6},
+
+		// Grapheme clusters count once.
+		{name: "Combining acute is normalized to a single character", text: "e\u0301", expected: 1},
+		{name: "Decomposed and precomposed text weigh the same", text: "Cafe\u0301 / Café", expected: 11},
+		{name: "Stacked combining marks without a precomposed form", text: "q\u0307\u0323", expected: 1},
+		{name: "Hangul jamo compose to a syllable", text: "\u1100\u1161", expected: 2},
+		{name: "Devanagari conjunct", text: "क्षि", expected: 2},
+		{name: "CRLF is a single cluster", text: "a\r\nb", expected: 3},
+
+		// Emoji weigh two, whatever their sequence.
+		{name: "Single emoji", text: "👋", expected: 2},
+		{name: "Emoji with text", text: "hola 👋", expected: 7},
+		{name: "Emoji with skin tone", text: "👋🏽", expected: 2},
+		{name: "ZWJ family", text: "👨‍👩‍👧‍👦", expected: 2},
+		{name: "ZWJ profession with skin tone", text: "👩🏾‍🚀", expected: 2},
+		{name: "Flag", text: "🇦🇷", expected: 2},
+		{name: "Subdivision flag", text: "🏴󠁧󠁢󠁳󠁣󠁴󠁿", expected: 2},
+		{name: "Keycap", text: "1️⃣", expected: 2},
+		{name: "Text symbol with emoji presentation", text: "©️", expected: 2},
+		{name: "Text symbol without emoji presentation", text: "©", expected: 1},
+		{name: "Heart with emoji presentation", text: "❤️", expected: 2},
+
+		// URLs count URLLength.
+		{name: "A long URL counts URLLength", text: "see " + longURL, expected: 4 + tweettext.URLLength},
+		{name: "A short URL counts URLLength too", text: "http://a.io", expected: tweettext.URLLength},
+		{name: "Every URL counts", text: "http://a.io http://b.io", expected: 2*tweettext.URLLength + 1},
+		{name: "Trailing punctuation after a URL counts", text: "http://a.io.", expected: tweettext.URLLength + 1},
+		{name: "URL among heavy characters", text: "見て https://go.dev 。", expected: 4 + 1 + tweettext.URLLength + 1 + 2},
+		{name: "URL with non-ASCII path", text: "https://example.com/日本語", expected: tweettext.URLLength},
+
+		// Edges.
+		{name: "Empty", text: "", expected: 0},
+		{name: "Surrounding whitespace is trimmed", text: "  hi\n", expected: 2},
+		{name: "Exactly the maximum", text: strings.Repeat("a", tweettext.MaxLength), expected: tweettext.MaxLength},
+		{name: "Half the maximum in CJK", text: strings.Repeat("字", tweettext.MaxLength/2), expected: tweettext.MaxLength},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			length := tweettext.Length(tc.text)
+
+			// Assert
+			assert.Equal(t, tc.expected, length)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected tweettext.Result
+	}{
+		{
+			name:     "Valid text",
+			text:     "hello",
+			expected: tweettext.Result{Text: "hello", WeightedLength: 5, Valid: true},
+		},
+		{
+			name:     "Blank text is invalid",
+			text:     " \n\t ",
+			expected: tweettext.Result{WeightedLength: 0, Valid: false},
+		},
+		{
+			name:     "Exactly the maximum is valid",
+			text:     strings.Repeat("字", tweettext.MaxLength/2),
+			expected: tweettext.Result{Text: strings.Repeat("字", tweettext.MaxLength/2), WeightedLength: tweettext.MaxLength, Valid: true},
+		},
+		{
+			name:     "Over the maximum is invalid",
+			text:     strings.Repeat("字", tweettext.MaxLength/2) + "a",
+			expected: tweettext.Result{Text: strings.Repeat("字", tweettext.MaxLength/2) + "a", WeightedLength: tweettext.MaxLength + 1, Valid: false},
+		},
+		{
+			name: "URLs have their offsets in the trimmed text",
+			text: "  read https://go.dev ",
+			expected: tweettext.Result{
+				Text:           "read https://go.dev",
+				WeightedLength: 5 + tweettext.URLLength,
+				Valid:          true,
+				URLs:           []domain.URLEntity{{URL: "https://go.dev", Start: 5, End: 19}},
+			},
+		},
+		{
+			name: "Text is returned NFC normalized with URL offsets in it",
+			text: "Cafe\u0301 https://go.dev",
+			expected: tweettext.Result{
+				Text:           "Caf\u00e9 https://go.dev",
+				WeightedLength: 5 + tweettext.URLLength,
+				Valid:          true,
+				URLs:           []domain.URLEntity{{URL: "https://go.dev", Start: 5, End: 19}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tweettext.Parse(tc.text)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
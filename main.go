@@ -1,18 +1,68 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/renzonaitor/tweet-api/cmd/http/config"
 	"github.com/renzonaitor/tweet-api/cmd/http/dependencies"
+	"github.com/renzonaitor/tweet-api/cmd/http/middleware"
 	"github.com/renzonaitor/tweet-api/cmd/http/routes"
+	"github.com/renzonaitor/tweet-api/internal/logging"
+	"github.com/renzonaitor/tweet-api/internal/tracing"
 )
 
 func main() {
 	cfg := config.LoadConfig()
-	dep := dependencies.InitDependencies(cfg)
+
+	logger, err := logging.New(os.Stdout, logging.Config{
+		Level:  cfg.Logging.Level,
+		Format: cfg.Logging.Format,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Libraries logging through the standard logger end up in the same output.
+	slog.SetDefault(logger)
+
+	if err := run(cfg, logger); err != nil {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run serves the API until SIGINT or SIGTERM, then shuts down gracefully. It returns instead of
+// exiting, so the deferred cleanups (connection pools, pending spans) always run.
+func run(cfg config.Config, logger *slog.Logger) error {
+	// Tracing goes first, so the database and Redis clients are instrumented with the exporter.
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	// SIGINT/SIGTERM start the graceful shutdown; the background workers stop with ctx.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	dep := dependencies.InitDependencies(ctx, cfg, logger)
+	defer dep.Close()
 
 	// Create a new ServeMux
 	mux := http.NewServeMux()
@@ -20,18 +70,42 @@ func main() {
 	// Register your routes
 	routes.SetupReadRoutes(mux, dep)  // Assuming you have a function to set up read routes
 	routes.SetupWriteRoutes(mux, dep) // And another for write routes
+	routes.SetupMediaRoutes(mux, dep)
+	routes.SetupStreamRoutes(mux, dep)
+	routes.SetupAdminRoutes(mux, dep)
+	routes.SetupHealthRoutes(mux, dep)
+	routes.SetupMetricsRoutes(mux)
 
 	const port = ":8080"
-	fmt.Printf("Starting server at port %s\n", port)
+	logger.Info("starting server", "port", port)
 
 	server := &http.Server{
 		Addr:    port,
-		Handler: mux,
+		Handler: middleware.Tracing(middleware.RequestID(middleware.AccessLog(logger)(middleware.Metrics(mux)))),
 	}
 
 	// Start the server
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal(err)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
 	}
 
+	// Fail readiness first and give the load balancers time to stop routing to this instance.
+	logger.Info("shutting down, draining traffic", "drain_delay", cfg.Server.DrainDelay)
+	dep.Health.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to finish in-flight requests: %w", err)
+	}
+	logger.Info("server stopped")
+	return nil
 }